@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"weather-reporter/src/internal/cache"
+)
+
+// runCache implements the cache subcommand.
+func runCache(args []string, stdout, stderr io.Writer, dir string) int {
+	if len(args) != 1 || args[0] != "clear" {
+		_, _ = fmt.Fprintln(stderr, "Usage: weather-reporter cache clear")
+		if isHelp(args) {
+			return 0
+		}
+		return 1
+	}
+	if dir == "" {
+		_, _ = fmt.Fprintln(stderr, "Error: no cache directory is available")
+		return 1
+	}
+	if err := cache.Clear(dir); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error clearing cache: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stdout, "Cache cleared: %s\n", dir)
+	return 0
+}
+
+// formatAge describes a duration in the largest whole unit, e.g.
+// "5 minutes" or "2 days".
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "less than a minute"
+	case d < time.Hour:
+		return plural(int(d/time.Minute), "minute")
+	case d < 24*time.Hour:
+		return plural(int(d/time.Hour), "hour")
+	default:
+		return plural(int(d/(24*time.Hour)), "day")
+	}
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
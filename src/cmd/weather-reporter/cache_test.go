@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runWithCache(args []string, cacheDir string, geoClient models.GeocodingService, weatherClient models.WeatherService) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	svc := services{geo: geoClient, weather: weatherClient, isInteractive: notInteractive, cacheDir: cacheDir}
+	code := run(args, strings.NewReader(""), &stdout, &stderr, svc)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_GeocodingCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil).Once()
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	for i := 0; i < 2; i++ {
+		code, stdout, stderr := runWithCache([]string{"Berlin"}, cacheDir, geoClient, weatherClient)
+		require.Equal(t, 0, code, stderr)
+		assert.Contains(t, stdout, "Weather for Berlin, Germany (Berlin)")
+	}
+
+	geoClient.AssertNumberOfCalls(t, "Search", 1)
+}
+
+func TestRun_NoCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	for i := 0; i < 2; i++ {
+		code, _, stderr := runWithCache([]string{"--no-cache", "Berlin"}, cacheDir, geoClient, weatherClient)
+		require.Equal(t, 0, code, stderr)
+	}
+
+	geoClient.AssertNumberOfCalls(t, "Search", 2)
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "--no-cache must not write the cache")
+}
+
+func TestRun_CacheClear(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "weather-reporter")
+	require.NoError(t, os.MkdirAll(filepath.Join(cacheDir, "geocoding"), 0o700))
+
+	code, stdout, _ := runWithCache([]string{"cache", "clear"}, cacheDir, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Cache cleared: "+cacheDir)
+	_, err := os.Stat(cacheDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRun_CacheUsage(t *testing.T) {
+	for _, args := range [][]string{{"cache"}, {"cache", "purge"}} {
+		code, _, stderr := runWithCache(args, t.TempDir(), &mockGeocodingService{}, &mockWeatherService{})
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "Usage: weather-reporter cache clear")
+	}
+}
+
+func TestRun_WeatherCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	geoClient := &mockGeocodingService{}
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		code, stdout, stderr := runWithCache([]string{"52.52,13.41"}, cacheDir, geoClient, weatherClient)
+		require.Equal(t, 0, code, stderr)
+		assert.Contains(t, stdout, "Temperature:          20.0°C")
+	}
+
+	weatherClient.AssertNumberOfCalls(t, "GetCurrentWeather", 1)
+}
+
+// redirectTransport sends every request to the server at target.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme, req.URL.Host = rt.target.Scheme, rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRun_ReverseGeocodingCache(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"lat": "52.52", "lon": "13.41", "address": {"city": "Berlin", "country": "Germany"}}`))
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+	svc := services{
+		geo:           &mockGeocodingService{},
+		weather:       weatherClient,
+		nominatim:     geo.NewNominatimClient(&http.Client{Transport: redirectTransport{target}}),
+		isInteractive: notInteractive,
+		breakers:      newBreakers(),
+		cacheDir:      t.TempDir(),
+	}
+
+	for range 2 {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"52.52,13.41"}, strings.NewReader(""), &stdout, &stderr, svc)
+		require.Equal(t, 0, code, stderr.String())
+		assert.Contains(t, stdout.String(), "Near Berlin, Germany")
+	}
+
+	assert.Equal(t, int32(1), requests.Load(), "the second lookup is served from the cache")
+}
+
+func TestRun_ReverseGeocodingFailureKeepsSearchesGoing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+	svc := services{
+		geo:           geoClient,
+		weather:       weatherClient,
+		nominatim:     geo.NewNominatimClient(&http.Client{Transport: redirectTransport{target}}),
+		isInteractive: notInteractive,
+		breakers:      newBreakers(),
+	}
+
+	for _, args := range [][]string{{"52.52,13.41"}, {"Berlin"}} {
+		var stdout, stderr bytes.Buffer
+		code := run(args, strings.NewReader(""), &stdout, &stderr, svc)
+		require.Equal(t, 0, code, stderr.String())
+	}
+
+	assert.Equal(t, 1, svc.breakers.reverse.Snapshot().ConsecutiveFailures)
+	assert.Equal(t, 0, svc.breakers.geo.Snapshot().ConsecutiveFailures, "Nominatim failures do not count against name searches")
+}
+
+func TestRun_WeatherCacheStaleFallback(t *testing.T) {
+	cacheDir := t.TempDir()
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil).Once()
+	code, _, stderr := runWithCache([]string{"52.52,13.41"}, cacheDir, &mockGeocodingService{}, weatherClient)
+	require.Equal(t, 0, code, stderr)
+
+	ageCacheEntries(t, filepath.Join(cacheDir, "weather"), 3*time.Hour)
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(nil, &weather.RequestError{Kind: weather.ErrNetwork, Err: errors.New("request failed: network is unreachable")})
+
+	code, stdout, stderr := runWithCache([]string{"52.52,13.41"}, cacheDir, &mockGeocodingService{}, weatherClient)
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Temperature:          20.0°C")
+	assert.Contains(t, stderr, "Warning: request failed: network is unreachable")
+	assert.Contains(t, stderr, "Showing cached data from 3 hours ago")
+}
+
+func TestRun_WeatherCacheRejectedRequestIsNotMasked(t *testing.T) {
+	cacheDir := t.TempDir()
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil).Once()
+	code, _, stderr := runWithCache([]string{"52.52,13.41"}, cacheDir, &mockGeocodingService{}, weatherClient)
+	require.Equal(t, 0, code, stderr)
+
+	ageCacheEntries(t, filepath.Join(cacheDir, "weather"), 3*time.Hour)
+	rejected := &weather.RequestError{Kind: weather.ErrBadRequest, StatusCode: 400, Err: errors.New("API returned status 400: Cannot initialize WindSpeedUnit from invalid String value kmh")}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(nil, rejected)
+
+	code, stdout, stderr := runWithCache([]string{"52.52,13.41"}, cacheDir, &mockGeocodingService{}, weatherClient)
+
+	assert.Equal(t, exitFailure, code)
+	assert.Empty(t, stdout)
+	assert.Equal(t, "Error fetching weather: API returned status 400: Cannot initialize WindSpeedUnit from invalid String value kmh\n", stderr)
+}
+
+func TestRun_MCPDoesNotServeStaleWeather(t *testing.T) {
+	cacheDir := t.TempDir()
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil).Once()
+	code, _, stderr := runWithCache([]string{"52.52,13.41"}, cacheDir, &mockGeocodingService{}, weatherClient)
+	require.Equal(t, 0, code, stderr)
+
+	ageCacheEntries(t, filepath.Join(cacheDir, "weather"), 3*time.Hour)
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(nil, &weather.RequestError{Kind: weather.ErrNetwork, Err: errors.New("request failed: network is unreachable")})
+
+	script := `{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_current_weather","arguments":{"location":"52.52,13.41"}}}` + "\n"
+	svc := services{geo: &mockGeocodingService{}, weather: weatherClient, isInteractive: notInteractive, cacheDir: cacheDir}
+	var stdout, stderrBuf bytes.Buffer
+	code = run([]string{"mcp"}, strings.NewReader(script), &stdout, &stderrBuf, svc)
+
+	require.Equal(t, 0, code, stderrBuf.String())
+	var resp struct {
+		Result struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+			IsError bool `json:"isError"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &resp), stdout.String())
+	assert.True(t, resp.Result.IsError, "stale data is not served to clients that cannot tell")
+	assert.Contains(t, resp.Result.Content[0].Text, "network is unreachable")
+	assert.Empty(t, stderrBuf.String())
+}
+
+// ageCacheEntries moves the stored_at time of every entry in dir into the
+// past.
+func ageCacheEntries(t *testing.T, dir string, age time.Duration) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		require.NoError(t, err)
+		var e map[string]any
+		require.NoError(t, json.Unmarshal(data, &e))
+		e["stored_at"] = time.Now().Add(-age).Add(-time.Minute).UTC().Format(time.RFC3339Nano)
+		data, err = json.Marshal(e)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(f, data, 0o600))
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "less than a minute", formatAge(30*time.Second))
+	assert.Equal(t, "1 minute", formatAge(90*time.Second))
+	assert.Equal(t, "45 minutes", formatAge(45*time.Minute))
+	assert.Equal(t, "2 hours", formatAge(150*time.Minute))
+	assert.Equal(t, "3 days", formatAge(80*time.Hour))
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"weather-reporter/src/internal/config"
+)
+
+// app carries what every command needs: the standard streams, the
+// services and, once loaded, the configuration.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	svc    services
+	cfg    config.Config
+}
+
+// command is a subcommand of the CLI.
+type command struct {
+	name    string
+	args    string // argument synopsis shown in the usage line
+	summary string
+	// needsConfig loads the configuration and creates the API clients
+	// before run is called.
+	needsConfig bool
+	run         func(a *app, args []string) int
+}
+
+// commands returns the subcommands in the order they are listed in the
+// help text.
+func commands() []command {
+	return []command{
+		{name: "now", args: "[flags] <location | lat,lon | @alias>", summary: "Show the current weather for a location", needsConfig: true, run: runNow},
+		{name: "forecast", args: "[flags] <location | lat,lon | @alias>", summary: "Show a daily or hourly forecast for a location", needsConfig: true, run: runForecast},
+		{name: "search", args: "[flags] <name>", summary: "List the locations matching a name", needsConfig: true, run: runSearch},
+		{name: "serve", args: "[flags]", summary: "Serve weather and location search as a JSON HTTP API, and optionally over gRPC", needsConfig: true, run: runServe},
+		{name: "mcp", args: "[flags]", summary: "Serve weather tools to AI assistants over the Model Context Protocol on stdio", needsConfig: true, run: runMCP},
+		{name: "favorites", args: "add|list|remove|rename", summary: "Manage saved locations", needsConfig: true, run: func(a *app, args []string) int {
+			return runFavorites(args, a.stdin, a.stdout, a.stderr, a.svc, a.cfg)
+		}},
+		// config loads the configuration itself so that it can be used to
+		// repair a broken config file.
+		{name: "config", args: "show|get|set|path", summary: "Show or change settings", run: func(a *app, args []string) int {
+			return runConfig(args, a.stdout, a.stderr, a.svc)
+		}},
+		{name: "cache", args: "clear", summary: "Manage the on-disk cache", run: func(a *app, args []string) int {
+			return runCache(args, a.stdout, a.stderr, a.svc.cacheDir)
+		}},
+		{name: "version", summary: "Print version information", run: runVersion},
+		{name: "help", args: "[command]", summary: "Show help for a command", run: runHelp},
+	}
+}
+
+// lookupCommand returns the subcommand called name.
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands() {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+// legacyCommand is run when the first argument is not a command name. It
+// keeps "weather-reporter [flags] <location>" working as an alias for now,
+// including the forecast flags it accepted before subcommands existed.
+func legacyCommand() command {
+	return command{
+		args:        "[flags] <location | lat,lon | @alias>",
+		summary:     "Show the current weather, or a forecast with --days, --hourly or --hours",
+		needsConfig: true,
+		run:         runLegacy,
+	}
+}
+
+// dispatch runs the command selected by args.
+func (a *app) dispatch(args []string) int {
+	cmd, rest := legacyCommand(), args
+	if len(args) > 0 {
+		if c, ok := lookupCommand(args[0]); ok {
+			cmd, rest = c, args[1:]
+		}
+	}
+
+	if cmd.needsConfig {
+		loaded, err := a.svc.loadConfig()
+		if err != nil {
+			_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+			return 1
+		}
+		a.cfg = loaded.Config
+		a.svc = a.svc.configure(a.cfg)
+	}
+	return cmd.run(a, rest)
+}
+
+// globalFlags are accepted by every command that contacts the APIs.
+type globalFlags struct {
+	noCache bool
+	timeout time.Duration
+	verbose bool
+}
+
+// register defines the global flags on fs, with defaults taken from cfg.
+func (g *globalFlags) register(fs *flag.FlagSet, cfg config.Config) {
+	fs.BoolVar(&g.noCache, "no-cache", !cfg.Cache, "Do not read or write the on-disk cache")
+	fs.DurationVar(&g.timeout, "timeout", cfg.Timeout, "Maximum time for the whole command")
+	fs.BoolVar(&g.verbose, "verbose", false, "Show the cause of failed requests")
+}
+
+// validate checks the global flag values.
+func (g *globalFlags) validate() error {
+	if g.timeout <= 0 {
+		return errors.New("--timeout must be positive")
+	}
+	return nil
+}
+
+// newFlagSet creates the flag set of cmd with the global flags defined.
+// Its -h output is the command's help text.
+func (a *app) newFlagSet(cmd command) (*flag.FlagSet, *globalFlags) {
+	name := "weather-reporter"
+	if cmd.name != "" {
+		name += " " + cmd.name
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.Usage = func() {
+		out := fs.Output()
+		_, _ = fmt.Fprintf(out, "Usage: %s %s\n", name, cmd.args)
+		if cmd.summary != "" {
+			_, _ = fmt.Fprintf(out, "\n%s.\n", cmd.summary)
+		}
+		_, _ = fmt.Fprintln(out, "\nFlags:")
+		printDefaults(fs)
+	}
+
+	var g globalFlags
+	g.register(fs, a.cfg)
+	return fs, &g
+}
+
+// aliasFlag is the value of a flag that is another name for a documented
+// flag. printDefaults leaves it out.
+type aliasFlag struct {
+	flag.Value
+}
+
+// printDefaults prints the flags of fs like fs.PrintDefaults, without the
+// aliases.
+func printDefaults(fs *flag.FlagSet) {
+	shown := flag.NewFlagSet(fs.Name(), flag.ContinueOnError)
+	shown.SetOutput(fs.Output())
+	fs.VisitAll(func(f *flag.Flag) {
+		if _, ok := f.Value.(aliasFlag); ok {
+			return
+		}
+		shown.Var(f.Value, f.Name, f.Usage)
+		shown.Lookup(f.Name).DefValue = f.DefValue
+	})
+	shown.PrintDefaults()
+}
+
+// parseFlags parses args with fs. It returns false and the exit code if
+// the command should stop: 0 after -h, 1 after an invalid flag.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	err := fs.Parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return 0, false
+	}
+	if err != nil {
+		return 1, false
+	}
+	return 0, true
+}
+
+// printUsage prints the top-level help text.
+func printUsage(out io.Writer) {
+	_, _ = fmt.Fprintln(out, "Usage: weather-reporter <command> [flags] [arguments]")
+	_, _ = fmt.Fprintln(out, "       weather-reporter [flags] <location | lat,lon | @alias>   (same as \"now\")")
+	_, _ = fmt.Fprintln(out, "\nCommands:")
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, c := range commands() {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", c.name, c.summary)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintln(out, "\nGlobal flags, accepted by now, forecast, search, serve and mcp:")
+	_, _ = fmt.Fprintln(out, "  --no-cache     Do not read or write the on-disk cache")
+	_, _ = fmt.Fprintln(out, "  --timeout D    Maximum time for the whole command, e.g. 30s")
+	_, _ = fmt.Fprintln(out, "  --verbose      Show the cause of failed requests")
+	_, _ = fmt.Fprintln(out, "\nRun 'weather-reporter help <command>' for the flags of a command.")
+}
+
+// runHelp implements the help command.
+func runHelp(a *app, args []string) int {
+	if len(args) == 0 {
+		printUsage(a.stdout)
+		return 0
+	}
+	if isHelp(args) {
+		_, _ = fmt.Fprintln(a.stderr, "Usage: weather-reporter help [command]")
+		return 0
+	}
+	cmd, ok := lookupCommand(args[0])
+	if !ok {
+		_, _ = fmt.Fprintf(a.stderr, "Error: unknown command %q\n", args[0])
+		printUsage(a.stderr)
+		return 1
+	}
+
+	// Every command prints its help on stderr for -h; show it on stdout
+	// when it was asked for explicitly.
+	helpApp := *a
+	helpApp.stderr = a.stdout
+	if cmd.needsConfig {
+		helpApp.cfg = config.Default()
+	}
+	return cmd.run(&helpApp, []string{"-h"})
+}
+
+// runVersion implements the version command.
+func runVersion(a *app, args []string) int {
+	if len(args) > 0 {
+		_, _ = fmt.Fprintln(a.stderr, "Usage: weather-reporter version")
+		if isHelp(args) {
+			return 0
+		}
+		return 1
+	}
+	printVersion(a.stdout)
+	return 0
+}
+
+func printVersion(out io.Writer) {
+	_, _ = fmt.Fprintf(out, "weather-reporter version %s\n", Version)
+	_, _ = fmt.Fprintf(out, "commit: %s\n", Commit)
+	_, _ = fmt.Fprintf(out, "built at: %s\n", Date)
+}
+
+// isHelp reports whether args asks for a command's help text.
+func isHelp(args []string) bool {
+	return len(args) == 1 && (args[0] == "-h" || args[0] == "-help" || args[0] == "--help")
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRun_NowCommand(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.ImperialUnits()).Return(stubWeatherResponse{}, nil)
+
+	code, stdout, stderr := runWith([]string{"now", "--units", "imperial", "Berlin"}, geoClient, weatherClient)
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Weather for Berlin, Germany (Berlin)")
+	weatherClient.AssertExpectations(t)
+}
+
+func TestRun_NowRejectsForecastFlags(t *testing.T) {
+	code, _, stderr := runWith([]string{"now", "--days", "3", "Berlin"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "flag provided but not defined: -days")
+}
+
+func TestRun_ForecastCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		method string
+		n      int
+	}{
+		{name: "Default Days", args: []string{"forecast", "52.52,13.41"}, method: "GetDailyForecast", n: defaultForecastDays},
+		{name: "Days", args: []string{"forecast", "--days", "3", "52.52,13.41"}, method: "GetDailyForecast", n: 3},
+		{name: "Hourly", args: []string{"forecast", "--hourly", "52.52,13.41"}, method: "GetHourlyForecast", n: defaultForecastHours},
+		{name: "Hours", args: []string{"forecast", "--hours", "6", "--lat", "52.52", "--lon", "13.41"}, method: "GetHourlyForecast", n: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weatherClient := &mockWeatherService{}
+			if tt.method == "GetDailyForecast" {
+				weatherClient.On(tt.method, mock.Anything, 52.52, 13.41, tt.n, models.MetricUnits()).Return([]models.DailyForecast{}, nil)
+			} else {
+				weatherClient.On(tt.method, mock.Anything, 52.52, 13.41, tt.n, models.MetricUnits()).Return([]models.HourlyForecast{}, nil)
+			}
+
+			code, _, stderr := runWith(tt.args, &mockGeocodingService{}, weatherClient)
+
+			assert.Equal(t, 0, code, stderr)
+			weatherClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_ForecastRejectsJSON(t *testing.T) {
+	code, _, stderr := runWith([]string{"forecast", "--output", "json", "Berlin"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "forecasts are only available as text")
+}
+
+func TestRun_SearchCommand(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+
+	code, stdout, stderr := runWith([]string{"search", "Berlin"}, geoClient, weatherClient)
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "2950159  Berlin  Germany  DE    Berlin  52.5200   13.4100")
+	weatherClient.AssertNotCalled(t, "GetCurrentWeather", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRun_VersionCommand(t *testing.T) {
+	code, stdout, _ := runWith([]string{"version"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "weather-reporter version dev")
+}
+
+func TestRun_Help(t *testing.T) {
+	code, stdout, _ := runWith([]string{"help"}, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 0, code)
+	for _, c := range commands() {
+		assert.Contains(t, stdout, "  "+c.name+" ")
+	}
+
+	code, stdout, _ = runWith([]string{"help", "forecast"}, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Usage: weather-reporter forecast [flags]")
+	assert.Contains(t, stdout, "-hourly")
+	assert.Contains(t, stdout, "-no-cache")
+
+	for _, name := range []string{"favorites", "config", "cache", "search", "now", "version", "help"} {
+		code, _, stderr := runWith([]string{"help", name}, &mockGeocodingService{}, &mockWeatherService{})
+		assert.Equal(t, 0, code, name)
+		assert.Empty(t, stderr, name)
+	}
+
+	code, _, stderr := runWith([]string{"help", "weather"}, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `unknown command "weather"`)
+}
+
+func TestRun_CommandHelpFlag(t *testing.T) {
+	code, _, stderr := runWith([]string{"now", "-h"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stderr, "Usage: weather-reporter now [flags] <location | lat,lon | @alias>")
+	assert.Contains(t, stderr, "-units")
+}
+
+func TestRun_LocationNamedLikeCommand(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "search", mock.Anything).Return([]models.Location{}, nil)
+
+	code, stdout, _ := runWith([]string{"--", "search"}, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Location not found: search")
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"weather-reporter/src/internal/config"
+)
+
+const configUsage = `Usage: weather-reporter config show
+       weather-reporter config get <key>
+       weather-reporter config set <key> <value>
+       weather-reporter config path`
+
+// runConfig implements the config subcommand.
+func runConfig(args []string, stdout, stderr io.Writer, svc services) int {
+	if isHelp(args) {
+		printConfigUsage(stderr)
+		return 0
+	}
+
+	var err error
+	switch {
+	case len(args) == 1 && args[0] == "show":
+		err = showConfig(stdout, svc)
+	case len(args) == 2 && args[0] == "get":
+		err = getConfig(stdout, svc, args[1])
+	case len(args) == 3 && args[0] == "set":
+		err = setConfig(stdout, svc, args[1], args[2])
+	case len(args) == 1 && args[0] == "path":
+		err = printConfigPath(stdout, svc)
+	default:
+		printConfigUsage(stderr)
+		return 1
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printConfigUsage prints the config command usage and the available
+// settings.
+func printConfigUsage(out io.Writer) {
+	_, _ = fmt.Fprintln(out, configUsage)
+	_, _ = fmt.Fprintln(out, "\nSettings:")
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, key := range config.Keys() {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", key, config.Help(key))
+	}
+	_ = tw.Flush()
+}
+
+// showConfig prints every setting with its effective value and where the
+// value came from.
+func showConfig(stdout io.Writer, svc services) error {
+	loaded, err := svc.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, key := range config.Keys() {
+		value, _ := loaded.Get(key)
+		if value == "" {
+			value = `""`
+		}
+		source := string(loaded.Sources[key])
+		if loaded.Sources[key] == config.SourceEnv {
+			source += " (" + config.EnvVar(key) + ")"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", key, value, source)
+	}
+	return tw.Flush()
+}
+
+// getConfig prints the effective value of one setting.
+func getConfig(stdout io.Writer, svc services, key string) error {
+	loaded, err := svc.loadConfig()
+	if err != nil {
+		return err
+	}
+	value, err := loaded.Get(key)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(stdout, value)
+	return err
+}
+
+// setConfig validates a setting and saves it in the config file.
+func setConfig(stdout io.Writer, svc services, key, value string) error {
+	if svc.configPath == "" {
+		return errors.New("no config directory is available")
+	}
+	if err := config.Set(svc.configPath, key, value); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(stdout, "Set %s = %s in %s\n", key, value, svc.configPath)
+	return err
+}
+
+// printConfigPath prints the location of the config file.
+func printConfigPath(stdout io.Writer, svc services) error {
+	if svc.configPath == "" {
+		return errors.New("no config directory is available")
+	}
+	_, err := fmt.Fprintln(stdout, svc.configPath)
+	return err
+}
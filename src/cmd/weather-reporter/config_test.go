@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/retry"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runWithConfig(args []string, configPath string, env map[string]string, svc services) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	svc.configPath = configPath
+	svc.lookupEnv = func(k string) (string, bool) {
+		v, ok := env[k]
+		return v, ok
+	}
+	if svc.isInteractive == nil {
+		svc.isInteractive = notInteractive
+	}
+	code := run(args, strings.NewReader(""), &stdout, &stderr, svc)
+	return code, stdout.String(), stderr.String()
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestRun_ConfigPrecedence(t *testing.T) {
+	path := writeConfigFile(t, "units: imperial\ntemperature_unit: celsius\n")
+
+	tests := []struct {
+		name  string
+		env   map[string]string
+		args  []string
+		units models.Units
+	}{
+		{name: "File", args: []string{"52.52,13.41"}, units: models.Units{Temperature: models.Celsius, WindSpeed: models.MilesPerHour, Precipitation: models.Inches}},
+		{name: "Env Over File", env: map[string]string{"WEATHER_REPORTER_TEMPERATURE_UNIT": "fahrenheit"}, args: []string{"52.52,13.41"}, units: models.ImperialUnits()},
+		{name: "Flag Over Env", env: map[string]string{"WEATHER_REPORTER_UNITS": "metric"}, args: []string{"--units", "imperial", "--temp-unit", "f", "52.52,13.41"}, units: models.ImperialUnits()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			weatherClient := &mockWeatherService{}
+			weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, tt.units).Return(stubWeatherResponse{}, nil)
+
+			code, _, stderr := runWithConfig(tt.args, path, tt.env, services{geo: &mockGeocodingService{}, weather: weatherClient})
+
+			assert.Equal(t, 0, code, stderr)
+			weatherClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_ConfigCreatesClients(t *testing.T) {
+	path := writeConfigFile(t, "http_timeout: 3s\nsearch_count: 4\nlanguage: de\n")
+	var got config.Config
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+	svc := services{newClients: func(cfg config.Config, s *services) {
+		got = cfg
+		s.geo = &mockGeocodingService{}
+		s.weather = weatherClient
+	}}
+
+	code, _, stderr := runWithConfig([]string{"52.52,13.41"}, path, nil, svc)
+
+	require.Equal(t, 0, code, stderr)
+	assert.Equal(t, 3*time.Second, got.HTTPTimeout)
+	assert.Equal(t, 4, got.SearchCount)
+	assert.Equal(t, "de", got.Language)
+}
+
+func TestRetryPolicy(t *testing.T) {
+	cfg := config.Default()
+	cfg.RetryAttempts = 5
+	cfg.RetryBackoff = time.Second
+	cfg.HTTPTimeout = 3 * time.Second
+
+	policy := retryPolicy(cfg)
+
+	assert.Equal(t, 5, policy.Attempts)
+	assert.Equal(t, time.Second, policy.Backoff)
+	assert.Equal(t, 3*time.Second, policy.AttemptTimeout)
+	assert.Equal(t, retry.DefaultPolicy.MaxDelay, policy.MaxDelay)
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	path := writeConfigFile(t, "search_count: many\n")
+
+	code, _, stderr := runWithConfig([]string{"Berlin"}, path, nil, services{geo: &mockGeocodingService{}, weather: &mockWeatherService{}})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "Error: config file "+path+`: search_count: must be a whole number between 1 and 100, got "many"`)
+}
+
+func TestRun_ConfigCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weather-reporter", "config.yaml")
+	svc := services{}
+
+	code, stdout, _ := runWithConfig([]string{"config", "path"}, path, nil, svc)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, path+"\n", stdout)
+
+	code, stdout, stderr := runWithConfig([]string{"config", "set", "units", "imperial"}, path, nil, svc)
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Set units = imperial")
+
+	code, stdout, _ = runWithConfig([]string{"config", "get", "units"}, path, nil, svc)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "imperial\n", stdout)
+
+	code, stdout, _ = runWithConfig([]string{"config", "show"}, path, map[string]string{"WEATHER_REPORTER_TIMEOUT": "1m"}, svc)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "units               imperial  file\n")
+	assert.Contains(t, stdout, "timeout             1m0s      env (WEATHER_REPORTER_TIMEOUT)\n")
+	assert.Contains(t, stdout, "search_count        10        default\n")
+	assert.Contains(t, stdout, `temperature_unit    ""        default`)
+}
+
+func TestRun_ConfigErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "Invalid Value", args: []string{"config", "set", "timeout", "-1s"}, want: "timeout: must be a positive duration"},
+		{name: "Unknown Key", args: []string{"config", "get", "colour"}, want: `unknown setting "colour"`},
+		{name: "Usage", args: []string{"config"}, want: "Usage: weather-reporter config show"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, stderr := runWithConfig(tt.args, filepath.Join(t.TempDir(), "config.yaml"), nil, services{})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, tt.want)
+		})
+	}
+}
+
+func TestRun_ConfigSetFixesBrokenFile(t *testing.T) {
+	path := writeConfigFile(t, "output: xml\n")
+
+	code, _, stderr := runWithConfig([]string{"config", "set", "output", "json"}, path, nil, services{})
+
+	assert.Equal(t, 0, code, stderr)
+	loaded, err := config.Load(path, func(string) (string, bool) { return "", false })
+	require.NoError(t, err)
+	assert.Equal(t, "json", loaded.Output)
+}
+
+func TestRun_TimeoutFlag(t *testing.T) {
+	code, _, stderr := runWithConfig([]string{"--timeout", "0s", "Berlin"}, "", nil, services{geo: &mockGeocodingService{}, weather: &mockWeatherService{}})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "--timeout must be positive")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/geo"
+)
+
+// Exit codes of run. Failed location searches exit with a code for their
+// cause, so that scripts can tell a failure worth retrying from a search
+// that will never succeed.
+const (
+	exitFailure     = 1 // invalid arguments and any other error
+	exitNetwork     = 3 // the geocoding service could not be reached
+	exitTimeout     = 4 // the search did not finish within --timeout
+	exitRateLimited = 5 // too many requests; retry later
+	exitUpstream    = 6 // the geocoding service failed
+	exitBadRequest  = 7 // the geocoding service rejected the search
+	exitUnavailable = 8 // a circuit breaker is open after repeated failures
+)
+
+// searchExitCode returns the exit code for a failed location search.
+func searchExitCode(err error) int {
+	switch {
+	case errors.Is(err, breaker.ErrOpen):
+		return exitUnavailable
+	case errors.Is(err, geo.ErrNetwork):
+		return exitNetwork
+	case errors.Is(err, geo.ErrTimeout):
+		return exitTimeout
+	case errors.Is(err, geo.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, geo.ErrUpstream):
+		return exitUpstream
+	case errors.Is(err, geo.ErrBadRequest):
+		return exitBadRequest
+	}
+	return exitFailure
+}
+
+// weatherExitCode returns the exit code for a failed weather request.
+func weatherExitCode(err error) int {
+	if errors.Is(err, breaker.ErrOpen) {
+		return exitUnavailable
+	}
+	return exitFailure
+}
+
+// searchFailed prints a failed location search to stderr, followed by
+// its cause when verbose is set, and returns the exit code for it.
+func searchFailed(stderr io.Writer, err error, verbose bool) int {
+	_, _ = fmt.Fprintf(stderr, "Error searching for location: %v\n", err)
+	var searchErr *geo.SearchError
+	if verbose && errors.As(err, &searchErr) {
+		_, _ = fmt.Fprintf(stderr, "Cause: %s\n", searchErr.Detail())
+	}
+	return searchExitCode(err)
+}
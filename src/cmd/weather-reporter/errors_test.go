@@ -0,0 +1,168 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_SearchErrorExitCodes(t *testing.T) {
+	tests := []struct {
+		kind error
+		code int
+	}{
+		{kind: geo.ErrNetwork, code: exitNetwork},
+		{kind: geo.ErrTimeout, code: exitTimeout},
+		{kind: geo.ErrRateLimited, code: exitRateLimited},
+		{kind: geo.ErrConcurrencyLimit, code: exitFailure},
+		{kind: geo.ErrUpstream, code: exitUpstream},
+		{kind: geo.ErrBadRequest, code: exitBadRequest},
+		{kind: geo.ErrCanceled, code: exitFailure},
+		{kind: nil, code: exitFailure},
+	}
+
+	for _, tt := range tests {
+		searchErr := &geo.SearchError{Kind: tt.kind, Err: errors.New("boom")}
+		for _, args := range [][]string{{"Berlin"}, {"search", "Berlin"}} {
+			geoClient := &mockGeocodingService{}
+			geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return(nil, searchErr)
+
+			code, _, stderr := runWith(args, geoClient, &mockWeatherService{})
+
+			assert.Equal(t, tt.code, code, "%v %v", args, tt.kind)
+			assert.Contains(t, stderr, "Error searching for location: "+searchErr.Error())
+			assert.NotContains(t, stderr, "boom", "details need --verbose")
+		}
+	}
+}
+
+func TestRun_Verbose(t *testing.T) {
+	searchErr := &geo.SearchError{Kind: geo.ErrRateLimited, StatusCode: 429, Err: errors.New("api error: Too many requests")}
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return(nil, searchErr)
+
+	code, _, stderr := runWith([]string{"now", "--verbose", "Berlin"}, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, exitRateLimited, code)
+	assert.Equal(t, ""+
+		"Error searching for location: too many location searches, please wait a moment and try again\n"+
+		"Cause: rate limited (HTTP 429): api error: Too many requests\n", stderr)
+}
+
+func TestRun_CircuitBreaker(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(nil, errors.New("service unavailable"))
+	svc := services{geo: geoClient, weather: weatherClient, isInteractive: notInteractive, breakers: newBreakers()}
+
+	runOnce := func() (int, string) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"Berlin"}, strings.NewReader(""), &stdout, &stderr, svc)
+		return code, stderr.String()
+	}
+
+	for range 5 {
+		code, stderr := runOnce()
+		assert.Equal(t, exitFailure, code)
+		assert.Contains(t, stderr, "service unavailable")
+	}
+	code, stderr := runOnce()
+
+	assert.Equal(t, exitUnavailable, code)
+	assert.Equal(t, "Error fetching weather: weather service is unavailable after repeated failures, retrying in 30s\n", stderr)
+	weatherClient.AssertNumberOfCalls(t, "GetCurrentWeather", 5)
+	assert.Equal(t, "open", svc.breakers.weather.State().String())
+	assert.Equal(t, "closed", svc.breakers.geo.State().String())
+}
+
+func TestRun_CircuitBreakerGeocoding(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Nowhere", mock.Anything).Return(nil, &geo.SearchError{Kind: geo.ErrBadRequest, Err: errors.New("bad")})
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return(nil, &geo.SearchError{Kind: geo.ErrUpstream, Err: errors.New("502")})
+	svc := services{geo: geoClient, weather: &mockWeatherService{}, isInteractive: notInteractive, breakers: newBreakers()}
+
+	runOnce := func(name string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		code := run([]string{"search", name}, strings.NewReader(""), &stdout, &stderr, svc)
+		return code, stderr.String()
+	}
+
+	for range 10 {
+		code, _ := runOnce("Nowhere")
+		assert.Equal(t, exitBadRequest, code, "rejected searches do not open the breaker")
+	}
+	for range 5 {
+		code, _ := runOnce("Berlin")
+		assert.Equal(t, exitUpstream, code)
+	}
+	code, stderr := runOnce("Berlin")
+
+	assert.Equal(t, exitUnavailable, code)
+	assert.Contains(t, stderr, "Error searching for location: geocoding service is unavailable after repeated failures")
+	geoClient.AssertNumberOfCalls(t, "Search", 15)
+}
+
+func TestBreakers_IgnoreConcurrencyLimit(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	target, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	// The clients keep their default base URLs, so that all calls share the
+	// SDKs' concurrency limit of 10 requests in flight.
+	httpClient := &http.Client{Transport: redirectTransport{target}}
+	svc := services{geo: geo.NewClient(httpClient), weather: weather.NewClient(httpClient), breakers: newBreakers()}
+	geoClient, weatherClient := svc.geocoder(true), svc.weatherService(true, nil)
+
+	const calls = 16
+	errs := make(chan error, 2*calls)
+	var wg sync.WaitGroup
+	for range calls {
+		wg.Go(func() {
+			_, err := geoClient.Search(t.Context(), "Berlin", models.SearchOptions{})
+			errs <- err
+		})
+		wg.Go(func() {
+			_, err := weatherClient.GetCurrentWeather(t.Context(), 52.52, 13.41, models.MetricUnits())
+			errs <- err
+		})
+	}
+	// The calls beyond the limit fail at once, while the others wait for
+	// the server.
+	var geoRejected, weatherRejected int
+	for geoRejected+weatherRejected < 2*(calls-10) {
+		err := <-errs
+		switch {
+		case errors.Is(err, geo.ErrConcurrencyLimit):
+			geoRejected++
+		case errors.Is(err, weather.ErrConcurrencyLimit):
+			weatherRejected++
+		default:
+			t.Fatalf("unexpected result before the server answered: %v", err)
+		}
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, calls-10, geoRejected)
+	assert.Equal(t, calls-10, weatherRejected)
+	assert.Equal(t, "closed", svc.breakers.geo.State().String())
+	assert.Equal(t, "closed", svc.breakers.weather.State().String())
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/favorites"
+	"weather-reporter/src/internal/ui"
+)
+
+const favoritesUsage = `Usage: weather-reporter favorites add <alias> <location | lat,lon>
+       weather-reporter favorites list
+       weather-reporter favorites remove <alias>
+       weather-reporter favorites rename <alias> <new-alias>`
+
+// loadFavorites opens the favorites store at path.
+func loadFavorites(path string) (*favorites.Store, error) {
+	if path == "" {
+		return nil, errors.New("no config directory is available for favorites")
+	}
+	return favorites.Load(path)
+}
+
+// runFavorites implements the favorites subcommand.
+func runFavorites(args []string, stdin io.Reader, stdout, stderr io.Writer, svc services, cfg config.Config) int {
+	if isHelp(args) {
+		_, _ = fmt.Fprintln(stderr, favoritesUsage)
+		return 0
+	}
+	if len(args) == 0 {
+		_, _ = fmt.Fprintln(stderr, favoritesUsage)
+		return 1
+	}
+
+	store, err := loadFavorites(svc.favoritesPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	switch cmd, rest := args[0], args[1:]; {
+	case cmd == "list" && len(rest) == 0:
+		if err := ui.PrintFavorites(stdout, store.List()); err != nil {
+			_, _ = fmt.Fprintf(stderr, "Error printing favorites: %v\n", err)
+			return 1
+		}
+		return 0
+	case cmd == "add" && len(rest) >= 2:
+		return addFavorite(store, rest[0], strings.Join(rest[1:], " "), stdin, stdout, stderr, svc, cfg)
+	case cmd == "remove" && len(rest) == 1:
+		return saveFavorites(store, store.Remove(rest[0]), stdout, stderr, "Removed @%s\n", favorites.Normalize(rest[0]))
+	case cmd == "rename" && len(rest) == 2:
+		return saveFavorites(store, store.Rename(rest[0], rest[1]), stdout, stderr, "Renamed @%s to @%s\n", favorites.Normalize(rest[0]), favorites.Normalize(rest[1]))
+	default:
+		_, _ = fmt.Fprintln(stderr, favoritesUsage)
+		return 1
+	}
+}
+
+// addFavorite resolves query like a normal lookup, prompting when there
+// are several matches, and saves the chosen location under alias. The
+// alias is checked first, so that an unusable one fails before any search
+// or prompt.
+func addFavorite(store *favorites.Store, alias, query string, stdin io.Reader, stdout, stderr io.Writer, svc services, cfg config.Config) int {
+	if err := store.CheckAvailable(alias); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if favorites.IsAlias(query) {
+		_, _ = fmt.Fprintln(stderr, "Error: a favorite cannot refer to another favorite")
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	loc, code, ok := svc.locator(stdin, stdout, stderr, stdout, !cfg.Cache).locate(ctx, query)
+	if !ok {
+		return code
+	}
+	return saveFavorites(store, store.Add(alias, loc), stdout, stderr, "Saved @%s: %s\n", favorites.Normalize(alias), loc.Name)
+}
+
+// saveFavorites writes the store if the preceding change succeeded and
+// prints the confirmation message.
+func saveFavorites(store *favorites.Store, changeErr error, stdout, stderr io.Writer, format string, args ...any) int {
+	if changeErr != nil {
+		_, _ = fmt.Fprintf(stderr, "Error: %v\n", changeErr)
+		return 1
+	}
+	if err := store.Save(); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error saving favorites: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stdout, format, args...)
+	return 0
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"weather-reporter/src/internal/favorites"
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runWithFavorites(args []string, stdin, favoritesPath string, geoClient models.GeocodingService, weatherClient models.WeatherService) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	svc := services{geo: geoClient, weather: weatherClient, isInteractive: func(io.Reader) bool { return stdin != "" }, favoritesPath: favoritesPath}
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr, svc)
+	return code, stdout.String(), stderr.String()
+}
+
+var londonCandidates = []models.Location{
+	{ID: 2643743, Name: "London", Latitude: 51.50853, Longitude: -0.12574, Country: "United Kingdom", Region: "England"},
+	{ID: 6058560, Name: "London", Latitude: 42.98339, Longitude: -81.23304, Country: "Canada", Region: "Ontario"},
+}
+
+func TestRun_FavoritesAddAndUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "London", mock.Anything).Return(londonCandidates, nil).Once()
+
+	code, stdout, stderr := runWithFavorites([]string{"favorites", "add", "office", "London"}, "2\n", path, geoClient, &mockWeatherService{})
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Saved @office: London")
+
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 42.98339, -81.23304, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	code, stdout, stderr = runWithFavorites([]string{"@office"}, "", path, geoClient, weatherClient)
+
+	require.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Weather for London, Canada (Ontario)")
+	geoClient.AssertNumberOfCalls(t, "Search", 1)
+	weatherClient.AssertExpectations(t)
+}
+
+func TestRun_FavoritesListRenameRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	store, err := favorites.Load(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Add("office", londonCandidates[0]))
+	require.NoError(t, store.Save())
+
+	code, stdout, _ := runWithFavorites([]string{"favorites", "list"}, "", path, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "@office  London, United Kingdom (England)  51.5085, -0.1257")
+
+	code, stdout, _ = runWithFavorites([]string{"favorites", "rename", "@office", "work"}, "", path, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Renamed @office to @work")
+
+	code, stdout, _ = runWithFavorites([]string{"favorites", "remove", "work"}, "", path, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Removed @work")
+
+	store, err = favorites.Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, store.List())
+}
+
+func TestRun_FavoritesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "Unknown Alias", args: []string{"@gym"}, want: "favorite not found: @gym"},
+		{name: "Remove Unknown", args: []string{"favorites", "remove", "gym"}, want: "favorite not found: @gym"},
+		{name: "Invalid Alias", args: []string{"favorites", "add", "my gym", "52.52,13.41"}, want: `invalid alias "my gym"`},
+		{name: "Invalid Alias Before Search", args: []string{"favorites", "add", "my gym", "London"}, want: `invalid alias "my gym"`},
+		{name: "Alias Of Alias", args: []string{"favorites", "add", "gym", "@office"}, want: "cannot refer to another favorite"},
+		{name: "Usage", args: []string{"favorites", "add", "gym"}, want: "Usage: weather-reporter favorites add"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "favorites.json")
+			geoClient := &mockGeocodingService{}
+
+			code, _, stderr := runWithFavorites(tt.args, "", path, geoClient, &mockWeatherService{})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, tt.want)
+			geoClient.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRun_FavoritesAddExistingAlias(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "favorites.json")
+	store, err := favorites.Load(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Add("office", londonCandidates[0]))
+	require.NoError(t, store.Save())
+	geoClient := &mockGeocodingService{}
+
+	code, stdout, stderr := runWithFavorites([]string{"favorites", "add", "@office", "London"}, "2\n", path, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "Error: favorite already exists: @office\n", stderr)
+	assert.Empty(t, stdout, "no prompt is shown")
+	geoClient.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/favorites"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/ui"
+)
+
+// coordinateFlags holds the --lat and --lon flags, which select a point
+// directly and skip the location search.
+type coordinateFlags struct {
+	lat string
+	lon string
+}
+
+// register defines the coordinate flags on fs.
+func (f *coordinateFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.lat, "lat", "", "Latitude in decimal degrees (requires --lon); skips the location search")
+	fs.StringVar(&f.lon, "lon", "", "Longitude in decimal degrees (requires --lat); skips the location search")
+}
+
+// isSet reports whether either coordinate flag was given.
+func (f *coordinateFlags) isSet() bool {
+	return f.lat != "" || f.lon != ""
+}
+
+// coordinates parses and validates the coordinate flags.
+func (f *coordinateFlags) coordinates() (lat, lon float64, err error) {
+	if f.lat == "" || f.lon == "" {
+		return 0, 0, errors.New("--lat and --lon must be used together")
+	}
+	if lat, err = strconv.ParseFloat(f.lat, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid --lat %q: must be a number in decimal degrees", f.lat)
+	}
+	if lon, err = strconv.ParseFloat(f.lon, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid --lon %q: must be a number in decimal degrees", f.lon)
+	}
+	return lat, lon, geo.ValidateCoordinates(lat, lon)
+}
+
+// filterFlags holds the --country and --region flags, which narrow down
+// the results of a location search.
+type filterFlags struct {
+	country string
+	region  string
+}
+
+// register defines the filter flags on fs.
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.country, "country", "", "Only consider locations in this country, as an ISO 3166-1 code such as US or DE")
+	fs.StringVar(&f.region, "region", "", "Only consider locations in this state or region, by name or abbreviation such as Oregon or OR")
+}
+
+// filter validates the filter flags and returns them as a geo.Filter.
+func (f *filterFlags) filter() (geo.Filter, error) {
+	filter := geo.Filter{Region: strings.TrimSpace(f.region)}
+	if f.country != "" {
+		code, err := geo.ParseCountryCode(f.country)
+		if err != nil {
+			return geo.Filter{}, err
+		}
+		filter.Country = code
+	}
+	return filter, nil
+}
+
+// registerPick defines the --pick flag on fs, storing the parsed strategy
+// in pick.
+func registerPick(fs *flag.FlagSet, pick *geo.Pick) {
+	fs.Func("pick", "Choose between multiple matches without a prompt: first, a position such as 2, most-populous or closest-to=LAT,LON", func(v string) error {
+		p, err := geo.ParsePick(v)
+		if err != nil {
+			return err
+		}
+		*pick = p
+		return nil
+	})
+}
+
+// registerLanguage defines the --lang flag on fs, storing the language of
+// location names in lang. Its default is taken from cfg.
+func registerLanguage(fs *flag.FlagSet, lang *string, cfg config.Config) {
+	fs.StringVar(lang, "lang", cfg.Language, "Language of location names, as an ISO 639-1 code such as de or ja")
+}
+
+// registerLanguageAlias defines --language, which search accepted before
+// --lang existed, as an alias of the --lang flag defined on fs.
+func registerLanguageAlias(fs *flag.FlagSet) {
+	lang := fs.Lookup("lang")
+	fs.Var(aliasFlag{lang.Value}, "language", "")
+	lang.Usage += " (alias: --language)"
+}
+
+// registerLocale defines the --locale flag on fs, storing the locale of
+// the output in locale. Empty means the user's locale.
+func registerLocale(fs *flag.FlagSet, locale *string) {
+	fs.StringVar(locale, "locale", "", fmt.Sprintf("Language of labels and number format: %s (default: from LC_ALL, LC_MESSAGES or LANG, else en)", strings.Join(ui.Locales(), ", ")))
+}
+
+// locator resolves the user's input into a single location, searching and
+// prompting for a choice when needed.
+type locator struct {
+	stdin         io.Reader
+	stdout        io.Writer
+	stderr        io.Writer
+	prompt        io.Writer
+	geoClient     models.GeocodingService
+	reverse       models.ReverseGeocodingService
+	favoritesPath string
+	isInteractive interactiveChecker
+	openTerminal  terminalOpener
+
+	// verbose prints the cause of failed searches.
+	verbose bool
+
+	// filter narrows down the results of a search by name.
+	filter geo.Filter
+
+	// pick chooses between multiple matches instead of the prompt, unless
+	// it is zero.
+	pick geo.Pick
+
+	// language is the language of location names. Empty means the
+	// geocoder's default.
+	language string
+
+	// locale is the language of the selection prompt.
+	locale ui.Locale
+}
+
+// fromCoordinateFlags resolves the location given by --lat and --lon.
+// It returns false and the exit code if the flags are invalid.
+func (l *locator) fromCoordinateFlags(ctx context.Context, coords coordinateFlags, args []string) (models.Location, int, bool) {
+	if len(args) > 0 {
+		_, _ = fmt.Fprintln(l.stderr, "Error: a location cannot be combined with --lat/--lon")
+		return models.Location{}, 1, false
+	}
+	lat, lon, err := coords.coordinates()
+	if err != nil {
+		_, _ = fmt.Fprintf(l.stderr, "Error: %v\n", err)
+		return models.Location{}, 1, false
+	}
+	return l.coordinateLocation(ctx, lat, lon), 0, true
+}
+
+// coordinateLocation builds the location for a coordinate pair and names
+// the nearest place when one is known. Reverse geocoding failures are not
+// fatal: the report is still printed, only without the nearby place.
+func (l *locator) coordinateLocation(ctx context.Context, lat, lon float64) models.Location {
+	return geo.Resolver{Reverse: l.reverse}.Locate(ctx, lat, lon)
+}
+
+// locate resolves a query that is a favorite alias, a coordinate pair or a
+// place name. It returns false and the exit code if no single location was
+// selected.
+func (l *locator) locate(ctx context.Context, query string) (models.Location, int, bool) {
+	if favorites.IsAlias(query) {
+		return l.favorite(query)
+	}
+	lat, lon, err := geo.ParseCoordinates(query)
+	if err == nil {
+		return l.coordinateLocation(ctx, lat, lon), 0, true
+	}
+	if !errors.Is(err, geo.ErrNotCoordinates) {
+		_, _ = fmt.Fprintf(l.stderr, "Error: %v\n", err)
+		return models.Location{}, 1, false
+	}
+	return l.search(ctx, query)
+}
+
+// favorite resolves a saved favorite without any geocoding call.
+func (l *locator) favorite(alias string) (models.Location, int, bool) {
+	store, err := loadFavorites(l.favoritesPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(l.stderr, "Error: %v\n", err)
+		return models.Location{}, 1, false
+	}
+	loc, err := store.Get(alias)
+	if err != nil {
+		_, _ = fmt.Fprintf(l.stderr, "Error: %v (see 'weather-reporter favorites list')\n", err)
+		return models.Location{}, 1, false
+	}
+	return loc, 0, true
+}
+
+// search looks up a place by name and lets the user choose between
+// multiple matches.
+func (l *locator) search(ctx context.Context, name string) (models.Location, int, bool) {
+	locations, err := geo.SearchQuery(ctx, l.geoClient, name, l.filter, models.SearchOptions{Language: l.language})
+	if err != nil {
+		return models.Location{}, searchFailed(l.stderr, err, l.verbose), false
+	}
+
+	if len(locations) == 0 {
+		_, _ = fmt.Fprintf(l.stdout, "Location not found: %s\n", name)
+		return models.Location{}, 0, false
+	}
+
+	if len(locations) == 1 {
+		return locations[0], 0, true
+	}
+
+	if !l.pick.IsZero() {
+		return l.picked(locations)
+	}
+
+	selected, err := l.choose(locations)
+	if err != nil {
+		_, _ = fmt.Fprintf(l.stderr, "Error selecting location: %v\n", err)
+		return models.Location{}, 1, false
+	}
+	return selected, 0, true
+}
+
+// choose lets the user choose between multiple matches, with the
+// arrow-key picker where the terminal supports it and the numbered prompt
+// elsewhere.
+func (l *locator) choose(locations []models.Location) (models.Location, error) {
+	interactive := l.isInteractive(l.stdin)
+	if interactive && l.openTerminal != nil {
+		if t, ok := l.openTerminal(l.stdin, l.prompt); ok {
+			return ui.PickLocation(t, locations, l.locale)
+		}
+	}
+	return ui.SelectLocation(locations, l.stdin, l.prompt, interactive, l.locale)
+}
+
+// picked chooses one of several matches with l.pick and reports the
+// choice on stderr, where it does not mix with the report.
+func (l *locator) picked(locations []models.Location) (models.Location, int, bool) {
+	i, err := l.pick.Choose(locations)
+	if err != nil {
+		_, _ = fmt.Fprintf(l.stderr, "Error selecting location: %v\n", err)
+		return models.Location{}, 1, false
+	}
+	_, _ = fmt.Fprintf(l.stderr, "Picked %s, match %d of %d (--pick %s)\n", ui.FormatLocation(locations[i]), i+1, len(locations), l.pick)
+	return locations[i], 0, true
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/ui"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRun_Coordinates(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		lat, lon float64
+		header   string
+	}{
+		{name: "Decimal Pair", args: []string{"52.52,13.41"}, lat: 52.52, lon: 13.41, header: "Weather for 52.5200°N, 13.4100°E"},
+		{name: "Negative Latitude", args: []string{"--", "-33.86", "151.2"}, lat: -33.86, lon: 151.2, header: "Weather for 33.8600°S, 151.2000°E"},
+		{name: "DMS", args: []string{"40°30'N", "74°15'W"}, lat: 40.5, lon: -74.25, header: "Weather for 40.5000°N, 74.2500°W"},
+		{name: "Flags", args: []string{"--lat", "52.52", "--lon", "13.41"}, lat: 52.52, lon: 13.41, header: "Weather for 52.5200°N, 13.4100°E"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+			weatherClient := &mockWeatherService{}
+			weatherClient.On("GetCurrentWeather", mock.Anything, tt.lat, tt.lon, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+			code, stdout, stderr := runWith(tt.args, geoClient, weatherClient)
+
+			assert.Equal(t, 0, code, stderr)
+			assert.Contains(t, stdout, tt.header+"\n")
+			geoClient.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+			weatherClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_InvalidCoordinates(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "Latitude Out Of Range", args: []string{"95,13.41"}, want: "invalid latitude 95"},
+		{name: "Lat Without Lon", args: []string{"--lat", "52.52"}, want: "--lat and --lon must be used together"},
+		{name: "Non-numeric Flag", args: []string{"--lat", "north", "--lon", "13.41"}, want: `invalid --lat "north"`},
+		{name: "Longitude Out Of Range Flag", args: []string{"--lat", "52.52", "--lon", "200"}, want: "invalid longitude 200"},
+		{name: "Flags And Location", args: []string{"--lat", "52.52", "--lon", "13.41", "Berlin"}, want: "cannot be combined with --lat/--lon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+			weatherClient := &mockWeatherService{}
+
+			code, _, stderr := runWith(tt.args, geoClient, weatherClient)
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, tt.want)
+			geoClient.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+type mockReverseGeocodingService struct {
+	mock.Mock
+}
+
+func (m *mockReverseGeocodingService) Reverse(ctx context.Context, lat, lon float64) (models.Location, error) {
+	args := m.Called(ctx, lat, lon)
+	return args.Get(0).(models.Location), args.Error(1)
+}
+
+func TestRun_CoordinatesNearbyPlace(t *testing.T) {
+	for _, args := range [][]string{{"52.52,13.41"}, {"--lat", "52.52", "--lon", "13.41"}} {
+		reverse := &mockReverseGeocodingService{}
+		reverse.On("Reverse", mock.Anything, 52.52, 13.41).Return(models.Location{Name: "Berlin", Country: "Germany", Region: "Berlin"}, nil)
+		weatherClient := &mockWeatherService{}
+		weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+		var stdout, stderr bytes.Buffer
+		code := run(args, strings.NewReader(""), &stdout, &stderr, services{geo: &mockGeocodingService{}, reverse: reverse, weather: weatherClient, isInteractive: notInteractive})
+
+		assert.Equal(t, 0, code, stderr.String())
+		assert.Contains(t, stdout.String(), "Weather for 52.5200°N, 13.4100°E\nNear Berlin, Germany (Berlin)\n")
+		reverse.AssertExpectations(t)
+	}
+}
+
+func TestRun_CoordinatesReverseGeocodingFails(t *testing.T) {
+	reverse := &mockReverseGeocodingService{}
+	reverse.On("Reverse", mock.Anything, 52.52, 13.41).Return(models.Location{}, errors.New("no nearby place found"))
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"52.52,13.41"}, strings.NewReader(""), &stdout, &stderr, services{geo: &mockGeocodingService{}, reverse: reverse, weather: weatherClient, isInteractive: notInteractive})
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), "Weather for 52.5200°N, 13.4100°E\n---")
+	assert.NotContains(t, stdout.String(), "Near")
+}
+
+func TestRun_QueryQualifiersResolveWithoutPrompt(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		opts models.SearchOptions
+		want models.Location
+	}{
+		{name: "Region And Country", args: []string{"Portland, OR, US"}, opts: models.SearchOptions{Count: geo.FilterSearchCount, Language: "en", CountryCode: "US"}, want: portlandCandidates[0]},
+		{name: "Region Only", args: []string{"Portland,", "ME"}, opts: models.SearchOptions{Count: geo.FilterSearchCount, Language: "en"}, want: portlandCandidates[1]},
+		{name: "Flags", args: []string{"--country", "us", "--region", "Maine", "Portland"}, opts: models.SearchOptions{Count: geo.FilterSearchCount, Language: "en", CountryCode: "US"}, want: portlandCandidates[1]},
+		{name: "Flags Override Query", args: []string{"--region", "Oregon", "Portland, ME"}, opts: models.SearchOptions{Count: geo.FilterSearchCount, Language: "en"}, want: portlandCandidates[0]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+			geoClient.On("Search", mock.Anything, "Portland", tt.opts).Return(portlandCandidates, nil)
+			weatherClient := &mockWeatherService{}
+			weatherClient.On("GetCurrentWeather", mock.Anything, tt.want.Latitude, tt.want.Longitude, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+			code, stdout, stderr := runWith(append([]string{"now"}, tt.args...), geoClient, weatherClient)
+
+			assert.Equal(t, 0, code, stderr)
+			assert.Contains(t, stdout, "Weather for Portland, United States ("+tt.want.Region+")")
+			assert.NotContains(t, stdout, "Multiple locations found")
+		})
+	}
+}
+
+func TestRun_QueryQualifiersNoMatch(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+
+	code, stdout, _ := runWith([]string{"Portland, TX"}, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Location not found: Portland, TX")
+}
+
+func TestRun_FilterFlagsInvalid(t *testing.T) {
+	code, _, stderr := runWith([]string{"now", "--country", "United States", "Portland"}, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `invalid country code "United States"`)
+
+	code, _, stderr = runWith([]string{"now", "--region", "OR", "--lat", "45.5", "--lon", "-122.7"}, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "--country, --region and --pick cannot be combined with --lat/--lon")
+}
+
+func TestRun_Pick(t *testing.T) {
+	tests := []struct {
+		pick   string
+		want   models.Location
+		stderr string
+	}{
+		{pick: "first", want: portlandCandidates[0], stderr: "Picked Portland, United States (Oregon), match 1 of 2 (--pick first)\n"},
+		{pick: "2", want: portlandCandidates[1], stderr: "Picked Portland, United States (Maine), match 2 of 2 (--pick 2)\n"},
+		{pick: "most-populous", want: portlandCandidates[0], stderr: "match 1 of 2 (--pick most-populous)"},
+		{pick: "closest-to=42.36,-71.06", want: portlandCandidates[1], stderr: "match 2 of 2 (--pick closest-to=42.36,-71.06)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pick, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+			geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+			weatherClient := &mockWeatherService{}
+			weatherClient.On("GetCurrentWeather", mock.Anything, tt.want.Latitude, tt.want.Longitude, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+			code, stdout, stderr := runWith([]string{"now", "--pick", tt.pick, "Portland"}, geoClient, weatherClient)
+
+			assert.Equal(t, 0, code, stderr)
+			assert.Contains(t, stderr, tt.stderr)
+			assert.Contains(t, stdout, "Weather for Portland, United States ("+tt.want.Region+")")
+			assert.NotContains(t, stdout, "Multiple locations found")
+		})
+	}
+}
+
+func TestRun_PickErrors(t *testing.T) {
+	code, _, stderr := runWith([]string{"now", "--pick", "last", "Portland"}, &mockGeocodingService{}, &mockWeatherService{})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `invalid value "last" for flag -pick`)
+
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+	code, _, stderr = runWith([]string{"now", "--pick", "3", "Portland"}, geoClient, &mockWeatherService{})
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "Error selecting location: cannot pick location 3: only 2 locations found")
+}
+
+func TestRun_Lang(t *testing.T) {
+	munich := models.Location{ID: 2867714, Name: "München", Latitude: 48.13743, Longitude: 11.57549, Country: "Deutschland", CountryCode: "DE", Region: "Bayern"}
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Munich", models.SearchOptions{Language: "de"}).Return([]models.Location{munich}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, munich.Latitude, munich.Longitude, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	code, stdout, stderr := runWith([]string{"now", "--lang", "DE", "Munich"}, geoClient, weatherClient)
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Weather for München, Deutschland (Bayern)")
+}
+
+func TestRun_LangFromLocale(t *testing.T) {
+	tokyo := models.Location{ID: 1850147, Name: "東京", Latitude: 35.6895, Longitude: 139.69171, Country: "日本", CountryCode: "JP", Region: "東京都"}
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Tokyo", models.SearchOptions{Language: "ja"}).Return([]models.Location{tokyo}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, tokyo.Latitude, tokyo.Longitude, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	code, stdout, stderr := runWithConfig([]string{"Tokyo"}, "", map[string]string{"LANG": "ja_JP.UTF-8"}, services{geo: geoClient, weather: weatherClient})
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Weather for 東京, 日本 (東京都)")
+}
+
+func TestRun_LangRecreatesClients(t *testing.T) {
+	reverse := &mockReverseGeocodingService{}
+	reverse.On("Reverse", mock.Anything, 52.52, 13.41).Return(models.Location{Name: "Berlin", Country: "Allemagne"}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+	var languages []string
+	svc := services{
+		newClients: func(cfg config.Config, s *services) {
+			languages = append(languages, cfg.Language)
+			s.reverse = reverse
+			s.weather = weatherClient
+		},
+	}
+
+	code, stdout, stderr := runWithConfig([]string{"now", "--lang", "fr", "52.52,13.41"}, "", nil, svc)
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, []string{"en", "fr"}, languages, "clients are recreated for the reverse geocoder")
+	assert.Contains(t, stdout, "Near Berlin, Allemagne")
+}
+
+func TestRun_LangInvalid(t *testing.T) {
+	code, _, stderr := runWith([]string{"now", "--lang", "german", "Munich"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `--lang: must be a two-letter ISO 639-1 language code such as en or de, got "german"`)
+}
+
+func TestRun_Locale(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	code, stdout, stderr := runWith([]string{"now", "--locale", "de", "Berlin"}, geoClient, weatherClient)
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Wetter für Berlin, Germany (Berlin)")
+	assert.Contains(t, stdout, "Temperatur:          20,0°C")
+}
+
+func TestRun_LocaleFromEnvironment(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetDailyForecast", mock.Anything, 52.52, 13.41, 7, models.MetricUnits()).Return([]models.DailyForecast{}, nil)
+
+	code, stdout, stderr := runWithConfig([]string{"forecast", "Berlin"}, "", map[string]string{"LC_ALL": "fr_FR.UTF-8"}, services{geo: geoClient, weather: weatherClient})
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "Prévisions pour Berlin")
+	assert.Contains(t, stdout, "Date  Min  Max  Précipitations  Vent max")
+}
+
+func TestRun_LocalePrompt(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+
+	code, stdout, _ := runWith([]string{"now", "--locale", "es", "Portland"}, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "Se encontraron varias ubicaciones:")
+}
+
+func TestRun_LocaleInvalid(t *testing.T) {
+	code, _, stderr := runWith([]string{"now", "--locale", "ja", "Berlin"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `--locale: unsupported locale "ja" (supported: de, en, es, fr)`)
+}
+
+// fakeTerminal is a ui.Terminal reading scripted key presses.
+type fakeTerminal struct {
+	io.Reader
+	bytes.Buffer
+}
+
+func (t *fakeTerminal) Read(p []byte) (int, error)     { return t.Reader.Read(p) }
+func (t *fakeTerminal) MakeRaw() (func() error, error) { return func() error { return nil }, nil }
+func (t *fakeTerminal) Width() int                     { return 80 }
+
+func TestRun_Picker(t *testing.T) {
+	maine := portlandCandidates[1]
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, maine.Latitude, maine.Longitude, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+	term := &fakeTerminal{Reader: strings.NewReader("\x1b[B\r")}
+	svc := services{
+		geo:           geoClient,
+		weather:       weatherClient,
+		isInteractive: func(io.Reader) bool { return true },
+		openTerminal:  func(io.Reader, io.Writer) (ui.Terminal, bool) { return term, true },
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"Portland"}, strings.NewReader(""), &stdout, &stderr, svc)
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, term.String(), "> Portland, United States (Oregon)")
+	assert.Contains(t, stdout.String(), "Weather for Portland, United States (Maine)")
+	assert.NotContains(t, stdout.String(), "Select location")
+}
+
+func TestRun_PickerCanceled(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+	term := &fakeTerminal{Reader: strings.NewReader("\x1b")}
+	svc := services{
+		geo:           geoClient,
+		weather:       &mockWeatherService{},
+		isInteractive: func(io.Reader) bool { return true },
+		openTerminal:  func(io.Reader, io.Writer) (ui.Terminal, bool) { return term, true },
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"Portland"}, strings.NewReader(""), &stdout, &stderr, svc)
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "Error selecting location: location selection canceled")
+}
+
+func TestRun_PickerFallsBackToPrompt(t *testing.T) {
+	maine := portlandCandidates[1]
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, maine.Latitude, maine.Longitude, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+	svc := services{
+		geo:           geoClient,
+		weather:       weatherClient,
+		isInteractive: func(io.Reader) bool { return true },
+		openTerminal:  func(io.Reader, io.Writer) (ui.Terminal, bool) { return nil, false },
+	}
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"Portland"}, strings.NewReader("2\n"), &stdout, &stderr, svc)
+
+	assert.Equal(t, 0, code, stderr.String())
+	assert.Contains(t, stdout.String(), "Select location [1-2]: ")
+	assert.Contains(t, stdout.String(), "Weather for Portland, United States (Maine)")
+}
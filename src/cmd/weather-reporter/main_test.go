@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockGeocodingService struct {
+	mock.Mock
+}
+
+func (m *mockGeocodingService) Search(ctx context.Context, name string, opts models.SearchOptions) ([]models.Location, error) {
+	args := m.Called(ctx, name, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Location), args.Error(1)
+}
+
+type mockWeatherService struct {
+	mock.Mock
+}
+
+func (m *mockWeatherService) GetCurrentWeather(ctx context.Context, lat, lon float64, units models.Units) (models.WeatherResponse, error) {
+	args := m.Called(ctx, lat, lon, units)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(models.WeatherResponse), args.Error(1)
+}
+
+func (m *mockWeatherService) GetDailyForecast(ctx context.Context, lat, lon float64, days int, units models.Units) ([]models.DailyForecast, error) {
+	args := m.Called(ctx, lat, lon, days, units)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DailyForecast), args.Error(1)
+}
+
+func (m *mockWeatherService) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int, units models.Units) ([]models.HourlyForecast, error) {
+	args := m.Called(ctx, lat, lon, hours, units)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.HourlyForecast), args.Error(1)
+}
+
+type stubWeatherResponse struct{}
+
+func (stubWeatherResponse) QuantityOfTemperature() string         { return "20.0°C" }
+func (stubWeatherResponse) QuantityOfHumidity() string            { return "50%" }
+func (stubWeatherResponse) QuantityOfApparentTemperature() string { return "18.0°C" }
+func (stubWeatherResponse) QuantityOfPrecipitation() string       { return "0.0 mm" }
+func (stubWeatherResponse) QuantityOfCloudCover() string          { return "10%" }
+func (stubWeatherResponse) QuantityOfPressure() string            { return "1013.0 hPa" }
+func (stubWeatherResponse) QuantityOfWindSpeed() string           { return "10.0 km/h" }
+func (stubWeatherResponse) QuantityOfWindDirection() string       { return "180°" }
+func (stubWeatherResponse) QuantityOfWindGusts() string           { return "15.0 km/h" }
+func (stubWeatherResponse) Observation() models.Observation {
+	return models.Observation{
+		Temperature:         models.Measurement{Value: 20, Unit: "°C"},
+		ApparentTemperature: models.Measurement{Value: 18, Unit: "°C"},
+		Humidity:            models.Measurement{Value: 50, Unit: "%"},
+		Precipitation:       models.Measurement{Value: 0, Unit: "mm"},
+		CloudCover:          models.Measurement{Value: 10, Unit: "%"},
+		Pressure:            models.Measurement{Value: 1013, Unit: "hPa"},
+		WindSpeed:           models.Measurement{Value: 10, Unit: "km/h"},
+		WindDirection:       models.Measurement{Value: 180, Unit: "°"},
+		WindGusts:           models.Measurement{Value: 15, Unit: "km/h"},
+	}
+}
+
+var berlin = models.Location{ID: 2950159, Name: "Berlin", Latitude: 52.52, Longitude: 13.41, Country: "Germany", CountryCode: "DE", Region: "Berlin"}
+
+func notInteractive(io.Reader) bool { return false }
+
+func runWith(args []string, geoClient models.GeocodingService, weatherClient models.WeatherService) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(""), &stdout, &stderr, services{geo: geoClient, weather: weatherClient, isInteractive: notInteractive})
+	return code, stdout.String(), stderr.String()
+}
+
+func TestRun_Version(t *testing.T) {
+	code, stdout, _ := runWith([]string{"-version"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "weather-reporter version dev")
+}
+
+func TestRun_Usage(t *testing.T) {
+	code, stdout, _ := runWith(nil, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stdout, "Usage: weather-reporter")
+}
+
+func TestRun_CurrentWeather(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	code, stdout, _ := runWith([]string{"Berlin"}, geoClient, weatherClient)
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Weather for Berlin, Germany (Berlin)")
+	assert.Contains(t, stdout, "Temperature:          20.0°C")
+	weatherClient.AssertExpectations(t)
+}
+
+func TestRun_CurrentWeatherJSON(t *testing.T) {
+	london := []models.Location{
+		{ID: 2643743, Name: "London", Latitude: 51.50853, Longitude: -0.12574, Country: "United Kingdom", Region: "England"},
+		{ID: 6058560, Name: "London", Latitude: 42.98339, Longitude: -81.23304, Country: "Canada", Region: "Ontario"},
+	}
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "London", mock.Anything).Return(london, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 51.50853, -0.12574, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--output", "json", "London"}, strings.NewReader("1\n"), &stdout, &stderr, services{geo: geoClient, weather: weatherClient, isInteractive: func(io.Reader) bool { return true }})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stderr.String(), "Multiple locations found:")
+
+	var doc struct {
+		SchemaVersion int `json:"schema_version"`
+		Location      struct {
+			ID     int    `json:"id"`
+			Admin1 string `json:"admin1"`
+		} `json:"location"`
+		Current struct {
+			Temperature struct {
+				Value float64 `json:"value"`
+				Unit  string  `json:"unit"`
+			} `json:"temperature"`
+		} `json:"current"`
+	}
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &doc), "stdout must contain only the JSON document")
+	assert.Equal(t, 1, doc.SchemaVersion)
+	assert.Equal(t, 2643743, doc.Location.ID)
+	assert.Equal(t, "England", doc.Location.Admin1)
+	assert.Equal(t, 20.0, doc.Current.Temperature.Value)
+	assert.Equal(t, "°C", doc.Current.Temperature.Unit)
+}
+
+func TestRun_DailyForecast(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetDailyForecast", mock.Anything, 52.52, 13.41, 3, models.MetricUnits()).Return([]models.DailyForecast{
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	code, stdout, _ := runWith([]string{"--days", "3", "Berlin"}, geoClient, weatherClient)
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "Forecast for Berlin, Germany (Berlin)")
+	assert.Contains(t, stdout, "Thu 2026-01-01")
+	weatherClient.AssertNotCalled(t, "GetCurrentWeather", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRun_HourlyForecast(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		hours int
+	}{
+		{name: "Default Horizon", args: []string{"--hourly", "Berlin"}, hours: 24},
+		{name: "Explicit Horizon", args: []string{"--hours", "48", "Berlin"}, hours: 48},
+		{name: "Hourly With Hours", args: []string{"--hourly", "--hours", "72", "Berlin"}, hours: 72},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+			geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+			weatherClient := &mockWeatherService{}
+			weatherClient.On("GetHourlyForecast", mock.Anything, 52.52, 13.41, tt.hours, models.MetricUnits()).Return([]models.HourlyForecast{
+				{Time: time.Date(2026, 1, 1, 14, 0, 0, 0, time.FixedZone("CET", 3600))},
+			}, nil)
+
+			code, stdout, _ := runWith(tt.args, geoClient, weatherClient)
+
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, "Hourly forecast for Berlin, Germany (Berlin)")
+			assert.Contains(t, stdout, "Thu 14:00 CET")
+			weatherClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_Units(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		units models.Units
+	}{
+		{name: "Imperial", args: []string{"--units", "imperial"}, units: models.ImperialUnits()},
+		{name: "Overrides", args: []string{"--units", "metric", "--temp-unit", "fahrenheit", "--wind-unit", "knots", "--precip-unit", "inch"},
+			units: models.Units{Temperature: models.Fahrenheit, WindSpeed: models.Knots, Precipitation: models.Inches}},
+		{name: "Imperial With Override", args: []string{"--units", "imperial", "--temp-unit", "celsius"},
+			units: models.Units{Temperature: models.Celsius, WindSpeed: models.MilesPerHour, Precipitation: models.Inches}},
+		{name: "Override Without System", args: []string{"--wind-unit", "ms"},
+			units: models.Units{Temperature: models.Celsius, WindSpeed: models.MetresPerSecond, Precipitation: models.Millimetres}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+			geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+			weatherClient := &mockWeatherService{}
+			weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, tt.units).Return(stubWeatherResponse{}, nil)
+
+			code, _, _ := runWith(append(tt.args, "Berlin"), geoClient, weatherClient)
+
+			assert.Equal(t, 0, code)
+			weatherClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRun_InvalidForecastFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "Too Many Days", args: []string{"--days", "17", "Berlin"}, want: "--days must be between 1 and 16"},
+		{name: "Too Many Hours", args: []string{"--hours", "169", "Berlin"}, want: "--hours must be between 1 and 168"},
+		{name: "Days And Hours", args: []string{"--days", "2", "--hourly", "Berlin"}, want: "--days cannot be combined"},
+		{name: "Unknown Output", args: []string{"--output", "xml", "Berlin"}, want: `unknown output format "xml"`},
+		{name: "Unknown Unit System", args: []string{"--units", "nautical", "Berlin"}, want: `unknown unit system "nautical"`},
+		{name: "Unknown Unit", args: []string{"--temp-unit", "kelvin", "Berlin"}, want: `unknown temperature unit "kelvin"`},
+		{name: "JSON Forecast", args: []string{"--output", "json", "--days", "2", "Berlin"}, want: "--output json is only supported for the current weather"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+
+			code, _, stderr := runWith(tt.args, geoClient, &mockWeatherService{})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, tt.want)
+			geoClient.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	t.Run("Search Error", func(t *testing.T) {
+		geoClient := &mockGeocodingService{}
+		geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return(nil, errors.New("unable to search locations, please try again"))
+
+		code, _, stderr := runWith([]string{"Berlin"}, geoClient, &mockWeatherService{})
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "Error searching for location")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		geoClient := &mockGeocodingService{}
+		geoClient.On("Search", mock.Anything, "Nowhere", mock.Anything).Return([]models.Location{}, nil)
+
+		code, stdout, _ := runWith([]string{"Nowhere"}, geoClient, &mockWeatherService{})
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "Location not found: Nowhere")
+	})
+
+	t.Run("Forecast Error", func(t *testing.T) {
+		geoClient := &mockGeocodingService{}
+		geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+		weatherClient := &mockWeatherService{}
+		weatherClient.On("GetDailyForecast", mock.Anything, 52.52, 13.41, 2, models.MetricUnits()).Return(nil, errors.New("boom"))
+
+		code, _, stderr := runWith([]string{"--days", "2", "Berlin"}, geoClient, weatherClient)
+
+		assert.Equal(t, 1, code)
+		assert.Contains(t, stderr, "Error fetching forecast: boom")
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"weather-reporter/src/internal/mcp"
+)
+
+// runMCP implements the mcp command: it serves the weather tools over the
+// Model Context Protocol on stdin and stdout until stdin is closed or the
+// process is interrupted.
+func runMCP(a *app, args []string) int {
+	cmd, _ := lookupCommand("mcp")
+	fs, global := a.newFlagSet(cmd)
+	fs.Lookup("timeout").Usage = "Maximum time for each tool call"
+	var flags reportFlags
+	flags.registerUnits(fs, a.cfg)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 1
+	}
+	units, err := flags.selectedUnits()
+	if err == nil {
+		err = global.validate()
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// stdout carries the protocol; everything else goes to stderr.
+	opts := mcp.Options{
+		Geocoder:       a.svc.geocoder(global.noCache),
+		Reverse:        a.svc.reverseGeocoder(global.noCache),
+		Weather:        a.svc.weatherService(global.noCache, nil),
+		Units:          units,
+		RequestTimeout: global.timeout,
+		Version:        Version,
+	}
+	if global.verbose {
+		opts.ErrorLog = a.stderr
+	}
+
+	stop := a.svc.stopContext
+	if stop == nil {
+		stop = signalContext
+	}
+	ctx, cancel := stop()
+	defer cancel()
+
+	if err := mcp.New(opts).Serve(ctx, a.stdin, a.stdout); err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun_MCP(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.ImperialUnits()).Return(stubWeatherResponse{}, nil)
+
+	script := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_current_weather","arguments":{"location":"Berlin"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_current_weather","arguments":{"location":"Portland"}}}`,
+	}, "\n") + "\n"
+	svc := services{geo: geoClient, weather: weatherClient, isInteractive: notInteractive}
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"mcp", "--units", "imperial"}, strings.NewReader(script), &stdout, &stderr, svc)
+	require.Equal(t, 0, code, stderr.String())
+	assert.Empty(t, stderr.String())
+
+	type response struct {
+		Result struct {
+			ProtocolVersion string `json:"protocolVersion"`
+			Tools           []struct {
+				Name string `json:"name"`
+			} `json:"tools"`
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+			StructuredContent struct {
+				Location struct {
+					Name string `json:"name"`
+				} `json:"location"`
+			} `json:"structuredContent"`
+			IsError bool `json:"isError"`
+		} `json:"result"`
+	}
+	var responses []response
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var resp response
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp), scanner.Text())
+		responses = append(responses, resp)
+	}
+	require.Len(t, responses, 4, "stdout only carries responses")
+
+	assert.Equal(t, "2025-06-18", responses[0].Result.ProtocolVersion)
+	assert.Len(t, responses[1].Result.Tools, 3)
+	assert.False(t, responses[2].Result.IsError, responses[2].Result.Content)
+	assert.Equal(t, "Berlin", responses[2].Result.StructuredContent.Location.Name)
+	assert.True(t, responses[3].Result.IsError)
+	assert.Contains(t, responses[3].Result.Content[0].Text, `"Portland" matches 2 locations`)
+	weatherClient.AssertExpectations(t)
+}
+
+func TestRun_MCPErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"mcp", "--units", "kelvin"}, `Error: unknown unit system "kelvin" (must be metric or imperial)`},
+		{[]string{"mcp", "--timeout", "0s"}, "Error: --timeout must be positive"},
+		{[]string{"mcp", "Berlin"}, "Usage: weather-reporter mcp [flags]"},
+	}
+
+	for _, tt := range tests {
+		code, stdout, stderr := runWith(tt.args, &mockGeocodingService{}, &mockWeatherService{})
+
+		assert.Equal(t, 1, code, tt.args)
+		assert.Contains(t, stderr, tt.err, tt.args)
+		assert.Empty(t, stdout, tt.args)
+	}
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/ui"
+	"weather-reporter/src/internal/weather"
+)
+
+const (
+	// defaultForecastHours is the hourly forecast horizon used by --hourly.
+	defaultForecastHours = 24
+
+	// defaultForecastDays is the daily forecast horizon of the forecast
+	// command when neither --days nor --hours is given.
+	defaultForecastDays = 7
+)
+
+// Output formats accepted by --output.
+const (
+	outputText = config.OutputText
+	outputJSON = config.OutputJSON
+)
+
+// reportFlags holds the raw values of the flags that control the report.
+type reportFlags struct {
+	days       int
+	hours      int
+	hourly     bool
+	output     string
+	units      string
+	tempUnit   string
+	windUnit   string
+	precipUnit string
+	coords     coordinateFlags
+	filter     filterFlags
+	pick       geo.Pick
+	lang       string
+	locale     string
+}
+
+// registerOutput defines the flags of the current-weather report.
+func (f *reportFlags) registerOutput(fs *flag.FlagSet, cfg config.Config) {
+	fs.StringVar(&f.output, "output", cfg.Output, "Output format for the current weather: text or json")
+}
+
+// registerForecast defines the flags that select a daily or hourly
+// forecast.
+func (f *reportFlags) registerForecast(fs *flag.FlagSet) {
+	fs.IntVar(&f.days, "days", 0, fmt.Sprintf("Show a daily forecast for the next N days (1-%d)", weather.MaxForecastDays))
+	fs.BoolVar(&f.hourly, "hourly", false, fmt.Sprintf("Show an hourly forecast for the next %d hours", defaultForecastHours))
+	fs.IntVar(&f.hours, "hours", 0, fmt.Sprintf("Show an hourly forecast for the next N hours (1-%d); implies --hourly", weather.MaxForecastHours))
+}
+
+// registerUnits defines the unit flags, with defaults taken from cfg.
+func (f *reportFlags) registerUnits(fs *flag.FlagSet, cfg config.Config) {
+	fs.StringVar(&f.units, "units", cfg.Units, "Unit system: metric or imperial")
+	fs.StringVar(&f.tempUnit, "temp-unit", cfg.TemperatureUnit, "Temperature unit override: celsius or fahrenheit")
+	fs.StringVar(&f.windUnit, "wind-unit", cfg.WindSpeedUnit, "Wind speed unit override: kmh, ms, mph or knots")
+	fs.StringVar(&f.precipUnit, "precip-unit", cfg.PrecipitationUnit, "Precipitation unit override: mm or inch")
+}
+
+// reportOptions selects which report run prints and how. At most one of
+// days and hours is non-zero; when both are zero the current weather is
+// reported.
+type reportOptions struct {
+	days   int
+	hours  int
+	output string
+	units  models.Units
+	locale ui.Locale
+}
+
+// options validates the report flags and resolves them into reportOptions.
+func (f *reportFlags) options() (reportOptions, error) {
+	days, hours := f.days, f.hours
+	if f.hourly && hours == 0 {
+		hours = defaultForecastHours
+	}
+	if days < 0 || days > weather.MaxForecastDays {
+		return reportOptions{}, fmt.Errorf("--days must be between 1 and %d", weather.MaxForecastDays)
+	}
+	if hours < 0 || hours > weather.MaxForecastHours {
+		return reportOptions{}, fmt.Errorf("--hours must be between 1 and %d", weather.MaxForecastHours)
+	}
+	if days > 0 && hours > 0 {
+		return reportOptions{}, fmt.Errorf("--days cannot be combined with --hourly or --hours")
+	}
+
+	switch f.output {
+	case outputText:
+	case outputJSON:
+		if days > 0 || hours > 0 {
+			return reportOptions{}, fmt.Errorf("--output json is only supported for the current weather")
+		}
+	default:
+		return reportOptions{}, fmt.Errorf("unknown output format %q (must be %s or %s)", f.output, outputText, outputJSON)
+	}
+
+	units, err := f.selectedUnits()
+	if err != nil {
+		return reportOptions{}, err
+	}
+
+	return reportOptions{days: days, hours: hours, output: f.output, units: units}, nil
+}
+
+// selectedUnits resolves the unit flags into the units to request.
+func (f *reportFlags) selectedUnits() (models.Units, error) {
+	units, err := models.UnitsForSystem(f.units)
+	if err != nil {
+		return models.Units{}, err
+	}
+	if err := units.Override(f.tempUnit, f.windUnit, f.precipUnit); err != nil {
+		return models.Units{}, err
+	}
+	return units, nil
+}
+
+// newLocator validates the flags that control the location search, which
+// only apply to searches by name, and returns a locator using them. A
+// --lang other than the configured language also applies to the names of
+// places near coordinates, so the API clients are recreated for it.
+func (a *app) newLocator(flags *reportFlags, prompt io.Writer, noCache bool) (*locator, error) {
+	filter, err := flags.filter.filter()
+	if err != nil {
+		return nil, err
+	}
+	if flags.coords.isSet() && (!filter.IsZero() || !flags.pick.IsZero()) {
+		return nil, errors.New("--country, --region and --pick cannot be combined with --lat/--lon")
+	}
+	lang, err := config.ParseLanguage(flags.lang)
+	if err != nil {
+		return nil, fmt.Errorf("--lang: %w", err)
+	}
+
+	svc := a.svc
+	if lang != a.cfg.Language {
+		cfg := a.cfg
+		cfg.Language = lang
+		svc = svc.configure(cfg)
+	}
+	l := svc.locator(a.stdin, a.stdout, a.stderr, prompt, noCache)
+	l.filter = filter
+	l.pick = flags.pick
+	l.language = lang
+	return l, nil
+}
+
+// locale returns the locale named by --locale or, without the flag, the
+// user's locale from the environment. Locales without a catalog fall back
+// to English unless they were asked for explicitly.
+func (a *app) locale(name string) (ui.Locale, error) {
+	if name != "" {
+		l, err := ui.ParseLocale(name)
+		if err != nil {
+			return ui.Locale{}, fmt.Errorf("--locale: %w", err)
+		}
+		return l, nil
+	}
+	if a.svc.lookupEnv != nil {
+		if lang, ok := config.LocaleLanguage(a.svc.lookupEnv); ok {
+			if l, err := ui.ParseLocale(lang); err == nil {
+				return l, nil
+			}
+		}
+	}
+	return ui.Locale{}, nil
+}
+
+// runNow implements the now command.
+func runNow(a *app, args []string) int {
+	cmd, _ := lookupCommand("now")
+	fs, global := a.newFlagSet(cmd)
+	var flags reportFlags
+	flags.registerOutput(fs, a.cfg)
+	flags.registerUnits(fs, a.cfg)
+	flags.coords.register(fs)
+	flags.filter.register(fs)
+	registerPick(fs, &flags.pick)
+	registerLanguage(fs, &flags.lang, a.cfg)
+	registerLocale(fs, &flags.locale)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	return a.report(fs, global, &flags)
+}
+
+// runForecast implements the forecast command. Without --days, --hourly
+// or --hours it shows a daily forecast for defaultForecastDays days.
+func runForecast(a *app, args []string) int {
+	cmd, _ := lookupCommand("forecast")
+	fs, global := a.newFlagSet(cmd)
+	flags := reportFlags{output: outputText}
+	flags.registerForecast(fs)
+	flags.registerUnits(fs, a.cfg)
+	flags.coords.register(fs)
+	flags.filter.register(fs)
+	registerPick(fs, &flags.pick)
+	registerLanguage(fs, &flags.lang, a.cfg)
+	registerLocale(fs, &flags.locale)
+	fs.Func("output", "Output format: text", func(v string) error {
+		if v != outputText {
+			return fmt.Errorf("forecasts are only available as %s", outputText)
+		}
+		return nil
+	})
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if flags.days == 0 && flags.hours == 0 && !flags.hourly {
+		flags.days = defaultForecastDays
+	}
+	return a.report(fs, global, &flags)
+}
+
+// runLegacy implements "weather-reporter [flags] <location>", the alias of
+// now that also accepts the forecast flags and -version.
+func runLegacy(a *app, args []string) int {
+	fs, global := a.newFlagSet(legacyCommand())
+	versionFlag := fs.Bool("version", false, "Print version information")
+	var flags reportFlags
+	flags.registerOutput(fs, a.cfg)
+	flags.registerForecast(fs)
+	flags.registerUnits(fs, a.cfg)
+	flags.coords.register(fs)
+	flags.filter.register(fs)
+	registerPick(fs, &flags.pick)
+	registerLanguage(fs, &flags.lang, a.cfg)
+	registerLocale(fs, &flags.locale)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+
+	if *versionFlag {
+		printVersion(a.stdout)
+		return 0
+	}
+	return a.report(fs, global, &flags)
+}
+
+// report looks up the location given by the parsed arguments of fs and
+// prints the report selected by flags.
+func (a *app) report(fs *flag.FlagSet, global *globalFlags, flags *reportFlags) int {
+	coords := flags.coords
+	locationArgs := fs.Args()
+	if len(locationArgs) == 0 && !coords.isSet() {
+		printUsage(a.stdout)
+		return 1
+	}
+
+	opts, err := flags.options()
+	if err == nil {
+		err = global.validate()
+	}
+	if err == nil {
+		opts.locale, err = a.locale(flags.locale)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Keep stdout clean for machine-readable output.
+	promptOut := a.stdout
+	if opts.output == outputJSON {
+		promptOut = a.stderr
+	}
+	l, err := a.newLocator(flags, promptOut, global.noCache)
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+	l.locale = opts.locale
+	l.verbose = global.verbose
+
+	ctx, cancel := context.WithTimeout(context.Background(), global.timeout)
+	defer cancel()
+
+	// 1. Resolve location
+	var selectedLocation models.Location
+	var ok bool
+	var code int
+	if coords.isSet() {
+		selectedLocation, code, ok = l.fromCoordinateFlags(ctx, coords, locationArgs)
+	} else {
+		selectedLocation, code, ok = l.locate(ctx, strings.Join(locationArgs, " "))
+	}
+	if !ok {
+		return code
+	}
+
+	// 2. Get and print Forecast or Weather
+	return report(ctx, a.stdout, a.stderr, a.svc.weatherService(global.noCache, staleWarning(a.stderr)), selectedLocation, opts)
+}
+
+// report fetches and prints the report selected by opts for loc.
+func report(ctx context.Context, stdout, stderr io.Writer, weatherClient models.WeatherService, loc models.Location, opts reportOptions) int {
+	switch {
+	case opts.hours > 0:
+		return reportHourlyForecast(ctx, stdout, stderr, weatherClient, loc, opts)
+	case opts.days > 0:
+		return reportDailyForecast(ctx, stdout, stderr, weatherClient, loc, opts)
+	default:
+		return reportCurrentWeather(ctx, stdout, stderr, weatherClient, loc, opts)
+	}
+}
+
+// reportCurrentWeather fetches and prints the current weather for loc.
+func reportCurrentWeather(ctx context.Context, stdout, stderr io.Writer, weatherClient models.WeatherService, loc models.Location, opts reportOptions) int {
+	weatherData, err := weatherClient.GetCurrentWeather(ctx, loc.Latitude, loc.Longitude, opts.units)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error fetching weather: %v\n", err)
+		return weatherExitCode(err)
+	}
+
+	printWeather := func(out io.Writer, loc models.Location, w models.WeatherResponse) error {
+		return ui.PrintWeather(out, opts.locale, loc, w)
+	}
+	if opts.output == outputJSON {
+		printWeather = ui.PrintWeatherJSON
+	}
+	if err := printWeather(stdout, loc, weatherData); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error printing weather: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// reportDailyForecast fetches and prints a daily forecast for loc.
+func reportDailyForecast(ctx context.Context, stdout, stderr io.Writer, weatherClient models.WeatherService, loc models.Location, opts reportOptions) int {
+	forecast, err := weatherClient.GetDailyForecast(ctx, loc.Latitude, loc.Longitude, opts.days, opts.units)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error fetching forecast: %v\n", err)
+		return weatherExitCode(err)
+	}
+
+	if err := ui.PrintDailyForecast(stdout, opts.locale, loc, forecast); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error printing forecast: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// reportHourlyForecast fetches and prints an hourly forecast for loc.
+func reportHourlyForecast(ctx context.Context, stdout, stderr io.Writer, weatherClient models.WeatherService, loc models.Location, opts reportOptions) int {
+	forecast, err := weatherClient.GetHourlyForecast(ctx, loc.Latitude, loc.Longitude, opts.hours, opts.units)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error fetching forecast: %v\n", err)
+		return weatherExitCode(err)
+	}
+
+	if err := ui.PrintHourlyForecast(stdout, opts.locale, loc, forecast); err != nil {
+		_, _ = fmt.Fprintf(stderr, "Error printing forecast: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/ui"
+)
+
+// Output formats accepted by search --output. JSON shares its name with
+// the report format.
+const (
+	outputTable = "table"
+	outputCSV   = "csv"
+)
+
+// searchFlags holds the raw values of the search command's flags.
+type searchFlags struct {
+	count    int
+	language string
+	output   string
+	filter   filterFlags
+}
+
+// register defines the search flags on fs, with defaults taken from cfg.
+func (f *searchFlags) register(fs *flag.FlagSet, cfg config.Config) {
+	output := outputTable
+	if cfg.Output == outputJSON {
+		output = outputJSON
+	}
+	fs.IntVar(&f.count, "count", cfg.SearchCount, fmt.Sprintf("Maximum number of locations to list (1-%d)", config.MaxSearchCount))
+	registerLanguage(fs, &f.language, cfg)
+	registerLanguageAlias(fs)
+	fs.StringVar(&f.output, "output", output, "Output format: table, json or csv")
+	f.filter.register(fs)
+}
+
+// options validates the search flags and resolves them into the options
+// passed to the geocoder and the filter applied to its results.
+func (f *searchFlags) options() (models.SearchOptions, geo.Filter, error) {
+	if f.count < 1 || f.count > config.MaxSearchCount {
+		return models.SearchOptions{}, geo.Filter{}, fmt.Errorf("--count must be between 1 and %d", config.MaxSearchCount)
+	}
+	lang, err := config.ParseLanguage(f.language)
+	if err != nil {
+		return models.SearchOptions{}, geo.Filter{}, fmt.Errorf("--lang: %w", err)
+	}
+	switch f.output {
+	case outputTable, outputJSON, outputCSV:
+	default:
+		return models.SearchOptions{}, geo.Filter{}, fmt.Errorf("unknown output format %q (must be %s, %s or %s)", f.output, outputTable, outputJSON, outputCSV)
+	}
+	filter, err := f.filter.filter()
+	if err != nil {
+		return models.SearchOptions{}, geo.Filter{}, err
+	}
+	return models.SearchOptions{Count: f.count, Language: lang}, filter, nil
+}
+
+// runSearch implements the search command: it lists the locations the
+// geocoder returns for a name without fetching any weather.
+func runSearch(a *app, args []string) int {
+	cmd, _ := lookupCommand("search")
+	fs, global := a.newFlagSet(cmd)
+	var flags searchFlags
+	flags.register(fs, a.cfg)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 1
+	}
+	if err := global.validate(); err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+	opts, filter, err := flags.options()
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), global.timeout)
+	defer cancel()
+
+	name := strings.Join(fs.Args(), " ")
+	locations, err := geo.SearchQuery(ctx, a.svc.geocoder(global.noCache), name, filter, opts)
+	if err != nil {
+		return searchFailed(a.stderr, err, global.verbose)
+	}
+	// Filtering may have requested more results than asked for.
+	if len(locations) > opts.Count {
+		locations = locations[:opts.Count]
+	}
+
+	if err := printSearchResults(a, flags.output, name, locations); err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error printing locations: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// printSearchResults writes locations in the given output format. JSON
+// and CSV output is written even when nothing matched, so that scripts
+// always receive a well-formed document.
+func printSearchResults(a *app, output, name string, locations []models.Location) error {
+	switch output {
+	case outputJSON:
+		return ui.PrintLocationsJSON(a.stdout, name, locations)
+	case outputCSV:
+		return ui.PrintLocationsCSV(a.stdout, locations)
+	}
+	if len(locations) == 0 {
+		_, err := fmt.Fprintf(a.stdout, "Location not found: %s\n", name)
+		return err
+	}
+	return ui.PrintLocationTable(a.stdout, locations)
+}
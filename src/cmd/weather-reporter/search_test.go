@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var portlandCandidates = []models.Location{
+	{ID: 5746545, Name: "Portland", Latitude: 45.52345, Longitude: -122.67621, Country: "United States", CountryCode: "US", Region: "Oregon", Population: 652503},
+	{ID: 4975802, Name: "Portland", Latitude: 43.66147, Longitude: -70.25533, Country: "United States", CountryCode: "US", Region: "Maine"},
+}
+
+func TestRun_SearchOptions(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", models.SearchOptions{Count: 25, Language: "fr", CountryCode: "US"}).Return(portlandCandidates, nil)
+
+	code, stdout, stderr := runWith([]string{"search", "--count", "25", "--country", "us", "--language", "FR", "Portland"}, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "5746545  Portland  United States  US    Oregon  45.5234   -122.6762")
+	geoClient.AssertExpectations(t)
+}
+
+func TestRun_SearchDefaultsFromConfig(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", models.SearchOptions{Count: 5, Language: "de"}).Return(portlandCandidates, nil)
+	path := writeConfigFile(t, "search_count: 5\nlanguage: de\noutput: json\n")
+
+	code, stdout, stderr := runWithConfig([]string{"search", "Portland"}, path, nil, services{geo: geoClient, weather: &mockWeatherService{}})
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, `"schema_version": 1`)
+	geoClient.AssertExpectations(t)
+}
+
+func TestRun_SearchJSON(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+
+	code, stdout, stderr := runWith([]string{"search", "--output", "json", "Portland"}, geoClient, &mockWeatherService{})
+
+	require.Equal(t, 0, code, stderr)
+	var doc struct {
+		Query   string `json:"query"`
+		Results []struct {
+			ID          int    `json:"id"`
+			CountryCode string `json:"country_code"`
+			Admin1      string `json:"admin1"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &doc))
+	assert.Equal(t, "Portland", doc.Query)
+	require.Len(t, doc.Results, 2)
+	assert.Equal(t, 4975802, doc.Results[1].ID)
+	assert.Equal(t, "US", doc.Results[1].CountryCode)
+	assert.Equal(t, "Maine", doc.Results[1].Admin1)
+}
+
+func TestRun_SearchCSV(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+
+	code, stdout, stderr := runWith([]string{"search", "--output", "csv", "Portland"}, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Equal(t, ""+
+		"id,name,country,country_code,admin1,latitude,longitude\n"+
+		"5746545,Portland,United States,US,Oregon,45.52345,-122.67621\n"+
+		"4975802,Portland,United States,US,Maine,43.66147,-70.25533\n", stdout)
+}
+
+func TestRun_SearchNoMatches(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{output: "table", want: "Location not found: Nowhere\n"},
+		{output: "csv", want: "id,name,country,country_code,admin1,latitude,longitude\n"},
+		{output: "json", want: `"results": []`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+			geoClient.On("Search", mock.Anything, "Nowhere", mock.Anything).Return([]models.Location{}, nil)
+
+			code, stdout, _ := runWith([]string{"search", "--output", tt.output, "Nowhere"}, geoClient, &mockWeatherService{})
+
+			assert.Equal(t, 0, code)
+			assert.Contains(t, stdout, tt.want)
+		})
+	}
+}
+
+func TestRun_SearchHelpListsLanguageOnce(t *testing.T) {
+	code, _, stderr := runWith([]string{"search", "-h"}, &mockGeocodingService{}, &mockWeatherService{})
+
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stderr, "  -lang string\n")
+	assert.Contains(t, stderr, "(alias: --language)")
+	assert.NotContains(t, stderr, "-language string")
+	assert.Contains(t, stderr, "-count int\n    \tMaximum number of locations to list (1-100) (default 10)")
+}
+
+func TestRun_SearchInvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "Count Too Small", args: []string{"--count", "0"}, want: "--count must be between 1 and 100"},
+		{name: "Count Too Large", args: []string{"--count", "101"}, want: "--count must be between 1 and 100"},
+		{name: "Country", args: []string{"--country", "USA"}, want: `invalid country code "USA"`},
+		{name: "Language", args: []string{"--language", "english"}, want: "--lang: must be a two-letter ISO 639-1 language code"},
+		{name: "Lang", args: []string{"--lang", "english"}, want: "--lang: must be a two-letter ISO 639-1 language code"},
+		{name: "Output", args: []string{"--output", "xml"}, want: `unknown output format "xml" (must be table, json or csv)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geoClient := &mockGeocodingService{}
+
+			code, _, stderr := runWith(append(append([]string{"search"}, tt.args...), "Portland"), geoClient, &mockWeatherService{})
+
+			assert.Equal(t, 1, code)
+			assert.Contains(t, stderr, tt.want)
+			geoClient.AssertNotCalled(t, "Search", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRun_SearchFilter(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Portland", models.SearchOptions{Count: geo.FilterSearchCount, Language: "en"}).Return(portlandCandidates, nil)
+
+	code, stdout, stderr := runWith([]string{"search", "--count", "1", "--region", "Maine", "Portland, US"}, geoClient, &mockWeatherService{})
+
+	assert.Equal(t, 0, code, stderr)
+	assert.Contains(t, stdout, "4975802  Portland  United States  US    Maine")
+	assert.NotContains(t, stdout, "Oregon")
+}
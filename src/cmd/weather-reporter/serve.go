@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"weather-reporter/src/internal/grpcapi"
+	"weather-reporter/src/internal/server"
+)
+
+const (
+	// defaultServeAddr is the address the serve command listens on.
+	defaultServeAddr = "localhost:8080"
+
+	// defaultShutdownTimeout is how long the serve command waits for
+	// requests in flight when it is stopped.
+	defaultShutdownTimeout = 10 * time.Second
+)
+
+// signalContext returns a context that is done once the process receives
+// SIGINT or SIGTERM.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+// runServe implements the serve command: it serves the JSON HTTP API, the
+// gRPC API if --grpc-addr is given and the runtime metrics if --debug-addr
+// is given, until it is interrupted, then finishes the requests in flight.
+func runServe(a *app, args []string) int {
+	cmd, _ := lookupCommand("serve")
+	fs, global := a.newFlagSet(cmd)
+	fs.Lookup("timeout").Usage = "Maximum time for each request"
+	addr := fs.String("addr", defaultServeAddr, "Address to listen on, as host:port")
+	grpcAddr := fs.String("grpc-addr", "", "Address to serve the gRPC API on, as host:port (default: no gRPC API)")
+	debugAddr := fs.String("debug-addr", "", "Address to serve the runtime metrics at /debug/vars on, as host:port (default: no metrics)")
+	shutdownTimeout := fs.Duration("shutdown-timeout", defaultShutdownTimeout, "Maximum time to finish requests in flight when stopping")
+	var flags reportFlags
+	flags.registerUnits(fs, a.cfg)
+	if code, ok := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 1
+	}
+	units, err := flags.selectedUnits()
+	if err == nil {
+		err = global.validate()
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	lns, err := listenServe(*addr, *grpcAddr, *debugAddr)
+	if err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+	opts := server.Options{
+		Geocoder:       a.svc.geocoder(global.noCache),
+		Reverse:        a.svc.reverseGeocoder(global.noCache),
+		Weather:        a.svc.weatherService(global.noCache, nil),
+		Units:          units,
+		RequestTimeout: global.timeout,
+	}
+	if global.verbose {
+		opts.ErrorLog = a.stderr
+	}
+	srv := server.New(*addr, opts)
+	servers := []func(context.Context) error{func(ctx context.Context) error {
+		return server.Serve(ctx, srv, lns.http, *shutdownTimeout)
+	}}
+	_, _ = fmt.Fprintf(a.stderr, "Listening on http://%s\n", lns.http.Addr())
+	if lns.grpc != nil {
+		grpcSrv := grpcapi.New(grpcapi.Options{
+			Geocoder:       opts.Geocoder,
+			Reverse:        opts.Reverse,
+			Weather:        opts.Weather,
+			Units:          units,
+			RequestTimeout: global.timeout,
+		})
+		servers = append(servers, func(ctx context.Context) error {
+			return grpcSrv.Serve(ctx, lns.grpc, *shutdownTimeout)
+		})
+		_, _ = fmt.Fprintf(a.stderr, "Serving gRPC on %s\n", lns.grpc.Addr())
+	}
+	if lns.debug != nil {
+		debugSrv := server.NewDebug(*debugAddr)
+		servers = append(servers, func(ctx context.Context) error {
+			return server.Serve(ctx, debugSrv, lns.debug, *shutdownTimeout)
+		})
+		_, _ = fmt.Fprintf(a.stderr, "Serving metrics on http://%s/debug/vars\n", lns.debug.Addr())
+	}
+
+	stop := a.svc.stopContext
+	if stop == nil {
+		stop = signalContext
+	}
+	ctx, cancel := stop()
+	defer cancel()
+
+	if err := serveAll(ctx, servers); err != nil {
+		_, _ = fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+	_, _ = fmt.Fprintln(a.stderr, "Server stopped")
+	return 0
+}
+
+// serveListeners are the listeners of the serve command. grpc and debug
+// are nil unless the gRPC API and the runtime metrics are enabled.
+type serveListeners struct {
+	http, grpc, debug net.Listener
+}
+
+// listenServe listens on the HTTP address, and on the gRPC and debug
+// addresses unless they are empty.
+func listenServe(httpAddr, grpcAddr, debugAddr string) (serveListeners, error) {
+	var lns serveListeners
+	var err error
+	if lns.http, err = net.Listen("tcp", httpAddr); err != nil {
+		return serveListeners{}, err
+	}
+	if grpcAddr != "" {
+		if lns.grpc, err = net.Listen("tcp", grpcAddr); err != nil {
+			lns.close()
+			return serveListeners{}, err
+		}
+	}
+	if debugAddr != "" {
+		if lns.debug, err = net.Listen("tcp", debugAddr); err != nil {
+			lns.close()
+			return serveListeners{}, err
+		}
+	}
+	return lns, nil
+}
+
+// close closes the listeners that are open.
+func (lns serveListeners) close() {
+	for _, ln := range []net.Listener{lns.http, lns.grpc, lns.debug} {
+		if ln != nil {
+			_ = ln.Close()
+		}
+	}
+}
+
+// serveAll runs servers until ctx is done. A server that fails stops the
+// others, and the first error is returned once all of them have returned.
+func serveAll(ctx context.Context, servers []func(context.Context) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errc := make(chan error, len(servers))
+	for _, serve := range servers {
+		go func() {
+			err := serve(ctx)
+			cancel()
+			errc <- err
+		}()
+	}
+	var first error
+	for range servers {
+		if err := <-errc; err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/grpcapi/weatherpb"
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// freeAddr returns a local address that is free to listen on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+	return addr
+}
+
+// getWhenReady requests url, retrying until the server accepts
+// connections.
+func getWhenReady(t *testing.T, url string) *http.Response {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := http.Get(url)
+		if err == nil {
+			return resp
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("server did not start: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRun_Serve(t *testing.T) {
+	geoClient := &mockGeocodingService{}
+	geoClient.On("Search", mock.Anything, "Berlin", mock.Anything).Return([]models.Location{berlin}, nil)
+	geoClient.On("Search", mock.Anything, "Portland", mock.Anything).Return(portlandCandidates, nil)
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.ImperialUnits()).Return(stubWeatherResponse{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := services{geo: geoClient, weather: weatherClient, isInteractive: notInteractive,
+		stopContext: func() (context.Context, context.CancelFunc) { return ctx, cancel }}
+	addr := freeAddr(t)
+	var stdout, stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run([]string{"serve", "--addr", addr, "--units", "imperial"}, strings.NewReader(""), &stdout, &stderr, svc)
+	}()
+
+	resp := getWhenReady(t, "http://"+addr+"/v1/weather?location=Berlin")
+	var doc struct {
+		Location struct {
+			Name string `json:"name"`
+		} `json:"location"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Berlin", doc.Location.Name)
+
+	resp, err := http.Get("http://" + addr + "/v1/weather?location=Portland")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusMultipleChoices, resp.StatusCode)
+
+	cancel()
+	assert.Equal(t, 0, <-done)
+	assert.Equal(t, "Listening on http://"+addr+"\nServer stopped\n", stderr.String())
+	assert.Empty(t, stdout.String())
+	weatherClient.AssertExpectations(t)
+}
+
+func TestRun_ServeGRPC(t *testing.T) {
+	weatherClient := &mockWeatherService{}
+	weatherClient.On("GetCurrentWeather", mock.Anything, 52.52, 13.41, models.MetricUnits()).Return(stubWeatherResponse{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := services{geo: &mockGeocodingService{}, weather: weatherClient, isInteractive: notInteractive,
+		stopContext: func() (context.Context, context.CancelFunc) { return ctx, cancel }}
+	addr, grpcAddr := freeAddr(t), freeAddr(t)
+	var stdout, stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run([]string{"serve", "--addr", addr, "--grpc-addr", grpcAddr}, strings.NewReader(""), &stdout, &stderr, svc)
+	}()
+
+	conn, err := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	callCtx, callCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer callCancel()
+	resp, err := weatherpb.NewWeatherReporterClient(conn).GetCurrentWeather(callCtx, &weatherpb.GetCurrentWeatherRequest{
+		Location: &weatherpb.LocationQuery{Location: &weatherpb.LocationQuery_Name{Name: "52.52,13.41"}},
+	}, grpc.WaitForReady(true))
+	require.NoError(t, err)
+	assert.Equal(t, 52.52, resp.GetLocation().GetLatitude())
+
+	cancel()
+	assert.Equal(t, 0, <-done)
+	assert.Equal(t, "Listening on http://"+addr+"\nServing gRPC on "+grpcAddr+"\nServer stopped\n", stderr.String())
+	weatherClient.AssertExpectations(t)
+}
+
+func TestRun_ServeDebug(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := services{geo: &mockGeocodingService{}, weather: &mockWeatherService{}, isInteractive: notInteractive,
+		stopContext: func() (context.Context, context.CancelFunc) { return ctx, cancel }}
+	addr, debugAddr := freeAddr(t), freeAddr(t)
+	var stdout, stderr bytes.Buffer
+	done := make(chan int, 1)
+	go func() {
+		done <- run([]string{"serve", "--addr", addr, "--debug-addr", debugAddr}, strings.NewReader(""), &stdout, &stderr, svc)
+	}()
+
+	resp := getWhenReady(t, "http://"+debugAddr+"/debug/vars")
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp = getWhenReady(t, "http://"+addr+"/debug/vars")
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "the API address does not expose the metrics")
+
+	cancel()
+	assert.Equal(t, 0, <-done)
+	assert.Equal(t, "Listening on http://"+addr+"\nServing metrics on http://"+debugAddr+"/debug/vars\nServer stopped\n", stderr.String())
+}
+
+func TestRun_ServeErrors(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+
+	tests := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"serve", "--units", "kelvin"}, `Error: unknown unit system "kelvin" (must be metric or imperial)`},
+		{[]string{"serve", "--timeout", "0s"}, "Error: --timeout must be positive"},
+		{[]string{"serve", "--addr", ln.Addr().String()}, "address already in use"},
+		{[]string{"serve", "--addr", "127.0.0.1:0", "--grpc-addr", ln.Addr().String()}, "address already in use"},
+		{[]string{"serve", "--addr", "127.0.0.1:0", "--grpc-addr", "127.0.0.1:0", "--debug-addr", ln.Addr().String()}, "address already in use"},
+		{[]string{"serve", "Berlin"}, "Usage: weather-reporter serve [flags]"},
+	}
+
+	for _, tt := range tests {
+		code, _, stderr := runWith(tt.args, &mockGeocodingService{}, &mockWeatherService{})
+
+		assert.Equal(t, 1, code, tt.args)
+		assert.Contains(t, stderr, tt.err, tt.args)
+	}
+}
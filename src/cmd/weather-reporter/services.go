@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/cache"
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/retry"
+	"weather-reporter/src/internal/weather"
+)
+
+// services holds the external dependencies of run so tests can replace them.
+type services struct {
+	geo           models.GeocodingService
+	reverse       models.ReverseGeocodingService
+	weather       models.WeatherService
+	isInteractive interactiveChecker
+
+	// nominatim, if set, is the online backend of the reverse geocoder,
+	// which reverseGeocoder wraps and uses instead of reverse.
+	nominatim *geo.NominatimClient
+
+	// breakers, if set, guard the geocoding and weather services. Nil
+	// calls them directly.
+	breakers *breakers
+
+	// openTerminal, if set, opens the terminal for the arrow-key picker.
+	// Nil always uses the numbered prompt.
+	openTerminal terminalOpener
+
+	// stopContext, if set, returns the context whose end stops the serve
+	// command. Nil stops it on SIGINT or SIGTERM.
+	stopContext func() (context.Context, context.CancelFunc)
+
+	// newClients, if set, creates the API clients above from the loaded
+	// configuration. Tests leave it nil and set the clients directly.
+	newClients func(cfg config.Config, s *services)
+
+	// lookupEnv reads environment variables. Nil means none are set.
+	lookupEnv func(string) (string, bool)
+
+	// configPath is the config file. Empty means only the defaults and the
+	// environment are used.
+	configPath string
+
+	// cacheDir is the root of the on-disk cache. Empty disables caching.
+	cacheDir string
+
+	// favoritesPath is the file holding saved favorites. Empty disables
+	// favorites.
+	favoritesPath string
+}
+
+// newAPIClients creates the production API clients using the HTTP and
+// search settings from cfg. The clients share an HTTP client that retries
+// transient failures; http_timeout bounds each attempt.
+func newAPIClients(cfg config.Config, s *services) {
+	httpClient := &http.Client{Transport: retry.NewTransport(http.DefaultTransport, retryPolicy(cfg))}
+	s.geo = geo.NewClient(httpClient, geo.WithCount(cfg.SearchCount), geo.WithLanguage(cfg.Language))
+	s.nominatim = geo.NewNominatimClient(httpClient, geo.WithNominatimLanguage(cfg.Language))
+	s.weather = weather.NewClient(httpClient)
+}
+
+// breakers are the circuit breakers around the API services. They are
+// created once per process, outliving the clients that newClients replaces,
+// so that every request of a long-running command shares their state.
+type breakers struct {
+	geo     *breaker.Breaker
+	reverse *breaker.Breaker
+	weather *breaker.Breaker
+}
+
+// newBreakers returns closed breakers with the default settings.
+func newBreakers() *breakers {
+	return &breakers{
+		geo:     breaker.New("geocoding", breaker.Settings{IsFailure: geoFailure}),
+		reverse: breaker.New("reverse_geocoding", breaker.Settings{IsFailure: reverseFailure}),
+		weather: breaker.New("weather", breaker.Settings{IsFailure: weatherFailure}),
+	}
+}
+
+// geoFailure reports whether a failed search counts against the geocoding
+// breaker. Rejected and canceled searches, and searches turned away by the
+// client's own concurrency limit, say nothing about the health of the
+// service.
+func geoFailure(err error) bool {
+	return !errors.Is(err, geo.ErrBadRequest) && !errors.Is(err, geo.ErrCanceled) &&
+		!errors.Is(err, context.Canceled) && !errors.Is(err, geo.ErrConcurrencyLimit)
+}
+
+// reverseFailure reports whether a failed Nominatim lookup counts against
+// the reverse geocoding breaker. Canceled lookups, and coordinates with no
+// place nearby, say nothing about the health of the service.
+func reverseFailure(err error) bool {
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, geo.ErrNoNearbyPlace)
+}
+
+// weatherFailure reports whether a failed weather request counts against
+// the weather breaker. Rejected and canceled requests, and requests turned
+// away by the client's own concurrency limit, say nothing about the health
+// of the service.
+func weatherFailure(err error) bool {
+	return !errors.Is(err, weather.ErrBadRequest) && !errors.Is(err, context.Canceled) &&
+		!errors.Is(err, weather.ErrConcurrencyLimit)
+}
+
+// retryPolicy returns the retry policy configured by cfg.
+func retryPolicy(cfg config.Config) retry.Policy {
+	policy := retry.DefaultPolicy
+	policy.Attempts = cfg.RetryAttempts
+	policy.Backoff = cfg.RetryBackoff
+	policy.AttemptTimeout = cfg.HTTPTimeout
+	return policy
+}
+
+// loadConfig loads the configuration from the config file and environment.
+func (s services) loadConfig() (config.Loaded, error) {
+	lookupEnv := s.lookupEnv
+	if lookupEnv == nil {
+		lookupEnv = func(string) (string, bool) { return "", false }
+	}
+	return config.Load(s.configPath, lookupEnv)
+}
+
+// configure returns the services with API clients created from cfg.
+func (s services) configure(cfg config.Config) services {
+	if s.newClients != nil {
+		s.newClients(cfg, &s)
+	}
+	return s
+}
+
+// locator returns a locator that uses the services to resolve locations.
+func (s services) locator(stdin io.Reader, stdout, stderr, prompt io.Writer, noCache bool) *locator {
+	return &locator{
+		stdin:         stdin,
+		stdout:        stdout,
+		stderr:        stderr,
+		prompt:        prompt,
+		geoClient:     s.geocoder(noCache),
+		reverse:       s.reverseGeocoder(noCache),
+		favoritesPath: s.favoritesPath,
+		isInteractive: s.isInteractive,
+		openTerminal:  s.openTerminal,
+	}
+}
+
+// geocoder returns the geocoding service behind its circuit breaker,
+// wrapped in the on-disk cache unless caching is disabled.
+func (s services) geocoder(noCache bool) models.GeocodingService {
+	var geoClient models.GeocodingService = s.geo
+	if s.breakers != nil {
+		geoClient = breaker.NewGeocodingService(geoClient, s.breakers.geo)
+	}
+	if noCache || s.cacheDir == "" {
+		return geoClient
+	}
+	store := cache.NewStore(filepath.Join(s.cacheDir, "geocoding"), cache.Options{
+		TTL:      geo.CacheTTL,
+		MaxBytes: geo.CacheMaxBytes,
+	})
+	return geo.NewCachedClient(geoClient, store)
+}
+
+// reverseGeocoder returns the reverse geocoding service. Nominatim is put
+// behind its own breaker, so that its failures never hold up name searches,
+// and, unless caching is disabled, the on-disk cache, so that it is asked
+// as rarely as its usage policy wants; the offline dataset answers when it
+// cannot.
+func (s services) reverseGeocoder(noCache bool) models.ReverseGeocodingService {
+	if s.nominatim == nil {
+		return s.reverse
+	}
+	var backend models.ReverseGeocodingService = s.nominatim
+	if s.breakers != nil {
+		backend = breaker.NewReverseGeocodingService(backend, s.breakers.reverse)
+	}
+	if !noCache && s.cacheDir != "" {
+		store := cache.NewStore(filepath.Join(s.cacheDir, "reverse"), cache.Options{
+			TTL:      geo.CacheTTL,
+			MaxBytes: geo.CacheMaxBytes,
+		})
+		backend = geo.NewCachedReverseGeocoder(backend, store, s.nominatim.Language())
+	}
+	return geo.NewReverseGeocoder(backend)
+}
+
+// weatherService returns the weather service behind its circuit breaker,
+// wrapped in the on-disk cache unless caching is disabled. Stale data is
+// served while the weather service is unreachable, or its breaker is open,
+// only if onStale is set to label it; the server modes leave it nil, as
+// their clients would not learn that the data is out of date.
+func (s services) weatherService(noCache bool, onStale weather.StaleFunc) models.WeatherService {
+	var weatherClient models.WeatherService = s.weather
+	if s.breakers != nil {
+		weatherClient = breaker.NewWeatherService(weatherClient, s.breakers.weather)
+	}
+	if noCache || s.cacheDir == "" {
+		return weatherClient
+	}
+	store := cache.NewStore(filepath.Join(s.cacheDir, "weather"), cache.Options{
+		TTL:      weather.CacheTTL,
+		MaxBytes: weather.CacheMaxBytes,
+	})
+	return weather.NewCachedClient(weatherClient, store, onStale)
+}
+
+// staleWarning returns a weather.StaleFunc that warns on stderr that the
+// weather shown is cached data of the given age.
+func staleWarning(stderr io.Writer) weather.StaleFunc {
+	return func(age time.Duration, err error) {
+		_, _ = fmt.Fprintf(stderr, "Warning: %v\n", err)
+		_, _ = fmt.Fprintf(stderr, "Showing cached data from %s ago; it may be out of date.\n", formatAge(age))
+	}
+}
@@ -0,0 +1,226 @@
+// Package breaker provides a circuit breaker that makes calls to a failing
+// backend fail fast instead of waiting for each one to time out, and
+// decorators applying it to the weather and geocoding services.
+package breaker
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned instead of calling the backend while a breaker is
+// open. Errors returned by a breaker are *OpenError values matching it.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// OpenError is returned by Breaker.Allow while the breaker is open.
+type OpenError struct {
+	// Name is the name of the breaker.
+	Name string
+	// RetryIn is the time left until the breaker lets a trial call
+	// through, or 0 if Trial is set.
+	RetryIn time.Duration
+	// Trial is set when the breaker is half-open and its trial call is
+	// still in flight, so it is being tried again already.
+	Trial bool
+}
+
+// Error names the unavailable service and when it will be tried again.
+func (e *OpenError) Error() string {
+	if e.Trial {
+		return fmt.Sprintf("%s service is unavailable after repeated failures, a trial request is in progress", e.Name)
+	}
+	return fmt.Sprintf("%s service is unavailable after repeated failures, retrying in %s", e.Name, e.RetryIn.Round(time.Second))
+}
+
+// Is makes errors.Is(err, ErrOpen) true for an *OpenError.
+func (e *OpenError) Is(target error) bool {
+	return target == ErrOpen
+}
+
+// State is the state of a breaker.
+type State int
+
+// Breaker states.
+const (
+	// Closed lets all calls through and counts consecutive failures.
+	Closed State = iota
+	// Open rejects all calls until OpenTimeout has passed.
+	Open
+	// HalfOpen lets a single trial call through, whose outcome closes or
+	// reopens the breaker.
+	HalfOpen
+)
+
+// String returns the name of s as used in the metrics.
+func (s State) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Settings configure a breaker. Zero values select the defaults.
+type Settings struct {
+	// FailureThreshold is the number of consecutive failures that opens
+	// the breaker. The default is 5.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before a trial call
+	// is let through. The default is 30s.
+	OpenTimeout time.Duration
+	// IsFailure reports whether an error returned by the backend counts
+	// as a failure. The default counts every error except cancellation by
+	// the caller.
+	IsFailure func(error) bool
+}
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+// metrics publishes the state of every breaker under "circuit_breakers"
+// in expvar, keyed by breaker name.
+var metrics = expvar.NewMap("circuit_breakers")
+
+// Breaker is a circuit breaker. It is safe for concurrent use, so that a
+// long-running process can share one per backend across all requests.
+type Breaker struct {
+	name     string
+	settings Settings
+	now      func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int // consecutive, while closed
+	openedAt time.Time
+	trial    bool // a half-open trial call is in flight
+	opens    int  // times the breaker opened
+	rejected int  // calls rejected while open
+}
+
+// New returns a closed breaker called name, which names it in errors and
+// metrics. A breaker created with the name of an earlier one replaces it
+// in the metrics.
+func New(name string, settings Settings) *Breaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = defaultFailureThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = defaultOpenTimeout
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(err error) bool { return !errors.Is(err, context.Canceled) }
+	}
+	b := &Breaker{name: name, settings: settings, now: time.Now}
+	metrics.Set(name, expvar.Func(func() any { return b.Snapshot() }))
+	return b
+}
+
+// Allow reports whether a call may go ahead. It returns an *OpenError
+// while the breaker is open, or half-open with a trial call in flight.
+// Every allowed call must be followed by a call to Record.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == Open {
+		if wait := b.openedAt.Add(b.settings.OpenTimeout).Sub(b.now()); wait > 0 {
+			b.rejected++
+			return &OpenError{Name: b.name, RetryIn: wait}
+		}
+		b.state = HalfOpen
+	}
+	if b.state == HalfOpen {
+		if b.trial {
+			b.rejected++
+			return &OpenError{Name: b.name, Trial: true}
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// Record reports the outcome of an allowed call.
+func (b *Breaker) Record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failed := err != nil && b.settings.IsFailure(err)
+	switch b.state {
+	case HalfOpen:
+		b.trial = false
+		if failed {
+			b.open()
+		} else if err == nil {
+			b.state, b.failures = Closed, 0
+		}
+	case Closed:
+		if !failed {
+			if err == nil {
+				b.failures = 0
+			}
+			return
+		}
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *Breaker) open() {
+	b.state, b.failures = Open, 0
+	b.openedAt = b.now()
+	b.opens++
+}
+
+// Do calls fn if the breaker allows it and records its outcome.
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	b.Record(err)
+	return err
+}
+
+// State returns the current state. An open breaker whose OpenTimeout has
+// passed is reported as half-open.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == Open && !b.now().Before(b.openedAt.Add(b.settings.OpenTimeout)) {
+		return HalfOpen
+	}
+	return b.state
+}
+
+// Metrics is a snapshot of a breaker's state, as published in expvar.
+type Metrics struct {
+	State               string `json:"state"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	Opens               int    `json:"opens"`
+	Rejected            int    `json:"rejected"`
+}
+
+// Snapshot returns the current metrics of the breaker.
+func (b *Breaker) Snapshot() Metrics {
+	state := b.State()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return Metrics{
+		State:               state.String(),
+		ConsecutiveFailures: b.failures,
+		Opens:               b.opens,
+		Rejected:            b.rejected,
+	}
+}
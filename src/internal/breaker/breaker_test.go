@@ -0,0 +1,129 @@
+package breaker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errBackend = errors.New("backend down")
+
+// newTestBreaker returns a breaker with a fake clock that tests advance by
+// changing the returned time.
+func newTestBreaker(name string, settings Settings) (*Breaker, *time.Time) {
+	b := New(name, settings)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func fail() error    { return errBackend }
+func succeed() error { return nil }
+
+func TestBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker("test", Settings{FailureThreshold: 3, OpenTimeout: time.Minute})
+
+	require.ErrorIs(t, b.Do(fail), errBackend)
+	require.ErrorIs(t, b.Do(fail), errBackend)
+	require.NoError(t, b.Do(succeed), "a success resets the count")
+	require.ErrorIs(t, b.Do(fail), errBackend)
+	require.ErrorIs(t, b.Do(fail), errBackend)
+	assert.Equal(t, Closed, b.State())
+
+	require.ErrorIs(t, b.Do(fail), errBackend)
+	assert.Equal(t, Open, b.State())
+
+	called := false
+	err := b.Do(func() error { called = true; return nil })
+	assert.False(t, called, "open breakers fail fast")
+	assert.ErrorIs(t, err, ErrOpen)
+	var openErr *OpenError
+	require.ErrorAs(t, err, &openErr)
+	assert.Equal(t, time.Minute, openErr.RetryIn)
+	assert.EqualError(t, err, "test service is unavailable after repeated failures, retrying in 1m0s")
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	b, now := newTestBreaker("test", Settings{FailureThreshold: 1, OpenTimeout: time.Minute})
+	require.ErrorIs(t, b.Do(fail), errBackend)
+
+	*now = now.Add(30 * time.Second)
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+
+	*now = now.Add(30 * time.Second)
+	assert.Equal(t, HalfOpen, b.State())
+	require.NoError(t, b.Allow(), "trial call")
+	err := b.Allow()
+	assert.ErrorIs(t, err, ErrOpen, "only one trial call at a time")
+	assert.EqualError(t, err, "test service is unavailable after repeated failures, a trial request is in progress")
+
+	b.Record(errBackend)
+	assert.Equal(t, Open, b.State(), "a failed trial reopens the breaker")
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+
+	*now = now.Add(time.Minute)
+	require.NoError(t, b.Do(succeed))
+	assert.Equal(t, Closed, b.State(), "a successful trial closes the breaker")
+	require.NoError(t, b.Allow())
+	require.NoError(t, b.Allow())
+}
+
+func TestBreaker_IsFailure(t *testing.T) {
+	b, _ := newTestBreaker("test", Settings{FailureThreshold: 1})
+
+	canceled := func() error { return context.Canceled }
+	require.ErrorIs(t, b.Do(canceled), context.Canceled)
+	assert.Equal(t, Closed, b.State(), "cancellation by the caller is not a failure")
+
+	ignored := errors.New("ignored")
+	b, _ = newTestBreaker("test", Settings{
+		FailureThreshold: 2,
+		IsFailure:        func(err error) bool { return !errors.Is(err, ignored) },
+	})
+	require.ErrorIs(t, b.Do(fail), errBackend)
+	require.ErrorIs(t, b.Do(func() error { return ignored }), ignored)
+	require.ErrorIs(t, b.Do(fail), errBackend)
+	assert.Equal(t, Open, b.State(), "ignored errors do not reset the count")
+}
+
+func TestBreaker_Defaults(t *testing.T) {
+	b, now := newTestBreaker("test", Settings{})
+
+	for range defaultFailureThreshold {
+		assert.Equal(t, Closed, b.State())
+		_ = b.Do(fail)
+	}
+	assert.Equal(t, Open, b.State())
+
+	*now = now.Add(defaultOpenTimeout)
+	assert.Equal(t, HalfOpen, b.State())
+}
+
+func TestBreaker_Metrics(t *testing.T) {
+	b, _ := newTestBreaker("metrics-test", Settings{FailureThreshold: 2})
+
+	_ = b.Do(fail)
+	assert.Equal(t, Metrics{State: "closed", ConsecutiveFailures: 1}, b.Snapshot())
+
+	_ = b.Do(fail)
+	_ = b.Do(succeed)
+	_ = b.Do(succeed)
+	assert.Equal(t, Metrics{State: "open", Opens: 1, Rejected: 2}, b.Snapshot())
+
+	var published map[string]Metrics
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("circuit_breakers").String()), &published))
+	assert.Equal(t, b.Snapshot(), published["metrics-test"])
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", Closed.String())
+	assert.Equal(t, "open", Open.String())
+	assert.Equal(t, "half-open", HalfOpen.String())
+	assert.Equal(t, "State(7)", State(7).String())
+}
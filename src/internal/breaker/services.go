@@ -0,0 +1,96 @@
+package breaker
+
+import (
+	"context"
+
+	"weather-reporter/src/internal/models"
+)
+
+// WeatherService implements models.WeatherService by guarding another
+// service with a Breaker.
+type WeatherService struct {
+	next    models.WeatherService
+	breaker *Breaker
+}
+
+// NewWeatherService wraps next with b.
+func NewWeatherService(next models.WeatherService, b *Breaker) *WeatherService {
+	return &WeatherService{next: next, breaker: b}
+}
+
+// GetCurrentWeather delegates to the wrapped service unless the breaker is
+// open.
+func (s *WeatherService) GetCurrentWeather(ctx context.Context, lat, lon float64, units models.Units) (models.WeatherResponse, error) {
+	var w models.WeatherResponse
+	err := s.breaker.Do(func() (err error) {
+		w, err = s.next.GetCurrentWeather(ctx, lat, lon, units)
+		return err
+	})
+	return w, err
+}
+
+// GetDailyForecast delegates to the wrapped service unless the breaker is
+// open.
+func (s *WeatherService) GetDailyForecast(ctx context.Context, lat, lon float64, days int, units models.Units) ([]models.DailyForecast, error) {
+	var forecast []models.DailyForecast
+	err := s.breaker.Do(func() (err error) {
+		forecast, err = s.next.GetDailyForecast(ctx, lat, lon, days, units)
+		return err
+	})
+	return forecast, err
+}
+
+// GetHourlyForecast delegates to the wrapped service unless the breaker is
+// open.
+func (s *WeatherService) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int, units models.Units) ([]models.HourlyForecast, error) {
+	var forecast []models.HourlyForecast
+	err := s.breaker.Do(func() (err error) {
+		forecast, err = s.next.GetHourlyForecast(ctx, lat, lon, hours, units)
+		return err
+	})
+	return forecast, err
+}
+
+// GeocodingService implements models.GeocodingService by guarding another
+// service with a Breaker.
+type GeocodingService struct {
+	next    models.GeocodingService
+	breaker *Breaker
+}
+
+// NewGeocodingService wraps next with b.
+func NewGeocodingService(next models.GeocodingService, b *Breaker) *GeocodingService {
+	return &GeocodingService{next: next, breaker: b}
+}
+
+// Search delegates to the wrapped service unless the breaker is open.
+func (s *GeocodingService) Search(ctx context.Context, name string, opts models.SearchOptions) ([]models.Location, error) {
+	var locations []models.Location
+	err := s.breaker.Do(func() (err error) {
+		locations, err = s.next.Search(ctx, name, opts)
+		return err
+	})
+	return locations, err
+}
+
+// ReverseGeocodingService implements models.ReverseGeocodingService by
+// guarding another service with a Breaker.
+type ReverseGeocodingService struct {
+	next    models.ReverseGeocodingService
+	breaker *Breaker
+}
+
+// NewReverseGeocodingService wraps next with b.
+func NewReverseGeocodingService(next models.ReverseGeocodingService, b *Breaker) *ReverseGeocodingService {
+	return &ReverseGeocodingService{next: next, breaker: b}
+}
+
+// Reverse delegates to the wrapped service unless the breaker is open.
+func (s *ReverseGeocodingService) Reverse(ctx context.Context, lat, lon float64) (models.Location, error) {
+	var loc models.Location
+	err := s.breaker.Do(func() (err error) {
+		loc, err = s.next.Reverse(ctx, lat, lon)
+		return err
+	})
+	return loc, err
+}
@@ -0,0 +1,114 @@
+package breaker
+
+import (
+	"context"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingWeather fails every call and counts them.
+type failingWeather struct {
+	calls int
+}
+
+func (f *failingWeather) GetCurrentWeather(context.Context, float64, float64, models.Units) (models.WeatherResponse, error) {
+	f.calls++
+	return nil, errBackend
+}
+
+func (f *failingWeather) GetDailyForecast(context.Context, float64, float64, int, models.Units) ([]models.DailyForecast, error) {
+	f.calls++
+	return nil, errBackend
+}
+
+func (f *failingWeather) GetHourlyForecast(context.Context, float64, float64, int, models.Units) ([]models.HourlyForecast, error) {
+	f.calls++
+	return nil, errBackend
+}
+
+func TestWeatherService(t *testing.T) {
+	next := &failingWeather{}
+	b, _ := newTestBreaker("weather-test", Settings{FailureThreshold: 3})
+	s := NewWeatherService(next, b)
+	ctx := context.Background()
+	units := models.MetricUnits()
+
+	_, err := s.GetCurrentWeather(ctx, 1, 2, units)
+	require.ErrorIs(t, err, errBackend)
+	_, err = s.GetDailyForecast(ctx, 1, 2, 3, units)
+	require.ErrorIs(t, err, errBackend)
+	_, err = s.GetHourlyForecast(ctx, 1, 2, 3, units)
+	require.ErrorIs(t, err, errBackend)
+
+	_, err = s.GetCurrentWeather(ctx, 1, 2, units)
+	assert.ErrorIs(t, err, ErrOpen)
+	_, err = s.GetDailyForecast(ctx, 1, 2, 3, units)
+	assert.ErrorIs(t, err, ErrOpen)
+	_, err = s.GetHourlyForecast(ctx, 1, 2, 3, units)
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.Equal(t, 3, next.calls, "the breaker is shared by all methods")
+}
+
+// geocoder returns its result for every search and counts them.
+type geocoder struct {
+	locations []models.Location
+	err       error
+	calls     int
+}
+
+func (g *geocoder) Search(context.Context, string, models.SearchOptions) ([]models.Location, error) {
+	g.calls++
+	return g.locations, g.err
+}
+
+func TestGeocodingService(t *testing.T) {
+	next := &geocoder{locations: []models.Location{{Name: "Berlin"}}}
+	b, _ := newTestBreaker("geocoding-test", Settings{FailureThreshold: 1})
+	s := NewGeocodingService(next, b)
+
+	locations, err := s.Search(context.Background(), "Berlin", models.SearchOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, next.locations, locations)
+
+	next.err = errBackend
+	_, err = s.Search(context.Background(), "Berlin", models.SearchOptions{})
+	require.ErrorIs(t, err, errBackend)
+
+	_, err = s.Search(context.Background(), "Berlin", models.SearchOptions{})
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.Equal(t, 2, next.calls)
+}
+
+// reverseGeocoder returns its result for every lookup and counts them.
+type reverseGeocoder struct {
+	loc   models.Location
+	err   error
+	calls int
+}
+
+func (r *reverseGeocoder) Reverse(context.Context, float64, float64) (models.Location, error) {
+	r.calls++
+	return r.loc, r.err
+}
+
+func TestReverseGeocodingService(t *testing.T) {
+	next := &reverseGeocoder{loc: models.Location{Name: "Berlin"}}
+	b, _ := newTestBreaker("reverse-test", Settings{FailureThreshold: 1})
+	s := NewReverseGeocodingService(next, b)
+
+	loc, err := s.Reverse(context.Background(), 52.52, 13.41)
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", loc.Name)
+
+	next.err = errBackend
+	_, err = s.Reverse(context.Background(), 52.52, 13.41)
+	require.ErrorIs(t, err, errBackend)
+
+	_, err = s.Reverse(context.Background(), 52.52, 13.41)
+	assert.ErrorIs(t, err, ErrOpen)
+	assert.Equal(t, 2, next.calls)
+}
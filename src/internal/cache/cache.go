@@ -0,0 +1,188 @@
+// Package cache provides a small file-based key/value store used to keep
+// API responses between invocations of the CLI.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// appName is the directory created under the user's cache directory.
+const appName = "weather-reporter"
+
+// Dir returns the default cache directory: $XDG_CACHE_HOME/weather-reporter,
+// or the platform equivalent when XDG_CACHE_HOME is not set.
+func Dir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine cache directory: %w", err)
+	}
+	return filepath.Join(base, appName), nil
+}
+
+// Clear removes the cache directory and everything in it. A missing
+// directory is not an error.
+func Clear(dir string) error {
+	return os.RemoveAll(dir)
+}
+
+// Options configures a Store.
+type Options struct {
+	// TTL is how long an entry is considered fresh. Zero means entries
+	// never expire.
+	TTL time.Duration
+
+	// MaxBytes limits the total size of the store's files. When a write
+	// exceeds it, the oldest entries are removed. Zero means no limit.
+	MaxBytes int64
+}
+
+// Store is a directory of JSON entries, one file per key. All methods are
+// safe to call on a store whose directory does not exist yet.
+type Store struct {
+	dir  string
+	opts Options
+	now  func() time.Time
+}
+
+// NewStore creates a store that keeps its entries in dir.
+func NewStore(dir string, opts Options) *Store {
+	return &Store{dir: dir, opts: opts, now: time.Now}
+}
+
+// entry is the on-disk representation of a cached value.
+type entry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+// Get decodes the fresh entry for key into v. It reports false if there is
+// no entry, it has expired, or it cannot be read; a broken cache is treated
+// as a miss.
+func (s *Store) Get(key string, v any) bool {
+	e, ok := s.read(key)
+	if !ok {
+		return false
+	}
+	if s.opts.TTL > 0 && s.now().Sub(e.StoredAt) > s.opts.TTL {
+		return false
+	}
+	return json.Unmarshal(e.Value, v) == nil
+}
+
+// GetStale decodes the entry for key into v regardless of its age and
+// returns how old it is. It is meant for serving the last known value when
+// fresh data cannot be fetched.
+func (s *Store) GetStale(key string, v any) (time.Duration, bool) {
+	e, ok := s.read(key)
+	if !ok || json.Unmarshal(e.Value, v) != nil {
+		return 0, false
+	}
+	return s.now().Sub(e.StoredAt), true
+}
+
+// Put stores v under key and then enforces the size limit.
+func (s *Store) Put(key string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry{Key: key, StoredAt: s.now().UTC(), Value: value})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	// Write to a temporary file first so concurrent readers never see a
+	// partially written entry.
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path(key)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return s.prune()
+}
+
+func (s *Store) read(key string) (entry, bool) {
+	data, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return entry{}, false
+	}
+	var e entry
+	// The key is stored alongside the value to guard against hash collisions.
+	if err := json.Unmarshal(data, &e); err != nil || e.Key != key {
+		return entry{}, false
+	}
+	return e, true
+}
+
+// path returns the file that holds key. Keys are hashed so that any string,
+// including ones with path separators, maps to a safe file name.
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// prune removes the least recently written entries until the store fits
+// within MaxBytes.
+func (s *Store) prune() error {
+	if s.opts.MaxBytes <= 0 {
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	infos := make([]os.FileInfo, 0, len(files))
+	var total int64
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		infos = append(infos, info)
+		total += info.Size()
+	}
+	if total <= s.opts.MaxBytes {
+		return nil
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ModTime().Before(infos[j].ModTime())
+	})
+	for _, info := range infos {
+		if total <= s.opts.MaxBytes {
+			break
+		}
+		err := os.Remove(filepath.Join(s.dir, info.Name()))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		total -= info.Size()
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type value struct {
+	Name string `json:"name"`
+}
+
+func newTestStore(t *testing.T, opts Options) (*Store, *time.Time) {
+	t.Helper()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := NewStore(filepath.Join(t.TempDir(), "store"), opts)
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestStore_PutGet(t *testing.T) {
+	s, _ := newTestStore(t, Options{})
+
+	require.NoError(t, s.Put("berlin", value{Name: "Berlin"}))
+
+	var got value
+	assert.True(t, s.Get("berlin", &got))
+	assert.Equal(t, "Berlin", got.Name)
+	assert.False(t, s.Get("paris", &got))
+}
+
+func TestStore_TTL(t *testing.T) {
+	s, now := newTestStore(t, Options{TTL: time.Hour})
+	require.NoError(t, s.Put("berlin", value{Name: "Berlin"}))
+
+	var got value
+	*now = now.Add(59 * time.Minute)
+	assert.True(t, s.Get("berlin", &got))
+
+	*now = now.Add(2 * time.Minute)
+	assert.False(t, s.Get("berlin", &got))
+}
+
+func TestStore_GetStale(t *testing.T) {
+	s, now := newTestStore(t, Options{TTL: time.Hour})
+	require.NoError(t, s.Put("berlin", value{Name: "Berlin"}))
+	*now = now.Add(3 * time.Hour)
+
+	var got value
+	assert.False(t, s.Get("berlin", &got))
+	age, ok := s.GetStale("berlin", &got)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Hour, age)
+	assert.Equal(t, "Berlin", got.Name)
+
+	_, ok = s.GetStale("paris", &got)
+	assert.False(t, ok)
+}
+
+func TestStore_MaxBytes(t *testing.T) {
+	s, _ := newTestStore(t, Options{MaxBytes: 300})
+
+	for i, key := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, s.Put(key, value{Name: key}))
+		// Give each entry a distinct modification time.
+		mtime := time.Now().Add(time.Duration(i-10) * time.Second)
+		require.NoError(t, os.Chtimes(s.path(key), mtime, mtime))
+	}
+	require.NoError(t, s.Put("e", value{Name: "e"}))
+
+	var got value
+	assert.False(t, s.Get("a", &got), "oldest entry should have been evicted")
+	assert.True(t, s.Get("e", &got), "newest entry should be kept")
+
+	var total int64
+	files, _ := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	for _, f := range files {
+		info, err := os.Stat(f)
+		require.NoError(t, err)
+		total += info.Size()
+	}
+	assert.LessOrEqual(t, total, int64(300))
+}
+
+func TestStore_CorruptEntryIsMiss(t *testing.T) {
+	s, _ := newTestStore(t, Options{})
+	require.NoError(t, s.Put("berlin", value{Name: "Berlin"}))
+	require.NoError(t, os.WriteFile(s.path("berlin"), []byte("{not json"), 0o600))
+
+	var got value
+	assert.False(t, s.Get("berlin", &got))
+}
+
+func TestStore_MissingDirIsMiss(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "missing"), Options{})
+
+	var got value
+	assert.False(t, s.Get("berlin", &got))
+}
+
+func TestClear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "weather-reporter")
+	s := NewStore(filepath.Join(dir, "geocoding"), Options{})
+	require.NoError(t, s.Put("berlin", value{Name: "Berlin"}))
+
+	require.NoError(t, Clear(dir))
+	_, err := os.Stat(dir)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, Clear(dir), "clearing a missing cache is not an error")
+}
+
+func TestDir(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", "/tmp/xdg-cache")
+	t.Setenv("HOME", "/tmp/home")
+
+	dir, err := Dir()
+	require.NoError(t, err)
+	if filepath.Separator == '/' && dir != filepath.Join("/tmp/home", "Library", "Caches", appName) {
+		assert.Equal(t, filepath.Join("/tmp/xdg-cache", appName), dir)
+	}
+}
@@ -0,0 +1,506 @@
+// Package config loads the CLI settings from built-in defaults, the
+// user's locale, a YAML config file and environment variables, in
+// increasing order of precedence. Command-line flags, which take
+// precedence over all of these, are applied by the caller.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"weather-reporter/src/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of the environment variables that override
+// config file settings, e.g. WEATHER_REPORTER_UNITS.
+const EnvPrefix = "WEATHER_REPORTER_"
+
+// Output formats.
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+)
+
+// MaxSearchCount is the largest number of results the geocoding API
+// returns for one search.
+const MaxSearchCount = 100
+
+// MaxRetryAttempts is the largest number of attempts of one API request.
+const MaxRetryAttempts = 10
+
+// Config holds the settings of the CLI.
+type Config struct {
+	// Timeout bounds a whole command, including all API requests.
+	Timeout time.Duration
+	// HTTPTimeout bounds a single API request.
+	HTTPTimeout time.Duration
+	// RetryAttempts is the maximum number of attempts of an API request
+	// that fails transiently, including the first.
+	RetryAttempts int
+	// RetryBackoff is the delay before the first retry, doubled for each
+	// further retry.
+	RetryBackoff time.Duration
+	// SearchCount is the maximum number of locations a search returns.
+	SearchCount int
+	// Language is the language of location names.
+	Language string
+	// Output is the default output format: text or json.
+	Output string
+	// Units is the unit system: metric or imperial.
+	Units string
+	// TemperatureUnit, WindSpeedUnit and PrecipitationUnit override single
+	// units of the unit system. Empty means no override.
+	TemperatureUnit   string
+	WindSpeedUnit     string
+	PrecipitationUnit string
+	// Cache enables the on-disk cache.
+	Cache bool
+}
+
+// Default returns the built-in settings.
+func Default() Config {
+	return Config{
+		Timeout:       30 * time.Second,
+		HTTPTimeout:   10 * time.Second,
+		RetryAttempts: 3,
+		RetryBackoff:  500 * time.Millisecond,
+		SearchCount:   10,
+		Language:      "en",
+		Output:        OutputText,
+		Units:         models.UnitSystemMetric,
+		Cache:         true,
+	}
+}
+
+// Source tells where the value of a setting came from.
+type Source string
+
+// Setting sources, from lowest to highest precedence.
+const (
+	SourceDefault Source = "default"
+	SourceLocale  Source = "locale"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+)
+
+// Loaded is a Config together with the source of each setting.
+type Loaded struct {
+	Config
+	Sources map[string]Source
+}
+
+// DefaultPath returns the default config file:
+// $XDG_CONFIG_HOME/weather-reporter/config.yaml, or the platform
+// equivalent when XDG_CONFIG_HOME is not set.
+func DefaultPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine config directory: %w", err)
+	}
+	return filepath.Join(base, "weather-reporter", "config.yaml"), nil
+}
+
+// Load builds the configuration from the defaults, the config file at path
+// and the environment. A missing file is not an error; an empty path skips
+// the file. lookupEnv is usually os.LookupEnv.
+//
+// The default language is that of the user's locale when it is known, see
+// LocaleLanguage.
+func Load(path string, lookupEnv func(string) (string, bool)) (Loaded, error) {
+	l := Loaded{Config: Default(), Sources: map[string]Source{}}
+	for _, k := range keys {
+		l.Sources[k.name] = SourceDefault
+	}
+	if lang, ok := LocaleLanguage(lookupEnv); ok {
+		l.Language = lang
+		l.Sources["language"] = SourceLocale
+	}
+
+	if path != "" {
+		values, err := readFile(path)
+		if err != nil {
+			return Loaded{}, err
+		}
+		for _, kv := range values {
+			if err := l.apply(kv[0], kv[1], SourceFile); err != nil {
+				return Loaded{}, fmt.Errorf("config file %s: %w", path, err)
+			}
+		}
+	}
+
+	for _, k := range keys {
+		value, ok := lookupEnv(EnvVar(k.name))
+		if !ok {
+			continue
+		}
+		if err := l.apply(k.name, value, SourceEnv); err != nil {
+			return Loaded{}, fmt.Errorf("environment variable %s: %w", EnvVar(k.name), err)
+		}
+	}
+
+	return l, nil
+}
+
+func (l *Loaded) apply(name, value string, source Source) error {
+	k, err := lookup(name)
+	if err != nil {
+		return err
+	}
+	if err := k.set(&l.Config, value); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	l.Sources[name] = source
+	return nil
+}
+
+// EnvVar returns the environment variable that overrides key.
+func EnvVar(key string) string {
+	return EnvPrefix + strings.ToUpper(key)
+}
+
+// Keys returns the names of all settings in display order.
+func Keys() []string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.name
+	}
+	return names
+}
+
+// Get returns the value of the setting key formatted as in the config file.
+func (c Config) Get(key string) (string, error) {
+	k, err := lookup(key)
+	if err != nil {
+		return "", err
+	}
+	return k.get(c), nil
+}
+
+// Validate checks that value is valid for the setting key.
+func Validate(key, value string) error {
+	k, err := lookup(key)
+	if err != nil {
+		return err
+	}
+	cfg := Default()
+	if err := k.set(&cfg, value); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// key describes one setting: its name in the config file and how to
+// format and parse its value.
+type key struct {
+	name string
+	help string
+	get  func(Config) string
+	set  func(*Config, string) error
+}
+
+var keys = []key{
+	{
+		name: "timeout",
+		help: "Maximum time for a whole command, e.g. 30s",
+		get:  func(c Config) string { return c.Timeout.String() },
+		set:  func(c *Config, v string) error { return setDuration(&c.Timeout, v) },
+	},
+	{
+		name: "http_timeout",
+		help: "Maximum time for a single API request, e.g. 10s",
+		get:  func(c Config) string { return c.HTTPTimeout.String() },
+		set:  func(c *Config, v string) error { return setDuration(&c.HTTPTimeout, v) },
+	},
+	{
+		name: "retry_attempts",
+		help: fmt.Sprintf("Maximum attempts of an API request that fails transiently (1-%d); 1 disables retries", MaxRetryAttempts),
+		get:  func(c Config) string { return strconv.Itoa(c.RetryAttempts) },
+		set:  setRetryAttempts,
+	},
+	{
+		name: "retry_backoff",
+		help: "Delay before the first retry, doubled for each further retry, e.g. 500ms",
+		get:  func(c Config) string { return c.RetryBackoff.String() },
+		set:  func(c *Config, v string) error { return setDuration(&c.RetryBackoff, v) },
+	},
+	{
+		name: "search_count",
+		help: fmt.Sprintf("Maximum number of locations returned by a search (1-%d)", MaxSearchCount),
+		get:  func(c Config) string { return strconv.Itoa(c.SearchCount) },
+		set:  setSearchCount,
+	},
+	{
+		name: "language",
+		help: "Language of location names, as an ISO 639-1 code such as en or de",
+		get:  func(c Config) string { return c.Language },
+		set:  setLanguage,
+	},
+	{
+		name: "output",
+		help: "Default output format: text or json",
+		get:  func(c Config) string { return c.Output },
+		set:  setOutput,
+	},
+	{
+		name: "units",
+		help: "Unit system: metric or imperial",
+		get:  func(c Config) string { return c.Units },
+		set:  setUnits,
+	},
+	{
+		name: "temperature_unit",
+		help: "Temperature unit override: celsius or fahrenheit",
+		get:  func(c Config) string { return c.TemperatureUnit },
+		set: func(c *Config, v string) error {
+			return setUnitOverride(&c.TemperatureUnit, v, func(u *models.Units) error { return u.Override(v, "", "") })
+		},
+	},
+	{
+		name: "wind_speed_unit",
+		help: "Wind speed unit override: kmh, ms, mph or knots",
+		get:  func(c Config) string { return c.WindSpeedUnit },
+		set: func(c *Config, v string) error {
+			return setUnitOverride(&c.WindSpeedUnit, v, func(u *models.Units) error { return u.Override("", v, "") })
+		},
+	},
+	{
+		name: "precipitation_unit",
+		help: "Precipitation unit override: mm or inch",
+		get:  func(c Config) string { return c.PrecipitationUnit },
+		set: func(c *Config, v string) error {
+			return setUnitOverride(&c.PrecipitationUnit, v, func(u *models.Units) error { return u.Override("", "", v) })
+		},
+	},
+	{
+		name: "cache",
+		help: "Use the on-disk cache: true or false",
+		get:  func(c Config) string { return strconv.FormatBool(c.Cache) },
+		set:  setCache,
+	},
+}
+
+// Help returns the description of the setting key.
+func Help(name string) string {
+	k, err := lookup(name)
+	if err != nil {
+		return ""
+	}
+	return k.help
+}
+
+func lookup(name string) (key, error) {
+	for _, k := range keys {
+		if k.name == name {
+			return k, nil
+		}
+	}
+	return key{}, fmt.Errorf("unknown setting %q (valid settings: %s)", name, strings.Join(Keys(), ", "))
+}
+
+func setDuration(d *time.Duration, v string) error {
+	parsed, err := time.ParseDuration(v)
+	if err != nil || parsed <= 0 {
+		return fmt.Errorf("must be a positive duration such as 30s or 1m, got %q", v)
+	}
+	*d = parsed
+	return nil
+}
+
+func setSearchCount(c *Config, v string) error {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > MaxSearchCount {
+		return fmt.Errorf("must be a whole number between 1 and %d, got %q", MaxSearchCount, v)
+	}
+	c.SearchCount = n
+	return nil
+}
+
+func setRetryAttempts(c *Config, v string) error {
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > MaxRetryAttempts {
+		return fmt.Errorf("must be a whole number between 1 and %d, got %q", MaxRetryAttempts, v)
+	}
+	c.RetryAttempts = n
+	return nil
+}
+
+var languagePattern = regexp.MustCompile(`^[a-z]{2}$`)
+
+func setLanguage(c *Config, v string) error {
+	lang, err := ParseLanguage(v)
+	if err != nil {
+		return err
+	}
+	c.Language = lang
+	return nil
+}
+
+// ParseLanguage validates a two-letter ISO 639-1 language code such as
+// "de" and returns it in lower case.
+func ParseLanguage(v string) (string, error) {
+	lang := strings.ToLower(v)
+	if !languagePattern.MatchString(lang) {
+		return "", fmt.Errorf("must be a two-letter ISO 639-1 language code such as en or de, got %q", lang)
+	}
+	return lang, nil
+}
+
+// localeVariables are the environment variables naming the user's locale,
+// in decreasing order of precedence.
+var localeVariables = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// LocaleLanguage returns the language of the user's locale, taken from the
+// first of LC_ALL, LC_MESSAGES and LANG that is set, e.g. "de" for
+// "de_DE.UTF-8". It returns false for the C and POSIX locales and for
+// locales without a two-letter language code.
+func LocaleLanguage(lookupEnv func(string) (string, bool)) (string, bool) {
+	for _, name := range localeVariables {
+		value, ok := lookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+		lang := value
+		if i := strings.IndexAny(lang, "_.@-"); i >= 0 {
+			lang = lang[:i]
+		}
+		lang, err := ParseLanguage(lang)
+		return lang, err == nil
+	}
+	return "", false
+}
+
+func setOutput(c *Config, v string) error {
+	if v != OutputText && v != OutputJSON {
+		return fmt.Errorf("must be %s or %s, got %q", OutputText, OutputJSON, v)
+	}
+	c.Output = v
+	return nil
+}
+
+func setUnits(c *Config, v string) error {
+	if _, err := models.UnitsForSystem(v); err != nil {
+		return err
+	}
+	c.Units = v
+	return nil
+}
+
+func setUnitOverride(field *string, v string, check func(*models.Units) error) error {
+	var u models.Units
+	if err := check(&u); err != nil {
+		return err
+	}
+	*field = v
+	return nil
+}
+
+func setCache(c *Config, v string) error {
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("must be true or false, got %q", v)
+	}
+	c.Cache = b
+	return nil
+}
+
+// readFile returns the key/value pairs of the config file in file order.
+func readFile(path string) ([][2]string, error) {
+	doc, err := readDocument(path)
+	if err != nil {
+		return nil, err
+	}
+	root := documentRoot(doc)
+	if root == nil {
+		return nil, nil
+	}
+
+	values := make([][2]string, 0, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		k, v := root.Content[i], root.Content[i+1]
+		if v.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("config file %s: line %d: %s must be a single value", path, v.Line, k.Value)
+		}
+		values = append(values, [2]string{k.Value, v.Value})
+	}
+	return values, nil
+}
+
+// readDocument parses the config file. A missing file yields an empty
+// document.
+func readDocument(path string) (*yaml.Node, error) {
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &doc, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("config file %s: %w", path, err)
+	}
+	if len(doc.Content) > 0 && doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file %s: expected a list of settings such as \"units: metric\"", path)
+	}
+	return &doc, nil
+}
+
+// documentRoot returns the top-level mapping of doc, or nil if there is
+// none.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	return doc.Content[0]
+}
+
+// Set validates value and writes it for key to the config file at path,
+// creating the file if needed. Other settings and comments in the file are
+// preserved.
+func Set(path, key, value string) error {
+	if err := Validate(key, value); err != nil {
+		return err
+	}
+
+	doc, err := readDocument(path)
+	if err != nil {
+		return err
+	}
+	root := documentRoot(doc)
+	if root == nil {
+		root = &yaml.Node{Kind: yaml.MappingNode}
+		*doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}
+	}
+
+	updated := false
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == key {
+			root.Content[i+1].Kind = yaml.ScalarNode
+			root.Content[i+1].Tag = ""
+			root.Content[i+1].Value = value
+			updated = true
+		}
+	}
+	if !updated {
+		root.Content = append(root.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: value})
+	}
+
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
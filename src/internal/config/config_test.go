@@ -0,0 +1,198 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noEnv(string) (string, bool) { return "", false }
+
+func envMap(m map[string]string) func(string) (string, bool) {
+	return func(k string) (string, bool) {
+		v, ok := m[k]
+		return v, ok
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	l, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, Default(), l.Config)
+	for _, k := range Keys() {
+		assert.Equal(t, SourceDefault, l.Sources[k], k)
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfig(t, "# personal settings\nunits: imperial\ntimeout: 45s\nsearch_count: 5\n")
+	env := envMap(map[string]string{
+		"WEATHER_REPORTER_TIMEOUT": "1m",
+		"WEATHER_REPORTER_CACHE":   "false",
+	})
+
+	l, err := Load(path, env)
+	require.NoError(t, err)
+
+	assert.Equal(t, "imperial", l.Units)
+	assert.Equal(t, SourceFile, l.Sources["units"])
+	assert.Equal(t, 5, l.SearchCount)
+	assert.Equal(t, time.Minute, l.Timeout, "env overrides the file")
+	assert.Equal(t, SourceEnv, l.Sources["timeout"])
+	assert.False(t, l.Cache)
+	assert.Equal(t, 10*time.Second, l.HTTPTimeout)
+	assert.Equal(t, SourceDefault, l.Sources["http_timeout"])
+	assert.Equal(t, 3, l.RetryAttempts)
+	assert.Equal(t, 500*time.Millisecond, l.RetryBackoff)
+}
+
+func TestLoad_LocaleLanguage(t *testing.T) {
+	l, err := Load("", envMap(map[string]string{"LANG": "de_DE.UTF-8"}))
+	require.NoError(t, err)
+	assert.Equal(t, "de", l.Language)
+	assert.Equal(t, SourceLocale, l.Sources["language"])
+
+	path := writeConfig(t, "language: fr\n")
+	l, err = Load(path, envMap(map[string]string{"LANG": "de_DE.UTF-8"}))
+	require.NoError(t, err)
+	assert.Equal(t, "fr", l.Language, "the file overrides the locale")
+
+	l, err = Load("", envMap(map[string]string{"LANG": "de_DE.UTF-8", "WEATHER_REPORTER_LANGUAGE": "ja"}))
+	require.NoError(t, err)
+	assert.Equal(t, "ja", l.Language)
+	assert.Equal(t, SourceEnv, l.Sources["language"])
+}
+
+func TestLocaleLanguage(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want string
+		ok   bool
+	}{
+		{env: map[string]string{}, ok: false},
+		{env: map[string]string{"LANG": "de_DE.UTF-8"}, want: "de", ok: true},
+		{env: map[string]string{"LANG": "ja_JP"}, want: "ja", ok: true},
+		{env: map[string]string{"LANG": "fr"}, want: "fr", ok: true},
+		{env: map[string]string{"LANG": "sr_RS@latin"}, want: "sr", ok: true},
+		{env: map[string]string{"LANG": "de_DE.UTF-8", "LC_MESSAGES": "es_ES.UTF-8"}, want: "es", ok: true},
+		{env: map[string]string{"LANG": "de_DE.UTF-8", "LC_MESSAGES": "es_ES", "LC_ALL": "ja_JP.UTF-8"}, want: "ja", ok: true},
+		{env: map[string]string{"LANG": "de_DE.UTF-8", "LC_ALL": ""}, want: "de", ok: true},
+		{env: map[string]string{"LANG": "C.UTF-8"}, ok: false},
+		{env: map[string]string{"LANG": "POSIX"}, ok: false},
+		{env: map[string]string{"LC_ALL": "C", "LANG": "de_DE.UTF-8"}, ok: false},
+		{env: map[string]string{"LANG": "fil_PH"}, ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := LocaleLanguage(envMap(tt.env))
+		assert.Equal(t, tt.ok, ok, tt.env)
+		assert.Equal(t, tt.want, got, tt.env)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     map[string]string
+		want    string
+	}{
+		{name: "Unknown Key", content: "unit: metric\n", want: `unknown setting "unit"`},
+		{name: "Bad Duration", content: "timeout: soon\n", want: `timeout: must be a positive duration such as 30s or 1m, got "soon"`},
+		{name: "Negative Duration", content: "http_timeout: -5s\n", want: "http_timeout: must be a positive duration"},
+		{name: "Count Out Of Range", content: "search_count: 500\n", want: `search_count: must be a whole number between 1 and 100, got "500"`},
+		{name: "Retry Attempts Out Of Range", content: "retry_attempts: 0\n", want: `retry_attempts: must be a whole number between 1 and 10, got "0"`},
+		{name: "Bad Retry Backoff", content: "retry_backoff: 0s\n", want: "retry_backoff: must be a positive duration"},
+		{name: "Bad Units", content: "units: scientific\n", want: "units:"},
+		{name: "Bad Unit Override", content: "wind_speed_unit: furlongs\n", want: "wind_speed_unit:"},
+		{name: "Bad Output", content: "output: xml\n", want: `output: must be text or json, got "xml"`},
+		{name: "Bad Language", content: "language: english\n", want: "language: must be a two-letter ISO 639-1 language code"},
+		{name: "Nested Value", content: "units:\n  system: metric\n", want: "line 2: units must be a single value"},
+		{name: "Not A Mapping", content: "- units\n", want: "expected a list of settings"},
+		{name: "Invalid YAML", content: "units: [\n", want: "config file"},
+		{name: "Bad Env", env: map[string]string{"WEATHER_REPORTER_CACHE": "maybe"}, want: `environment variable WEATHER_REPORTER_CACHE: cache: must be true or false, got "maybe"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeConfig(t, tt.content)
+
+			_, err := Load(path, envMap(tt.env))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestLoad_EmptyFile(t *testing.T) {
+	l, err := Load(writeConfig(t, "# nothing yet\n"), noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, Default(), l.Config)
+}
+
+func TestConfig_Get(t *testing.T) {
+	cfg := Default()
+
+	v, err := cfg.Get("timeout")
+	require.NoError(t, err)
+	assert.Equal(t, "30s", v)
+
+	v, err = cfg.Get("cache")
+	require.NoError(t, err)
+	assert.Equal(t, "true", v)
+
+	_, err = cfg.Get("colour")
+	assert.ErrorContains(t, err, `unknown setting "colour"`)
+}
+
+func TestSet(t *testing.T) {
+	path := writeConfig(t, "# personal settings\nunits: imperial # US office\n")
+
+	require.NoError(t, Set(path, "units", "metric"))
+	require.NoError(t, Set(path, "language", "de"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# personal settings\nunits: metric # US office\nlanguage: de\n", string(data))
+
+	l, err := Load(path, noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, "metric", l.Units)
+	assert.Equal(t, "de", l.Language)
+}
+
+func TestSet_CreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weather-reporter", "config.yaml")
+
+	require.NoError(t, Set(path, "search_count", "3"))
+
+	l, err := Load(path, noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, 3, l.SearchCount)
+}
+
+func TestSet_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+
+	assert.ErrorContains(t, Set(path, "search_count", "zero"), "search_count: must be a whole number")
+	assert.ErrorContains(t, Set(path, "colour", "blue"), "unknown setting")
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "invalid settings must not be written")
+}
+
+func TestEnvVar(t *testing.T) {
+	assert.Equal(t, "WEATHER_REPORTER_HTTP_TIMEOUT", EnvVar("http_timeout"))
+}
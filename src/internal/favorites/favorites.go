@@ -0,0 +1,182 @@
+// Package favorites stores locations saved by the user under short aliases.
+package favorites
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"weather-reporter/src/internal/models"
+)
+
+// fileName is the name of the favorites file inside the config directory.
+const fileName = "favorites.json"
+
+var (
+	// ErrNotFound is returned when an alias is not saved.
+	ErrNotFound = errors.New("favorite not found")
+
+	// ErrExists is returned when saving an alias that is already taken.
+	ErrExists = errors.New("favorite already exists")
+)
+
+// aliasPattern restricts aliases to characters that need no quoting in a
+// shell.
+var aliasPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// DefaultPath returns the default favorites file:
+// $XDG_CONFIG_HOME/weather-reporter/favorites.json, or the platform
+// equivalent when XDG_CONFIG_HOME is not set.
+func DefaultPath() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine config directory: %w", err)
+	}
+	return filepath.Join(base, "weather-reporter", fileName), nil
+}
+
+// Favorite is a location saved under an alias.
+type Favorite struct {
+	Alias    string          `json:"alias"`
+	Location models.Location `json:"location"`
+}
+
+// Store is the set of favorites kept in a JSON file. Changes are only
+// written when Save is called.
+type Store struct {
+	path      string
+	favorites map[string]models.Location
+}
+
+// Load reads the favorites file at path. A missing file yields an empty
+// store.
+func Load(path string) (*Store, error) {
+	s := &Store{path: path, favorites: map[string]models.Location{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Favorite
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("invalid favorites file %s: %w", path, err)
+	}
+	for _, f := range list {
+		s.favorites[f.Alias] = f.Location
+	}
+	return s, nil
+}
+
+// Save writes the favorites back to the file, creating its directory if
+// needed.
+func (s *Store) Save() error {
+	data, err := json.MarshalIndent(s.List(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, append(data, '\n'), 0o600)
+}
+
+// List returns all favorites sorted by alias.
+func (s *Store) List() []Favorite {
+	list := make([]Favorite, 0, len(s.favorites))
+	for alias, loc := range s.favorites {
+		list = append(list, Favorite{Alias: alias, Location: loc})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Alias < list[j].Alias })
+	return list
+}
+
+// Get returns the location saved under alias. A leading "@" is ignored.
+func (s *Store) Get(alias string) (models.Location, error) {
+	loc, ok := s.favorites[Normalize(alias)]
+	if !ok {
+		return models.Location{}, fmt.Errorf("%w: @%s", ErrNotFound, Normalize(alias))
+	}
+	return loc, nil
+}
+
+// Add saves loc under alias.
+func (s *Store) Add(alias string, loc models.Location) error {
+	if err := s.CheckAvailable(alias); err != nil {
+		return err
+	}
+	s.favorites[Normalize(alias)] = loc
+	return nil
+}
+
+// CheckAvailable returns the error Add would return for alias, if any: it
+// is invalid or already taken. It lets callers reject an alias before
+// looking up the location to save.
+func (s *Store) CheckAvailable(alias string) error {
+	alias, err := validate(alias)
+	if err != nil {
+		return err
+	}
+	if _, ok := s.favorites[alias]; ok {
+		return fmt.Errorf("%w: @%s", ErrExists, alias)
+	}
+	return nil
+}
+
+// Remove deletes the favorite saved under alias.
+func (s *Store) Remove(alias string) error {
+	alias = Normalize(alias)
+	if _, ok := s.favorites[alias]; !ok {
+		return fmt.Errorf("%w: @%s", ErrNotFound, alias)
+	}
+	delete(s.favorites, alias)
+	return nil
+}
+
+// Rename moves the favorite saved under oldAlias to newAlias.
+func (s *Store) Rename(oldAlias, newAlias string) error {
+	oldAlias = Normalize(oldAlias)
+	loc, ok := s.favorites[oldAlias]
+	if !ok {
+		return fmt.Errorf("%w: @%s", ErrNotFound, oldAlias)
+	}
+	newAlias, err := validate(newAlias)
+	if err != nil {
+		return err
+	}
+	if newAlias == oldAlias {
+		return nil
+	}
+	if _, ok := s.favorites[newAlias]; ok {
+		return fmt.Errorf("%w: @%s", ErrExists, newAlias)
+	}
+	delete(s.favorites, oldAlias)
+	s.favorites[newAlias] = loc
+	return nil
+}
+
+// IsAlias reports whether query refers to a favorite, i.e. starts with "@".
+func IsAlias(query string) bool {
+	return strings.HasPrefix(query, "@")
+}
+
+// Normalize strips the "@" prefix from an alias.
+func Normalize(alias string) string {
+	return strings.TrimPrefix(alias, "@")
+}
+
+func validate(alias string) (string, error) {
+	alias = Normalize(alias)
+	if !aliasPattern.MatchString(alias) {
+		return "", fmt.Errorf("invalid alias %q: use letters, digits, '-', '_' or '.', starting with a letter or digit", alias)
+	}
+	return alias, nil
+}
@@ -0,0 +1,105 @@
+package favorites
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var london = models.Location{ID: 2643743, Name: "London", Latitude: 51.50853, Longitude: -0.12574, Country: "United Kingdom", Region: "England"}
+
+func TestStore_AddSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "weather-reporter", fileName)
+	s, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, s.List())
+
+	require.NoError(t, s.Add("@office", london))
+	require.NoError(t, s.Save())
+
+	s, err = Load(path)
+	require.NoError(t, err)
+	loc, err := s.Get("@office")
+	require.NoError(t, err)
+	assert.Equal(t, london, loc)
+	loc, err = s.Get("office")
+	require.NoError(t, err)
+	assert.Equal(t, london, loc)
+}
+
+func TestStore_Add(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), fileName))
+	require.NoError(t, err)
+	require.NoError(t, s.Add("office", london))
+
+	assert.ErrorIs(t, s.Add("@office", london), ErrExists)
+	assert.ErrorContains(t, s.Add("my office", london), `invalid alias "my office"`)
+	assert.ErrorContains(t, s.Add("@", london), "invalid alias")
+}
+
+func TestStore_CheckAvailable(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), fileName))
+	require.NoError(t, err)
+	require.NoError(t, s.Add("office", london))
+
+	assert.NoError(t, s.CheckAvailable("@home"))
+	assert.ErrorIs(t, s.CheckAvailable("@office"), ErrExists)
+	assert.ErrorContains(t, s.CheckAvailable("my office"), `invalid alias "my office"`)
+	assert.Len(t, s.List(), 1, "checking adds nothing")
+}
+
+func TestStore_GetMissing(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), fileName))
+	require.NoError(t, err)
+
+	_, err = s.Get("@home")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "favorite not found: @home")
+}
+
+func TestStore_RemoveAndRename(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), fileName))
+	require.NoError(t, err)
+	require.NoError(t, s.Add("office", london))
+	require.NoError(t, s.Add("home", models.Location{Name: "Paris"}))
+
+	assert.ErrorIs(t, s.Rename("office", "home"), ErrExists)
+	assert.ErrorIs(t, s.Rename("gym", "club"), ErrNotFound)
+	require.NoError(t, s.Rename("@office", "work"))
+	require.NoError(t, s.Remove("home"))
+	assert.ErrorIs(t, s.Remove("home"), ErrNotFound)
+
+	assert.Equal(t, []Favorite{{Alias: "work", Location: london}}, s.List())
+}
+
+func TestStore_ListSorted(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), fileName))
+	require.NoError(t, err)
+	for _, alias := range []string{"zurich", "berlin", "madrid"} {
+		require.NoError(t, s.Add(alias, models.Location{Name: alias}))
+	}
+
+	var aliases []string
+	for _, f := range s.List() {
+		aliases = append(aliases, f.Alias)
+	}
+	assert.Equal(t, []string{"berlin", "madrid", "zurich"}, aliases)
+}
+
+func TestLoad_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), fileName)
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "invalid favorites file")
+}
+
+func TestIsAlias(t *testing.T) {
+	assert.True(t, IsAlias("@office"))
+	assert.False(t, IsAlias("London"))
+}
@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"weather-reporter/src/internal/cache"
+	"weather-reporter/src/internal/models"
+)
+
+const (
+	// CacheTTL is how long a geocoding result is reused. Place names and
+	// coordinates rarely change, so results are kept for a long time.
+	CacheTTL = 30 * 24 * time.Hour
+
+	// CacheMaxBytes limits the size of the on-disk geocoding cache.
+	CacheMaxBytes = 1 << 20
+)
+
+// CachedClient implements models.GeocodingService by serving repeated
+// searches from a cache.Store and delegating misses to another service.
+type CachedClient struct {
+	next  models.GeocodingService
+	store *cache.Store
+}
+
+// NewCachedClient wraps next with a cache backed by store.
+func NewCachedClient(next models.GeocodingService, store *cache.Store) *CachedClient {
+	return &CachedClient{next: next, store: store}
+}
+
+// Search returns the cached result for name if there is one and otherwise
+// searches with the wrapped service. Only successful, non-empty results are
+// cached, and failing to write the cache never fails the search.
+func (c *CachedClient) Search(ctx context.Context, name string, opts models.SearchOptions) ([]models.Location, error) {
+	key := cacheKey(name, opts)
+
+	var locations []models.Location
+	if c.store.Get(key, &locations) {
+		return locations, nil
+	}
+
+	locations, err := c.next.Search(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) > 0 {
+		_ = c.store.Put(key, locations)
+	}
+	return locations, nil
+}
+
+// cacheKey identifies a search by its normalized query and options.
+func cacheKey(name string, opts models.SearchOptions) string {
+	return fmt.Sprintf("%s|%d|%s|%s", normalizeQuery(name), opts.Count,
+		strings.ToLower(opts.Language), strings.ToUpper(opts.CountryCode))
+}
+
+// normalizeQuery maps queries that only differ in case or spacing to the
+// same cache key.
+func normalizeQuery(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// CachedReverseGeocoder implements models.ReverseGeocodingService by
+// serving repeated lookups from a cache.Store and delegating misses to
+// another service.
+type CachedReverseGeocoder struct {
+	next     models.ReverseGeocodingService
+	store    *cache.Store
+	language string
+}
+
+// NewCachedReverseGeocoder wraps next, which names places in language,
+// with a cache backed by store.
+func NewCachedReverseGeocoder(next models.ReverseGeocodingService, store *cache.Store, language string) *CachedReverseGeocoder {
+	return &CachedReverseGeocoder{next: next, store: store, language: language}
+}
+
+// Reverse returns the cached place for the coordinates if there is one and
+// otherwise asks the wrapped service. Coordinates are rounded to three
+// decimals, about 100 m, which is well below the city-level resolution of
+// the lookup. Only places found are cached.
+func (c *CachedReverseGeocoder) Reverse(ctx context.Context, lat, lon float64) (models.Location, error) {
+	key := fmt.Sprintf("%.3f|%.3f|%s", lat, lon, strings.ToLower(c.language))
+
+	var loc models.Location
+	if c.store.Get(key, &loc) {
+		return loc, nil
+	}
+
+	loc, err := c.next.Reverse(ctx, lat, lon)
+	if err != nil {
+		return models.Location{}, err
+	}
+	_ = c.store.Put(key, loc)
+	return loc, nil
+}
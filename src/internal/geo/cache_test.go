@@ -0,0 +1,115 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"weather-reporter/src/internal/cache"
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingSearcher struct {
+	calls     int
+	locations []models.Location
+	err       error
+}
+
+func (s *countingSearcher) Search(ctx context.Context, name string, opts models.SearchOptions) ([]models.Location, error) {
+	s.calls++
+	return s.locations, s.err
+}
+
+func TestCachedClient_Search(t *testing.T) {
+	next := &countingSearcher{locations: []models.Location{{ID: 1, Name: "London", Country: "United Kingdom"}}}
+	c := NewCachedClient(next, cache.NewStore(t.TempDir(), cache.Options{TTL: CacheTTL}))
+
+	first, err := c.Search(context.Background(), "London", models.SearchOptions{})
+	require.NoError(t, err)
+	second, err := c.Search(context.Background(), "  london ", models.SearchOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, next.calls)
+	assert.Equal(t, first, second)
+}
+
+func TestCachedClient_DoesNotCacheEmptyOrFailedSearches(t *testing.T) {
+	next := &countingSearcher{}
+	c := NewCachedClient(next, cache.NewStore(t.TempDir(), cache.Options{}))
+
+	_, _ = c.Search(context.Background(), "Nowhere", models.SearchOptions{})
+	_, _ = c.Search(context.Background(), "Nowhere", models.SearchOptions{})
+	assert.Equal(t, 2, next.calls)
+
+	next.err = errors.New("unable to search locations, please try again")
+	_, err := c.Search(context.Background(), "Berlin", models.SearchOptions{})
+	assert.EqualError(t, err, "unable to search locations, please try again")
+}
+
+func TestCachedClient_KeysIncludeOptions(t *testing.T) {
+	next := &countingSearcher{locations: []models.Location{{ID: 1, Name: "Portland"}}}
+	c := NewCachedClient(next, cache.NewStore(t.TempDir(), cache.Options{TTL: CacheTTL}))
+
+	_, _ = c.Search(context.Background(), "Portland", models.SearchOptions{})
+	_, _ = c.Search(context.Background(), "Portland", models.SearchOptions{CountryCode: "US"})
+	_, _ = c.Search(context.Background(), "Portland", models.SearchOptions{CountryCode: "us"})
+	_, _ = c.Search(context.Background(), "Portland", models.SearchOptions{Count: 20})
+	_, _ = c.Search(context.Background(), "Portland", models.SearchOptions{Language: "de"})
+
+	assert.Equal(t, 4, next.calls)
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	assert.Equal(t, "new york", normalizeQuery("  New   York "))
+	assert.Equal(t, "são paulo", normalizeQuery("São Paulo"))
+}
+
+type countingReverse struct {
+	calls int
+	loc   models.Location
+	err   error
+}
+
+func (r *countingReverse) Reverse(ctx context.Context, lat, lon float64) (models.Location, error) {
+	r.calls++
+	return r.loc, r.err
+}
+
+func TestCachedReverseGeocoder_Reverse(t *testing.T) {
+	next := &countingReverse{loc: models.Location{Name: "Berlin", Country: "Germany"}}
+	c := NewCachedReverseGeocoder(next, cache.NewStore(t.TempDir(), cache.Options{TTL: CacheTTL}), "en")
+
+	first, err := c.Reverse(context.Background(), 52.52, 13.41)
+	require.NoError(t, err)
+	second, err := c.Reverse(context.Background(), 52.5201, 13.4102)
+	require.NoError(t, err)
+	assert.Equal(t, 1, next.calls, "nearby coordinates share an entry")
+	assert.Equal(t, first, second)
+
+	_, _ = c.Reverse(context.Background(), 52.53, 13.41)
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCachedReverseGeocoder_KeysIncludeLanguage(t *testing.T) {
+	next := &countingReverse{loc: models.Location{Name: "Berlin"}}
+	store := cache.NewStore(t.TempDir(), cache.Options{TTL: CacheTTL})
+
+	_, _ = NewCachedReverseGeocoder(next, store, "en").Reverse(context.Background(), 52.52, 13.41)
+	_, _ = NewCachedReverseGeocoder(next, store, "fr").Reverse(context.Background(), 52.52, 13.41)
+
+	assert.Equal(t, 2, next.calls)
+}
+
+func TestCachedReverseGeocoder_DoesNotCacheFailures(t *testing.T) {
+	next := &countingReverse{err: ErrNoNearbyPlace}
+	c := NewCachedReverseGeocoder(next, cache.NewStore(t.TempDir(), cache.Options{}), "en")
+
+	_, err := c.Reverse(context.Background(), 0, 0)
+	require.ErrorIs(t, err, ErrNoNearbyPlace)
+	_, err = c.Reverse(context.Background(), 0, 0)
+	require.ErrorIs(t, err, ErrNoNearbyPlace)
+	assert.Equal(t, 2, next.calls)
+}
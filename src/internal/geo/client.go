@@ -3,7 +3,6 @@ package geo
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"time"
 
@@ -12,7 +11,11 @@ import (
 	geocoding "github.com/gregbalnis/open-meteo-geocoding-sdk"
 )
 
-const defaultBaseURL = "https://geocoding-api.open-meteo.com/v1"
+const (
+	defaultBaseURL  = "https://geocoding-api.open-meteo.com/v1"
+	defaultCount    = 10
+	defaultLanguage = "en"
+)
 
 // Client is a geocoding client that implements models.GeocodingService
 // using the open-meteo-geocoding-sdk library. It retains baseURL/httpClient
@@ -22,16 +25,37 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    string
 	sdkClient  *geocoding.Client
+	count      int
+	language   string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithCount sets the maximum number of locations returned by a search.
+func WithCount(count int) Option {
+	return func(c *Client) {
+		c.count = count
+	}
+}
+
+// WithLanguage sets the language of the returned location names.
+func WithLanguage(language string) Option {
+	return func(c *Client) {
+		c.language = language
+	}
 }
 
 // NewClient creates a new geocoding client using the open-meteo-geocoding-sdk.
 // If httpClient is nil, a default client with a 10s timeout is used.
 //
-// The client is configured to:
+// By default the client is configured to:
 //   - Return up to 10 location results per search
 //   - Use English language for location names
 //   - Respect context cancellation and timeouts
-func NewClient(httpClient *http.Client) *Client {
+//
+// WithCount and WithLanguage change the first two.
+func NewClient(httpClient *http.Client, options ...Option) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 10 * time.Second}
 	}
@@ -40,29 +64,52 @@ func NewClient(httpClient *http.Client) *Client {
 	// SDK expects the full endpoint including /search
 	sdkBase := baseURL + "/search"
 
+	// The SDK has no country filter and drops some result fields, so its
+	// requests go through a transport that adds the country selected for
+	// each search and keeps the raw response.
+	sdkHTTPClient := *httpClient
+	sdkHTTPClient.Transport = &searchTransport{base: httpClient.Transport}
+
 	var opts []geocoding.Option
-	opts = append(opts, geocoding.WithHTTPClient(httpClient))
+	opts = append(opts, geocoding.WithHTTPClient(&sdkHTTPClient))
 	opts = append(opts, geocoding.WithBaseURL(sdkBase))
 
-	return &Client{
-		httpClient: httpClient,
+	c := &Client{
+		httpClient: &sdkHTTPClient,
 		baseURL:    baseURL,
 		sdkClient:  geocoding.NewClient(opts...),
+		count:      defaultCount,
+		language:   defaultLanguage,
 	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
 }
 
 // Search searches for locations by name using the SDK.
-// It returns up to 10 matching locations.
+// It returns up to opts.Count matching locations, falling back to the
+// client's configured count and language for zero options.
 //
-// All errors are converted to user-friendly messages without technical details:
-//   - Timeout errors: "Search took too long. Please try again."
-//   - All other errors: "Unable to search locations. Please try again."
-func (c *Client) Search(ctx context.Context, name string) ([]models.Location, error) {
+// Failed searches return a *SearchError. Its message is meant for users
+// and depends on the cause, which errors.Is matches against ErrNetwork,
+// ErrTimeout and the other kinds; Detail has the technical details.
+func (c *Client) Search(ctx context.Context, name string, options models.SearchOptions) ([]models.Location, error) {
 	// Configure search options
 	opts := &geocoding.SearchOptions{
-		Count:    10,
-		Language: "en",
+		Count:    c.count,
+		Language: c.language,
+	}
+	if options.Count > 0 {
+		opts.Count = options.Count
+	}
+	if options.Language != "" {
+		opts.Language = options.Language
 	}
+	if options.CountryCode != "" {
+		ctx = withCountry(ctx, options.CountryCode)
+	}
+	ctx, rec := withRecorder(ctx)
 
 	// Call SDK
 	sdkClient := c.sdkClient
@@ -76,58 +123,37 @@ func (c *Client) Search(ctx context.Context, name string) ([]models.Location, er
 
 	sdkLocations, err := sdkClient.Search(ctx, name, opts)
 	if err != nil {
-		return nil, convertSDKError(err)
+		return nil, newSearchError(err, rec.status)
 	}
 
 	// Map SDK locations to internal model
+	raw := rec.locations()
 	locations := make([]models.Location, len(sdkLocations))
 	for i, sdkLoc := range sdkLocations {
-		locations[i] = mapSDKLocation(sdkLoc)
+		locations[i] = mapSDKLocation(sdkLoc, raw[sdkLoc.ID])
 	}
 
 	return locations, nil
 }
 
-// mapSDKLocation converts an SDK location to our internal Location model.
-// Note: The SDK does not provide an admin1/region field, so Region will be empty.
-func mapSDKLocation(sdkLocation geocoding.Location) models.Location {
+// mapSDKLocation converts an SDK location to our internal Location model,
+// adding the fields the SDK does not decode from raw.
+func mapSDKLocation(sdkLocation geocoding.Location, raw rawLocation) models.Location {
 	return models.Location{
-		ID:        sdkLocation.ID,
-		Name:      sdkLocation.Name,
-		Latitude:  sdkLocation.Latitude,
-		Longitude: sdkLocation.Longitude,
-		Country:   sdkLocation.Country,
-		Region:    sdkLocation.Admin1,
-	}
-}
-
-// convertSDKError converts SDK errors to user-friendly error messages.
-// All technical details are hidden from users.
-func convertSDKError(err error) error {
-	if err == nil {
-		return nil
-	}
-
-	// Context-based errors
-	if errors.Is(err, context.DeadlineExceeded) {
-		return errors.New("search took too long, please try again")
+		ID:          sdkLocation.ID,
+		Name:        sdkLocation.Name,
+		Latitude:    sdkLocation.Latitude,
+		Longitude:   sdkLocation.Longitude,
+		Country:     sdkLocation.Country,
+		CountryCode: sdkLocation.CountryCode,
+		Region:      sdkLocation.Admin1,
+		Admin2:      sdkLocation.Admin2,
+		Admin3:      sdkLocation.Admin3,
+		Admin4:      sdkLocation.Admin4,
+		Timezone:    raw.Timezone,
+		Elevation:   sdkLocation.Elevation,
+		Population:  raw.Population,
+		Postcodes:   raw.Postcodes,
+		FeatureCode: raw.FeatureCode,
 	}
-	if errors.Is(err, context.Canceled) {
-		return errors.New("unable to search locations, please try again")
-	}
-
-	// SDK-defined errors
-	if errors.Is(err, geocoding.ErrConcurrencyLimitExceeded) {
-		return errors.New("unable to search locations, please try again")
-	}
-	if errors.Is(err, geocoding.ErrInvalidParameter) {
-		return errors.New("unable to search locations, please try again")
-	}
-	var apiErr *geocoding.APIError
-	if errors.As(err, &apiErr) {
-		return errors.New("unable to search locations, please try again")
-	}
-
-	// Default fallback
-	return errors.New("unable to search locations, please try again")
 }
@@ -70,7 +70,7 @@ func TestSearch(t *testing.T) {
 			client := NewClient(server.Client())
 			client.baseURL = server.URL // Override base URL for testing
 
-			results, err := client.Search(context.Background(), tt.query)
+			results, err := client.Search(context.Background(), tt.query, models.SearchOptions{})
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -100,7 +100,7 @@ func TestSearch_Timeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	defer cancel()
 
-	_, err := client.Search(ctx, "Timeout")
+	_, err := client.Search(ctx, "Timeout", models.SearchOptions{})
 	assert.Error(t, err)
 	// Error should be user-friendly message (no technical details)
 	assert.Contains(t, err.Error(), "search took too long")
@@ -112,3 +112,81 @@ func TestNewClient_Default(t *testing.T) {
 	assert.NotNil(t, client.httpClient)
 	assert.Equal(t, defaultBaseURL, client.baseURL)
 }
+
+func TestSearch_Options(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "3", r.URL.Query().Get("count"))
+		assert.Equal(t, "de", r.URL.Query().Get("language"))
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), WithCount(3), WithLanguage("de"))
+	client.baseURL = server.URL
+
+	_, err := client.Search(context.Background(), "München", models.SearchOptions{})
+	assert.NoError(t, err)
+}
+
+func TestSearch_PerCallOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "25", r.URL.Query().Get("count"))
+		assert.Equal(t, "fr", r.URL.Query().Get("language"))
+		assert.Equal(t, "US", r.URL.Query().Get("countryCode"))
+		_, _ = w.Write([]byte(`{"results": [{"id": 5746545, "name": "Portland", "latitude": 45.52345, "longitude": -122.67621, "country": "United States", "country_code": "US", "admin1": "Oregon"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), WithCount(3), WithLanguage("de"))
+	client.baseURL = server.URL
+
+	results, err := client.Search(context.Background(), "Portland", models.SearchOptions{Count: 25, Language: "fr", CountryCode: "us"})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Location{{
+		ID: 5746545, Name: "Portland", Latitude: 45.52345, Longitude: -122.67621,
+		Country: "United States", CountryCode: "US", Region: "Oregon",
+	}}, results)
+}
+
+func TestSearch_NoCountryByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, r.URL.Query().Has("countryCode"))
+		_, _ = w.Write([]byte(`{"results": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.baseURL = server.URL
+
+	_, err := client.Search(context.Background(), "Portland", models.SearchOptions{})
+	assert.NoError(t, err)
+}
+
+func TestSearch_AllFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results": [
+			{"id": 2950159, "name": "Berlin", "latitude": 52.52437, "longitude": 13.41053, "elevation": 74.0,
+			 "feature_code": "PPLC", "country_code": "DE", "admin1_id": 2950157, "timezone": "Europe/Berlin",
+			 "population": 3426354, "postcodes": ["10967", "13347"], "country_id": 2921044,
+			 "country": "Germany", "admin1": "State of Berlin", "admin2": "Berlin, Stadt", "admin3": "Mitte", "admin4": "Mitte-Nord"},
+			{"id": 4975802, "name": "Portland", "latitude": 43.66147, "longitude": -70.25533}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.baseURL = server.URL
+
+	results, err := client.Search(context.Background(), "Berlin", models.SearchOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Location{
+		{
+			ID: 2950159, Name: "Berlin", Latitude: 52.52437, Longitude: 13.41053,
+			Country: "Germany", CountryCode: "DE", Region: "State of Berlin",
+			Admin2: "Berlin, Stadt", Admin3: "Mitte", Admin4: "Mitte-Nord",
+			Timezone: "Europe/Berlin", Elevation: 74, Population: 3426354,
+			Postcodes: []string{"10967", "13347"}, FeatureCode: "PPLC",
+		},
+		{ID: 4975802, Name: "Portland", Latitude: 43.66147, Longitude: -70.25533},
+	}, results)
+}
@@ -0,0 +1,134 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"weather-reporter/src/internal/models"
+)
+
+// ErrNotCoordinates is returned by ParseCoordinates when the input does not
+// look like a coordinate pair and should be treated as a place name.
+var ErrNotCoordinates = errors.New("not a coordinate pair")
+
+// coordinateComponent matches a single latitude or longitude written as
+// decimal degrees ("52.52", "-13.41", "52.52°N") or as degrees, minutes and
+// optional seconds ("52°31'N", "13°24'36.5\"E").
+const coordinateComponent = `([+-]?\d+(?:\.\d+)?)\s*°?\s*` +
+	`(?:(\d+(?:\.\d+)?)\s*['′]\s*)?` +
+	`(?:(\d+(?:\.\d+)?)\s*(?:"|″|'')\s*)?` +
+	`([NSEWnsew])?`
+
+var coordinatePattern = regexp.MustCompile(`^\s*` + coordinateComponent + `\s*[,;\s]\s*` + coordinateComponent + `\s*$`)
+
+// ParseCoordinates parses a latitude/longitude pair such as "52.52,13.41",
+// "52.52 13.41", "52.52°N 13.41°E" or "52°31'N 13°24'E". Components are
+// expected latitude first unless hemisphere letters say otherwise.
+//
+// It returns ErrNotCoordinates if s is not written as a coordinate pair, and
+// a descriptive error if it is but the values are out of range.
+func ParseCoordinates(s string) (lat, lon float64, err error) {
+	m := coordinatePattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, ErrNotCoordinates
+	}
+
+	first, firstHemisphere, err := parseComponent(m[1], m[2], m[3], m[4])
+	if err != nil {
+		return 0, 0, err
+	}
+	second, secondHemisphere, err := parseComponent(m[5], m[6], m[7], m[8])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lat, lon = first, second
+	switch {
+	case isLongitudeHemisphere(firstHemisphere) && !isLongitudeHemisphere(secondHemisphere):
+		lat, lon = second, first
+	case isLongitudeHemisphere(firstHemisphere) || (secondHemisphere != "" && !isLongitudeHemisphere(secondHemisphere)):
+		return 0, 0, fmt.Errorf("invalid coordinates %q: expected one latitude (N/S) and one longitude (E/W)", s)
+	}
+
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return 0, 0, err
+	}
+	return lat, lon, nil
+}
+
+// ValidateCoordinates checks that lat and lon are within range.
+func ValidateCoordinates(lat, lon float64) error {
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("invalid latitude %g: must be between -90 and 90", lat)
+	}
+	if lon < -180 || lon > 180 {
+		return fmt.Errorf("invalid longitude %g: must be between -180 and 180", lon)
+	}
+	return nil
+}
+
+// parseComponent converts the captured parts of one coordinate component
+// into signed decimal degrees and its upper-case hemisphere letter.
+func parseComponent(degrees, minutes, seconds, hemisphere string) (float64, string, error) {
+	value, err := strconv.ParseFloat(degrees, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid degrees %q", degrees)
+	}
+	negative := strings.HasPrefix(degrees, "-")
+	value = math.Abs(value)
+
+	for _, part := range []struct {
+		text    string
+		divisor float64
+		name    string
+	}{{minutes, 60, "minutes"}, {seconds, 3600, "seconds"}} {
+		if part.text == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(part.text, 64)
+		if err != nil || v >= 60 {
+			return 0, "", fmt.Errorf("invalid %s %q: must be less than 60", part.name, part.text)
+		}
+		value += v / part.divisor
+	}
+
+	hemisphere = strings.ToUpper(hemisphere)
+	if hemisphere != "" && negative {
+		return 0, "", fmt.Errorf("invalid coordinate %q: use either a sign or a hemisphere, not both", degrees+hemisphere)
+	}
+	if negative || hemisphere == "S" || hemisphere == "W" {
+		value = -value
+	}
+	return value, hemisphere, nil
+}
+
+func isLongitudeHemisphere(h string) bool {
+	return h == "E" || h == "W"
+}
+
+// CoordinateLocation returns a Location for a point given only by its
+// coordinates, named after the coordinates themselves.
+func CoordinateLocation(lat, lon float64) models.Location {
+	return models.Location{
+		Name:      FormatCoordinates(lat, lon),
+		Latitude:  lat,
+		Longitude: lon,
+	}
+}
+
+// FormatCoordinates formats a coordinate pair with hemisphere letters,
+// e.g. "52.5200°N, 13.4100°E".
+func FormatCoordinates(lat, lon float64) string {
+	latHemisphere, lonHemisphere := "N", "E"
+	if lat < 0 {
+		latHemisphere = "S"
+	}
+	if lon < 0 {
+		lonHemisphere = "W"
+	}
+	return fmt.Sprintf("%.4f°%s, %.4f°%s", math.Abs(lat), latHemisphere, math.Abs(lon), lonHemisphere)
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		in       string
+		lat, lon float64
+	}{
+		{"52.52,13.41", 52.52, 13.41},
+		{"52.52, 13.41", 52.52, 13.41},
+		{"52.52 13.41", 52.52, 13.41},
+		{"-33.8688,151.2093", -33.8688, 151.2093},
+		{"40.7128 -74.0060", 40.7128, -74.006},
+		{"52.52°N 13.41°E", 52.52, 13.41},
+		{"33.87S, 151.21E", -33.87, 151.21},
+		{"52°31'N 13°24'E", 52 + 31.0/60, 13 + 24.0/60},
+		{`52°31'12"N 13°24'36"E`, 52 + 31.0/60 + 12.0/3600, 13 + 24.0/60 + 36.0/3600},
+		{"52° 31′ N, 13° 24′ E", 52 + 31.0/60, 13 + 24.0/60},
+		{"40°42'N 74°0'W", 40.7, -74},
+		{"13°24'E 52°31'N", 52 + 31.0/60, 13 + 24.0/60},
+		{"0,0", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			lat, lon, err := ParseCoordinates(tt.in)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.lat, lat, 1e-9)
+			assert.InDelta(t, tt.lon, lon, 1e-9)
+		})
+	}
+}
+
+func TestParseCoordinates_NotCoordinates(t *testing.T) {
+	for _, in := range []string{"Berlin", "New York", "52.52", "10 Downing Street", "", "St. Louis 63101"} {
+		_, _, err := ParseCoordinates(in)
+		assert.ErrorIs(t, err, ErrNotCoordinates, in)
+	}
+}
+
+func TestParseCoordinates_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"91,0":            "invalid latitude 91",
+		"0,181":           "invalid longitude 181",
+		"52°61'N 13°24'E": `invalid minutes "61"`,
+		"-52.5N 13.4E":    "use either a sign or a hemisphere",
+		"52.5N 13.4N":     "expected one latitude (N/S) and one longitude (E/W)",
+		"52.5E 13.4E":     "expected one latitude (N/S) and one longitude (E/W)",
+	}
+	for in, want := range tests {
+		_, _, err := ParseCoordinates(in)
+		assert.ErrorContains(t, err, want, in)
+		assert.NotErrorIs(t, err, ErrNotCoordinates, in)
+	}
+}
+
+func TestCoordinateLocation(t *testing.T) {
+	assert.Equal(t, models.Location{Name: "52.5200°N, 13.4100°E", Latitude: 52.52, Longitude: 13.41}, CoordinateLocation(52.52, 13.41))
+	assert.Equal(t, "33.8688°S, 74.0060°W", FormatCoordinates(-33.8688, -74.006))
+}
@@ -0,0 +1,19 @@
+package geo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// ParseCountryCode validates an ISO 3166-1 alpha-2 country code such as
+// "US" or "de" and returns it in upper case.
+func ParseCountryCode(s string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if !countryCodePattern.MatchString(code) {
+		return "", fmt.Errorf("invalid country code %q: must be a two-letter ISO 3166-1 code such as US or DE", s)
+	}
+	return code, nil
+}
@@ -0,0 +1,21 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCountryCode(t *testing.T) {
+	for _, in := range []string{"US", "us", " de "} {
+		code, err := ParseCountryCode(in)
+		assert.NoError(t, err, in)
+		assert.Len(t, code, 2)
+		assert.Equal(t, code, strings.ToUpper(strings.TrimSpace(in)))
+	}
+	for _, in := range []string{"", "U", "USA", "1A", "Ü1"} {
+		_, err := ParseCountryCode(in)
+		assert.Error(t, err, in)
+	}
+}
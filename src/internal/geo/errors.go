@@ -0,0 +1,128 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+
+	geocoding "github.com/gregbalnis/open-meteo-geocoding-sdk"
+)
+
+// Causes of a failed search. The errors returned by Client.Search are
+// *SearchError values that match one of them with errors.Is.
+var (
+	// ErrNetwork means the geocoding service could not be reached.
+	ErrNetwork = errors.New("network unreachable")
+	// ErrRateLimited means the service turned the search away for making
+	// too many requests.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrConcurrencyLimit means the search was not sent because the client
+	// already had as many searches in flight as the SDK allows. Unlike the
+	// other kinds, it says nothing about the service.
+	ErrConcurrencyLimit = errors.New("concurrency limit exceeded")
+	// ErrBadRequest means the service rejected the search parameters.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUpstream means the service failed with a 5xx status.
+	ErrUpstream = errors.New("geocoding service error")
+	// ErrTimeout means the search did not finish in time.
+	ErrTimeout = errors.New("timeout")
+	// ErrCanceled means the search was canceled by the caller.
+	ErrCanceled = errors.New("canceled")
+)
+
+// SearchError is a failed location search. Its message is meant for users
+// and leaves out technical details, which Detail provides.
+type SearchError struct {
+	// Kind is the cause of the failure, one of the Err variables above, or
+	// nil if it is not known.
+	Kind error
+	// StatusCode is the HTTP status of the response, or 0 if there was
+	// none.
+	StatusCode int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns a message for users, with advice where there is any.
+func (e *SearchError) Error() string {
+	switch e.Kind {
+	case ErrNetwork:
+		return "unable to reach the location search service, please check your internet connection"
+	case ErrRateLimited:
+		return "too many location searches, please wait a moment and try again"
+	case ErrConcurrencyLimit:
+		return "too many location searches at once, please try again"
+	case ErrBadRequest:
+		return "the location search was rejected, please check the location name and options"
+	case ErrUpstream:
+		return "the location search service is unavailable, please try again later"
+	case ErrTimeout:
+		return "search took too long, please try again"
+	case ErrCanceled:
+		return "search was canceled"
+	}
+	return "unable to search locations, please try again"
+}
+
+// Detail describes the cause of the failure for troubleshooting, as in
+// "bad request (HTTP 400): api error: Parameter count must be ...".
+func (e *SearchError) Detail() string {
+	kind := "unknown error"
+	if e.Kind != nil {
+		kind = e.Kind.Error()
+	}
+	if e.StatusCode != 0 {
+		kind += fmt.Sprintf(" (HTTP %d)", e.StatusCode)
+	}
+	return fmt.Sprintf("%s: %v", kind, e.Err)
+}
+
+// Unwrap returns the kind and the underlying error, so that errors.Is
+// matches either.
+func (e *SearchError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// newSearchError classifies an error of the SDK, given the HTTP status of
+// the response if there was one.
+func newSearchError(err error, statusCode int) *SearchError {
+	return &SearchError{Kind: classify(err, statusCode), StatusCode: statusCode, Err: err}
+}
+
+func classify(err error, statusCode int) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrCanceled
+	case errors.Is(err, geocoding.ErrConcurrencyLimitExceeded):
+		return ErrConcurrencyLimit
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case errors.Is(err, geocoding.ErrInvalidParameter):
+		return ErrBadRequest
+	case statusCode >= 500:
+		return ErrUpstream
+	case statusCode >= 400:
+		return ErrBadRequest
+	}
+
+	// The API also reports invalid parameters as an error in a 200
+	// response.
+	var apiErr *geocoding.APIError
+	if errors.As(err, &apiErr) {
+		return ErrBadRequest
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return ErrNetwork
+	}
+	return nil
+}
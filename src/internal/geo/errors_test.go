@@ -0,0 +1,142 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/models"
+
+	geocoding "github.com/gregbalnis/open-meteo-geocoding-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearch_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    error
+		message string
+		detail  string
+	}{
+		{
+			name:    "rate limited",
+			status:  http.StatusTooManyRequests,
+			body:    `{"error": true, "reason": "Too many requests"}`,
+			want:    ErrRateLimited,
+			message: "too many location searches, please wait a moment and try again",
+			detail:  "rate limited (HTTP 429): api error: Too many requests",
+		},
+		{
+			name:    "upstream",
+			status:  http.StatusBadGateway,
+			want:    ErrUpstream,
+			message: "the location search service is unavailable, please try again later",
+			detail:  "geocoding service error (HTTP 502): unexpected status code: 502",
+		},
+		{
+			name:    "bad request",
+			status:  http.StatusBadRequest,
+			body:    `{"error": true, "reason": "Parameter count must be between 1 and 100."}`,
+			want:    ErrBadRequest,
+			message: "the location search was rejected, please check the location name and options",
+			detail:  "bad request (HTTP 400): api error: Parameter count must be between 1 and 100.",
+		},
+		{
+			name:    "error in a 200 response",
+			status:  http.StatusOK,
+			body:    `{"error": true, "reason": "Invalid language"}`,
+			want:    ErrBadRequest,
+			message: "the location search was rejected, please check the location name and options",
+			detail:  "bad request (HTTP 200): api error: Invalid language",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client := NewClient(server.Client())
+			client.baseURL = server.URL
+
+			_, err := client.Search(context.Background(), "London", models.SearchOptions{})
+
+			var searchErr *SearchError
+			require.ErrorAs(t, err, &searchErr)
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.status, searchErr.StatusCode)
+			assert.EqualError(t, err, tt.message)
+			assert.Equal(t, tt.detail, searchErr.Detail())
+		})
+	}
+}
+
+func TestSearch_NetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	client := NewClient(server.Client())
+	client.baseURL = server.URL
+	server.Close()
+
+	_, err := client.Search(context.Background(), "London", models.SearchOptions{})
+
+	assert.ErrorIs(t, err, ErrNetwork)
+	assert.EqualError(t, err, "unable to reach the location search service, please check your internet connection")
+}
+
+func TestSearch_ContextErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+	client := NewClient(server.Client())
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err := client.Search(ctx, "London", models.SearchOptions{})
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Search(ctx, "London", models.SearchOptions{})
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.EqualError(t, err, "search was canceled")
+}
+
+func TestSearch_InvalidParameter(t *testing.T) {
+	client := NewClient(http.DefaultClient)
+
+	_, err := client.Search(context.Background(), "London", models.SearchOptions{Count: 101})
+
+	assert.ErrorIs(t, err, ErrBadRequest)
+	var searchErr *SearchError
+	require.ErrorAs(t, err, &searchErr)
+	assert.Zero(t, searchErr.StatusCode, "rejected before any request")
+}
+
+func TestSearch_ConcurrencyLimit(t *testing.T) {
+	err := newSearchError(fmt.Errorf("search failed: %w", geocoding.ErrConcurrencyLimitExceeded), 0)
+
+	assert.ErrorIs(t, err, ErrConcurrencyLimit)
+	assert.NotErrorIs(t, err, ErrRateLimited, "the limit is our own, not the service's")
+	assert.EqualError(t, err, "too many location searches at once, please try again")
+	assert.Equal(t, "concurrency limit exceeded: search failed: "+geocoding.ErrConcurrencyLimitExceeded.Error(), err.Detail())
+}
+
+func TestSearchError_Unknown(t *testing.T) {
+	err := &SearchError{Err: errors.New("failed to decode response")}
+
+	assert.EqualError(t, err, "unable to search locations, please try again")
+	assert.Equal(t, "unknown error: failed to decode response", err.Detail())
+	assert.False(t, errors.Is(err, ErrNetwork))
+}
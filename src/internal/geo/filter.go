@@ -0,0 +1,186 @@
+package geo
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"weather-reporter/src/internal/models"
+)
+
+// FilterSearchCount is the number of results requested from the geocoding
+// API when they are filtered by region locally, so that the matching
+// places are not cut off by the default result limit.
+const FilterSearchCount = 100
+
+//go:embed data/regions.csv
+var regionsCSV string
+
+// Filter narrows search results down to a country and first-order
+// administrative area. Empty fields match every location.
+type Filter struct {
+	// Country is an ISO 3166-1 alpha-2 code such as "US" or a country
+	// name such as "United States".
+	Country string
+
+	// Region is an admin1 name such as "Oregon" or a common abbreviation
+	// such as "OR".
+	Region string
+
+	// Qualifier is a country or region, whichever matches. It holds the
+	// part after the name in queries like "Portland, ME", where ME may be
+	// Maine or Montenegro.
+	Qualifier string
+}
+
+// ParseQuery splits a query such as "Portland, OR, US" into the place name
+// and a filter. The last of three or more comma-separated parts is the
+// country and the parts in between are the region; a single part after
+// the name is a qualifier.
+func ParseQuery(query string) (string, Filter) {
+	var parts []string
+	for _, p := range strings.Split(query, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	switch len(parts) {
+	case 0:
+		return strings.TrimSpace(query), Filter{}
+	case 1:
+		return parts[0], Filter{}
+	case 2:
+		return parts[0], Filter{Qualifier: parts[1]}
+	default:
+		last := len(parts) - 1
+		return parts[0], Filter{
+			Country: parts[last],
+			Region:  strings.Join(parts[1:last], ", "),
+		}
+	}
+}
+
+// IsZero reports whether f matches every location.
+func (f Filter) IsZero() bool {
+	return f == Filter{}
+}
+
+// Merge returns f with the non-empty fields of o replacing its own. As the
+// qualifier of f may stand for a country or a region, it is dropped when o
+// sets either.
+func (f Filter) Merge(o Filter) Filter {
+	if o.Country != "" || o.Region != "" {
+		f.Qualifier = ""
+	}
+	if o.Country != "" {
+		f.Country = o.Country
+	}
+	if o.Region != "" {
+		f.Region = o.Region
+	}
+	if o.Qualifier != "" {
+		f.Qualifier = o.Qualifier
+	}
+	return f
+}
+
+// SearchOptions returns opts adjusted for searching with f: a country
+// code is passed on to the API, and more results are requested when they
+// are filtered by region or qualifier locally.
+func (f Filter) SearchOptions(opts models.SearchOptions) models.SearchOptions {
+	if code, err := ParseCountryCode(f.Country); err == nil {
+		opts.CountryCode = code
+	}
+	if (f.Region != "" || f.Qualifier != "") && opts.Count < FilterSearchCount {
+		opts.Count = FilterSearchCount
+	}
+	return opts
+}
+
+// Apply returns the locations that match f, keeping their order.
+func (f Filter) Apply(locations []models.Location) []models.Location {
+	if f.IsZero() {
+		return locations
+	}
+	matches := make([]models.Location, 0, len(locations))
+	for _, loc := range locations {
+		if f.Match(loc) {
+			matches = append(matches, loc)
+		}
+	}
+	return matches
+}
+
+// Match reports whether loc matches every field of f. Names are compared
+// without regard to case.
+func (f Filter) Match(loc models.Location) bool {
+	if f.Country != "" && !matchCountry(loc, f.Country) {
+		return false
+	}
+	if f.Region != "" && !matchRegion(loc, f.Region) {
+		return false
+	}
+	if f.Qualifier != "" && !matchCountry(loc, f.Qualifier) && !matchRegion(loc, f.Qualifier) {
+		return false
+	}
+	return true
+}
+
+func matchCountry(loc models.Location, country string) bool {
+	return strings.EqualFold(loc.CountryCode, country) || strings.EqualFold(loc.Country, country)
+}
+
+func matchRegion(loc models.Location, region string) bool {
+	if loc.Region == "" {
+		return false
+	}
+	if strings.EqualFold(loc.Region, region) {
+		return true
+	}
+	name, ok := regionAbbreviations()[regionKey(loc.CountryCode, region)]
+	return ok && strings.EqualFold(loc.Region, name)
+}
+
+var (
+	abbreviationsOnce sync.Once
+	abbreviations     map[string]string
+)
+
+// regionAbbreviations returns the embedded admin1 abbreviations, keyed by
+// regionKey, parsing them on first use.
+func regionAbbreviations() map[string]string {
+	abbreviationsOnce.Do(func() {
+		parsed, err := parseAbbreviations(regionsCSV)
+		if err != nil {
+			panic(fmt.Sprintf("geo: invalid embedded regions dataset: %v", err))
+		}
+		abbreviations = parsed
+	})
+	return abbreviations
+}
+
+// parseAbbreviations reads admin1 abbreviations from CSV with the header
+// country_code,abbreviation,admin1.
+func parseAbbreviations(data string) (map[string]string, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("missing header")
+	}
+
+	names := make(map[string]string, len(records)-1)
+	for _, rec := range records[1:] {
+		names[regionKey(rec[0], rec[1])] = rec[2]
+	}
+	return names, nil
+}
+
+func regionKey(countryCode, abbreviation string) string {
+	return strings.ToUpper(countryCode) + "/" + strings.ToUpper(abbreviation)
+}
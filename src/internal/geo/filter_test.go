@@ -0,0 +1,97 @@
+package geo
+
+import (
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var portlands = []models.Location{
+	{ID: 5746545, Name: "Portland", Country: "United States", CountryCode: "US", Region: "Oregon"},
+	{ID: 4975802, Name: "Portland", Country: "United States", CountryCode: "US", Region: "Maine"},
+	{ID: 2152668, Name: "Portland", Country: "Australia", CountryCode: "AU", Region: "Victoria"},
+	{ID: 3489741, Name: "Portland", Country: "Jamaica", CountryCode: "JM", Region: "Portland"},
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query  string
+		name   string
+		filter Filter
+	}{
+		{query: "Portland", name: "Portland"},
+		{query: "  Portland ", name: "Portland"},
+		{query: "Portland, OR", name: "Portland", filter: Filter{Qualifier: "OR"}},
+		{query: "Portland,OR,US", name: "Portland", filter: Filter{Country: "US", Region: "OR"}},
+		{query: "Portland, Oregon, United States", name: "Portland", filter: Filter{Country: "United States", Region: "Oregon"}},
+		{query: "Springfield, Cook, Illinois, US", name: "Springfield", filter: Filter{Country: "US", Region: "Cook, Illinois"}},
+		{query: "Portland, , US", name: "Portland", filter: Filter{Qualifier: "US"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			name, filter := ParseQuery(tt.query)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.filter, filter)
+		})
+	}
+}
+
+func TestFilter_Apply(t *testing.T) {
+	ids := func(locations []models.Location) []int {
+		var ids []int
+		for _, loc := range locations {
+			ids = append(ids, loc.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []int
+	}{
+		{name: "Zero", filter: Filter{}, want: []int{5746545, 4975802, 2152668, 3489741}},
+		{name: "Country Code", filter: Filter{Country: "us"}, want: []int{5746545, 4975802}},
+		{name: "Country Name", filter: Filter{Country: "Australia"}, want: []int{2152668}},
+		{name: "Region Name", filter: Filter{Region: "oregon"}, want: []int{5746545}},
+		{name: "Region Abbreviation", filter: Filter{Country: "US", Region: "OR"}, want: []int{5746545}},
+		{name: "Abbreviation Of Another Country", filter: Filter{Region: "VIC"}, want: []int{2152668}},
+		{name: "Qualifier Region", filter: Filter{Qualifier: "ME"}, want: []int{4975802}},
+		{name: "Qualifier Country", filter: Filter{Qualifier: "Jamaica"}, want: []int{3489741}},
+		{name: "No Match", filter: Filter{Country: "US", Region: "Texas"}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(tt.filter.Apply(portlands)))
+		})
+	}
+}
+
+func TestFilter_SearchOptions(t *testing.T) {
+	assert.Equal(t, models.SearchOptions{Count: 5}, Filter{}.SearchOptions(models.SearchOptions{Count: 5}))
+	assert.Equal(t, models.SearchOptions{Count: 5, CountryCode: "US"}, Filter{Country: "us"}.SearchOptions(models.SearchOptions{Count: 5}))
+	assert.Equal(t, models.SearchOptions{Count: 5}, Filter{Country: "United States"}.SearchOptions(models.SearchOptions{Count: 5}))
+	assert.Equal(t, models.SearchOptions{Count: FilterSearchCount, CountryCode: "US"}, Filter{Country: "US", Region: "OR"}.SearchOptions(models.SearchOptions{}))
+	assert.Equal(t, models.SearchOptions{Count: FilterSearchCount}, Filter{Qualifier: "ME"}.SearchOptions(models.SearchOptions{Count: 10}))
+}
+
+func TestFilter_Merge(t *testing.T) {
+	parsed := Filter{Country: "US", Region: "OR"}
+	assert.Equal(t, Filter{Country: "US", Region: "Maine"}, parsed.Merge(Filter{Region: "Maine"}))
+	assert.Equal(t, parsed, parsed.Merge(Filter{}))
+	assert.Equal(t, Filter{Region: "Oregon"}, Filter{Qualifier: "ME"}.Merge(Filter{Region: "Oregon"}))
+	assert.Equal(t, Filter{Qualifier: "ME"}, Filter{Qualifier: "OR"}.Merge(Filter{Qualifier: "ME"}))
+}
+
+func TestParseAbbreviations(t *testing.T) {
+	names, err := parseAbbreviations("country_code,abbreviation,admin1\nUS,OR,Oregon\n")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"US/OR": "Oregon"}, names)
+
+	_, err = parseAbbreviations("")
+	assert.Error(t, err)
+}
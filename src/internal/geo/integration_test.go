@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"weather-reporter/src/internal/models"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,7 +31,7 @@ func TestIntegration_GeocodingAPIContract(t *testing.T) {
 
 	t.Run("London_Search_Returns_Valid_Data", func(t *testing.T) {
 		ctx := context.Background()
-		locations, err := client.Search(ctx, "London")
+		locations, err := client.Search(ctx, "London", models.SearchOptions{})
 
 		assert.NoError(t, err, "London search should succeed")
 		assert.NotEmpty(t, locations, "Should return at least one result")
@@ -53,7 +55,7 @@ func TestIntegration_GeocodingAPIContract(t *testing.T) {
 
 	t.Run("Ambiguous_Query_Returns_Multiple_Results", func(t *testing.T) {
 		ctx := context.Background()
-		locations, err := client.Search(ctx, "Springfield")
+		locations, err := client.Search(ctx, "Springfield", models.SearchOptions{})
 
 		assert.NoError(t, err, "Springfield search should succeed")
 		assert.NotEmpty(t, locations, "Should return multiple results for ambiguous query")
@@ -71,7 +73,7 @@ func TestIntegration_GeocodingAPIContract(t *testing.T) {
 
 	t.Run("Result_Limit_Respected", func(t *testing.T) {
 		ctx := context.Background()
-		locations, err := client.Search(ctx, "London")
+		locations, err := client.Search(ctx, "London", models.SearchOptions{})
 
 		assert.NoError(t, err, "Search should succeed")
 		assert.LessOrEqual(t, len(locations), 10, "Should return at most 10 results (SDK default limit)")
@@ -79,7 +81,7 @@ func TestIntegration_GeocodingAPIContract(t *testing.T) {
 
 	t.Run("No_Results_Returns_Empty_Slice", func(t *testing.T) {
 		ctx := context.Background()
-		locations, err := client.Search(ctx, "ZZZNonexistentCityXYZ123")
+		locations, err := client.Search(ctx, "ZZZNonexistentCityXYZ123", models.SearchOptions{})
 
 		assert.NoError(t, err, "No results should not be an error")
 		assert.Empty(t, locations, "Should return empty slice for no results")
@@ -87,7 +89,7 @@ func TestIntegration_GeocodingAPIContract(t *testing.T) {
 
 	t.Run("Complete_Data_Structure", func(t *testing.T) {
 		ctx := context.Background()
-		locations, err := client.Search(ctx, "Tokyo")
+		locations, err := client.Search(ctx, "Tokyo", models.SearchOptions{})
 
 		assert.NoError(t, err, "Tokyo search should succeed")
 		assert.NotEmpty(t, locations, "Should return at least one result")
@@ -127,7 +129,7 @@ func TestIntegration_GeocodingAPIContract(t *testing.T) {
 // If this test fails:
 // 1. Check if API response structure changed
 // 2. Update mapSDKLocation() function in client.go
-// 3. Update convertSDKError() if error handling changed
+// 3. Update classify() in errors.go if error handling changed
 // 4. Update this documentation with new contract
 func TestIntegration_APIContractChange(t *testing.T) {
 	if testing.Short() {
@@ -147,7 +149,7 @@ func TestIntegration_APIContractChange(t *testing.T) {
 		// Wait for context to definitely timeout
 		time.Sleep(10 * time.Millisecond)
 
-		_, err := client.Search(ctx, "London")
+		_, err := client.Search(ctx, "London", models.SearchOptions{})
 
 		assert.Error(t, err, "Should return error when context times out")
 		assert.Contains(t, err.Error(), "search took too long", "Should return user-friendly timeout message")
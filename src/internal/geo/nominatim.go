@@ -0,0 +1,191 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"weather-reporter/src/internal/models"
+)
+
+const (
+	defaultNominatimURL = "https://nominatim.openstreetmap.org"
+	nominatimUserAgent  = "weather-reporter (https://github.com/gregbalnis/weather-reporter)"
+
+	// nominatimInterval is the least time between two requests of a
+	// client. Nominatim's usage policy allows one request per second.
+	nominatimInterval = time.Second
+)
+
+// NominatimClient is a reverse geocoding backend backed by the
+// OpenStreetMap Nominatim API. It implements models.ReverseGeocodingService
+// and is meant to be plugged into a ReverseGeocoder. Concurrent lookups
+// wait for their turn so that the client stays within Nominatim's rate
+// limit.
+type NominatimClient struct {
+	httpClient *http.Client
+	baseURL    string
+	language   string
+	throttle   *throttle
+}
+
+// NominatimOption configures a NominatimClient.
+type NominatimOption func(*NominatimClient)
+
+// WithNominatimLanguage sets the language of the returned place names.
+func WithNominatimLanguage(language string) NominatimOption {
+	return func(c *NominatimClient) {
+		c.language = language
+	}
+}
+
+// NewNominatimClient creates a Nominatim reverse geocoding client.
+// If httpClient is nil, a default client with a 10s timeout is used.
+// Place names are in English unless WithNominatimLanguage says otherwise.
+func NewNominatimClient(httpClient *http.Client, options ...NominatimOption) *NominatimClient {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	c := &NominatimClient{
+		httpClient: httpClient,
+		baseURL:    defaultNominatimURL,
+		language:   defaultLanguage,
+		throttle:   &throttle{interval: nominatimInterval},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// nominatimResponse mirrors the subset of the jsonv2 reverse response used.
+type nominatimResponse struct {
+	PlaceID int64  `json:"place_id"`
+	Lat     string `json:"lat"`
+	Lon     string `json:"lon"`
+	Name    string `json:"name"`
+	Error   string `json:"error"`
+	Address struct {
+		City         string `json:"city"`
+		Town         string `json:"town"`
+		Village      string `json:"village"`
+		Hamlet       string `json:"hamlet"`
+		Municipality string `json:"municipality"`
+		State        string `json:"state"`
+		Country      string `json:"country"`
+	} `json:"address"`
+}
+
+// Language returns the language of the returned place names.
+func (c *NominatimClient) Language() string {
+	return c.language
+}
+
+// Reverse returns the settlement nearest to the given coordinates. It
+// waits until a second has passed since the client's previous request, or
+// returns the context's error if ctx ends first.
+func (c *NominatimClient) Reverse(ctx context.Context, lat, lon float64) (models.Location, error) {
+	if err := c.throttle.wait(ctx); err != nil {
+		return models.Location{}, err
+	}
+
+	u, err := url.Parse(c.baseURL + "/reverse")
+	if err != nil {
+		return models.Location{}, fmt.Errorf("invalid base URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("format", "jsonv2")
+	q.Set("lat", strconv.FormatFloat(lat, 'f', -1, 64))
+	q.Set("lon", strconv.FormatFloat(lon, 'f', -1, 64))
+	q.Set("zoom", "10") // city level
+	q.Set("accept-language", c.language)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return models.Location{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", nominatimUserAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return models.Location{}, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return models.Location{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var r nominatimResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return models.Location{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if r.Error != "" {
+		return models.Location{}, ErrNoNearbyPlace
+	}
+
+	name := firstNonEmpty(r.Address.City, r.Address.Town, r.Address.Village, r.Address.Hamlet, r.Address.Municipality, r.Name)
+	if name == "" {
+		return models.Location{}, ErrNoNearbyPlace
+	}
+	placeLat, _ := strconv.ParseFloat(r.Lat, 64)
+	placeLon, _ := strconv.ParseFloat(r.Lon, 64)
+
+	return models.Location{
+		Name:      name,
+		Latitude:  placeLat,
+		Longitude: placeLon,
+		Country:   r.Address.Country,
+		Region:    r.Address.State,
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// throttle spaces out the calls to wait by at least interval.
+type throttle struct {
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time // earliest start of the next call
+}
+
+// wait blocks until the caller's turn. A caller whose ctx ends while
+// waiting gives up its turn to nobody; the following callers still wait
+// for it, which errs on the side of fewer requests.
+func (t *throttle) wait(ctx context.Context) error {
+	t.mu.Lock()
+	now := time.Now()
+	start := t.next
+	if start.Before(now) {
+		start = now
+	}
+	t.next = start.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,129 @@
+package geo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNominatimClient(t *testing.T, handler http.HandlerFunc) *NominatimClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	c := NewNominatimClient(server.Client())
+	c.baseURL = server.URL
+	return c
+}
+
+func TestNominatimClient_Reverse(t *testing.T) {
+	c := newTestNominatimClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reverse", r.URL.Path)
+		assert.Equal(t, "jsonv2", r.URL.Query().Get("format"))
+		assert.Equal(t, "52.52", r.URL.Query().Get("lat"))
+		assert.Equal(t, "13.41", r.URL.Query().Get("lon"))
+		assert.Equal(t, "en", r.URL.Query().Get("accept-language"))
+		assert.Equal(t, nominatimUserAgent, r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"place_id": 1, "lat": "52.5170365", "lon": "13.3888599", "name": "Berlin",
+			"address": {"city": "Berlin", "state": "Berlin", "country": "Germany"}}`))
+	})
+
+	loc, err := c.Reverse(context.Background(), 52.52, 13.41)
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", loc.Name)
+	assert.Equal(t, "Germany", loc.Country)
+	assert.Equal(t, "Berlin", loc.Region)
+	assert.InDelta(t, 52.5170365, loc.Latitude, 1e-9)
+}
+
+func TestNominatimClient_ReverseVillage(t *testing.T) {
+	c := newTestNominatimClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"lat": "47.1", "lon": "8.2", "name": "",
+			"address": {"village": "Hitzkirch", "state": "Lucerne", "country": "Switzerland"}}`))
+	})
+
+	loc, err := c.Reverse(context.Background(), 47.1, 8.2)
+	require.NoError(t, err)
+	assert.Equal(t, "Hitzkirch", loc.Name)
+}
+
+func TestNominatimClient_Language(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "ja", r.URL.Query().Get("accept-language"))
+		_, _ = w.Write([]byte(`{"lat": "35.68", "lon": "139.69", "name": "東京都",
+			"address": {"city": "東京都", "country": "日本"}}`))
+	}))
+	defer server.Close()
+	c := NewNominatimClient(server.Client(), WithNominatimLanguage("ja"))
+	c.baseURL = server.URL
+
+	loc, err := c.Reverse(context.Background(), 35.68, 139.69)
+	require.NoError(t, err)
+	assert.Equal(t, "東京都", loc.Name)
+	assert.Equal(t, "日本", loc.Country)
+}
+
+func TestNominatimClient_ReverseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{name: "Unable to geocode", status: http.StatusOK, body: `{"error": "Unable to geocode"}`, wantErr: ErrNoNearbyPlace},
+		{name: "No settlement", status: http.StatusOK, body: `{"lat": "0", "lon": "0", "address": {}}`, wantErr: ErrNoNearbyPlace},
+		{name: "Server error", status: http.StatusInternalServerError, body: ``},
+		{name: "Invalid JSON", status: http.StatusOK, body: `{`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestNominatimClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			_, err := c.Reverse(context.Background(), 0, 0)
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNominatimClient_Throttle(t *testing.T) {
+	var requests int
+	c := newTestNominatimClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"lat": "52.52", "lon": "13.41", "address": {"city": "Berlin"}}`))
+	})
+	c.throttle.interval = 50 * time.Millisecond
+
+	start := time.Now()
+	for range 3 {
+		_, err := c.Reverse(context.Background(), 52.52, 13.41)
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "requests are spaced by the interval")
+	assert.Equal(t, 3, requests)
+}
+
+func TestNominatimClient_ThrottleCanceled(t *testing.T) {
+	c := newTestNominatimClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"lat": "52.52", "lon": "13.41", "address": {"city": "Berlin"}}`))
+	})
+	c.throttle.interval = time.Hour
+
+	_, err := c.Reverse(context.Background(), 52.52, 13.41)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.Reverse(ctx, 52.52, 13.41)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,114 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"weather-reporter/src/internal/models"
+)
+
+// Pick strategies accepted by ParsePick.
+const (
+	PickFirst        = "first"
+	PickMostPopulous = "most-populous"
+	PickClosestTo    = "closest-to"
+)
+
+// Pick is a strategy that deterministically chooses one of several search
+// results, so that ambiguous names can be resolved without a prompt.
+type Pick struct {
+	strategy string
+	position int // 1-based, for a numeric pick
+	lat, lon float64
+}
+
+// ParsePick parses a strategy written as "first", a 1-based position such
+// as "2", "most-populous" or "closest-to=LAT,LON".
+func ParsePick(s string) (Pick, error) {
+	switch {
+	case s == PickFirst:
+		return Pick{strategy: PickFirst}, nil
+	case s == PickMostPopulous:
+		return Pick{strategy: PickMostPopulous}, nil
+	case strings.HasPrefix(s, PickClosestTo+"="):
+		lat, lon, err := ParseCoordinates(strings.TrimPrefix(s, PickClosestTo+"="))
+		if errors.Is(err, ErrNotCoordinates) {
+			return Pick{}, fmt.Errorf("invalid pick %q: %s needs coordinates such as %s=45.52,-122.68", s, PickClosestTo, PickClosestTo)
+		}
+		if err != nil {
+			return Pick{}, err
+		}
+		return Pick{strategy: PickClosestTo, lat: lat, lon: lon}, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return Pick{}, fmt.Errorf("invalid pick %q: must be %s, a position such as 2, %s or %s=LAT,LON", s, PickFirst, PickMostPopulous, PickClosestTo)
+	}
+	return Pick{position: n}, nil
+}
+
+// IsZero reports whether p is the zero Pick, which picks nothing.
+func (p Pick) IsZero() bool {
+	return p == Pick{}
+}
+
+// String returns p in the form accepted by ParsePick.
+func (p Pick) String() string {
+	switch p.strategy {
+	case PickClosestTo:
+		return fmt.Sprintf("%s=%g,%g", PickClosestTo, p.lat, p.lon)
+	case "":
+		if p.position == 0 {
+			return ""
+		}
+		return strconv.Itoa(p.position)
+	default:
+		return p.strategy
+	}
+}
+
+// Choose returns the index of the location that p picks from locations.
+// Ties are resolved in favor of the earlier location, which the geocoding
+// API ranks as more relevant.
+func (p Pick) Choose(locations []models.Location) (int, error) {
+	if len(locations) == 0 {
+		return 0, errors.New("no locations to pick from")
+	}
+
+	switch p.strategy {
+	case PickFirst:
+		return 0, nil
+	case PickMostPopulous:
+		return mostPopulous(locations), nil
+	case PickClosestTo:
+		return closestTo(locations, p.lat, p.lon), nil
+	}
+
+	if p.position < 1 || p.position > len(locations) {
+		return 0, fmt.Errorf("cannot pick location %d: only %d locations found", p.position, len(locations))
+	}
+	return p.position - 1, nil
+}
+
+func mostPopulous(locations []models.Location) int {
+	best := 0
+	for i, loc := range locations {
+		if loc.Population > locations[best].Population {
+			best = i
+		}
+	}
+	return best
+}
+
+func closestTo(locations []models.Location, lat, lon float64) int {
+	best, bestDistance := 0, distanceKm(lat, lon, locations[0].Latitude, locations[0].Longitude)
+	for i, loc := range locations[1:] {
+		if d := distanceKm(lat, lon, loc.Latitude, loc.Longitude); d < bestDistance {
+			best, bestDistance = i+1, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,86 @@
+package geo
+
+import (
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var springfields = []models.Location{
+	{ID: 4409896, Name: "Springfield", Latitude: 37.21533, Longitude: -93.29824, Region: "Missouri", Population: 166810},
+	{ID: 4250542, Name: "Springfield", Latitude: 39.80172, Longitude: -89.64371, Region: "Illinois", Population: 116565},
+	{ID: 4951788, Name: "Springfield", Latitude: 42.10148, Longitude: -72.58981, Region: "Massachusetts", Population: 155929},
+	{ID: 5761708, Name: "Springfield", Latitude: 44.04624, Longitude: -123.02203, Region: "Oregon"},
+}
+
+func TestParsePick(t *testing.T) {
+	for _, s := range []string{"first", "3", "most-populous", "closest-to=44.05,-123.09"} {
+		p, err := ParsePick(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, p.String())
+		assert.False(t, p.IsZero())
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: `invalid pick ""`},
+		{in: "last", want: `invalid pick "last"`},
+		{in: "0", want: `invalid pick "0"`},
+		{in: "-1", want: `invalid pick "-1"`},
+		{in: "closest-to=", want: `closest-to needs coordinates`},
+		{in: "closest-to=Berlin", want: `closest-to needs coordinates`},
+		{in: "closest-to=95,10", want: "invalid latitude 95"},
+	}
+	for _, tt := range tests {
+		_, err := ParsePick(tt.in)
+		if assert.Error(t, err, tt.in) {
+			assert.Contains(t, err.Error(), tt.want)
+		}
+	}
+}
+
+func TestPick_Choose(t *testing.T) {
+	tests := []struct {
+		pick string
+		want int
+	}{
+		{pick: "first", want: 0},
+		{pick: "3", want: 2},
+		{pick: "most-populous", want: 0},
+		{pick: "closest-to=44.05,-123.09", want: 3},
+		{pick: "closest-to=42.3601,-71.0589", want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pick, func(t *testing.T) {
+			p, err := ParsePick(tt.pick)
+			require.NoError(t, err)
+
+			got, err := p.Choose(springfields)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPick_ChooseErrors(t *testing.T) {
+	p, err := ParsePick("5")
+	require.NoError(t, err)
+	_, err = p.Choose(springfields)
+	assert.EqualError(t, err, "cannot pick location 5: only 4 locations found")
+
+	_, err = Pick{strategy: PickFirst}.Choose(nil)
+	assert.EqualError(t, err, "no locations to pick from")
+}
+
+func TestPick_MostPopulousUnknownPopulation(t *testing.T) {
+	locations := []models.Location{{ID: 1}, {ID: 2}}
+	got, err := Pick{strategy: PickMostPopulous}.Choose(locations)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got)
+}
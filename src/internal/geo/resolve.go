@@ -0,0 +1,120 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"weather-reporter/src/internal/models"
+)
+
+// ErrLocationNotFound is returned by Resolver.Resolve when no location
+// matches the query.
+var ErrLocationNotFound = errors.New("location not found")
+
+// AmbiguousError is returned by Resolver.Resolve when a query matches
+// several locations and no Pick chooses one of them.
+type AmbiguousError struct {
+	// Query is the query as given.
+	Query string
+	// Candidates are the matching locations, most relevant first.
+	Candidates []models.Location
+	// Err is why the Pick of the query failed, if it had one.
+	Err error
+}
+
+// Error explains why no single location was chosen.
+func (e *AmbiguousError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%q matches %d locations", e.Query, len(e.Candidates))
+}
+
+// Unwrap returns the error of the failed Pick, if any.
+func (e *AmbiguousError) Unwrap() error {
+	return e.Err
+}
+
+// SearchQuery searches for query, which may name a country and region
+// after the place as in "Portland, OR, US", and returns the results that
+// match them and filter. Filter fields take precedence over the query.
+func SearchQuery(ctx context.Context, geocoder models.GeocodingService, query string, filter Filter, opts models.SearchOptions) ([]models.Location, error) {
+	name, parsed := ParseQuery(query)
+	filter = parsed.Merge(filter)
+	locations, err := geocoder.Search(ctx, name, filter.SearchOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return filter.Apply(locations), nil
+}
+
+// Query is a location to be resolved by a Resolver.
+type Query struct {
+	// Text is a place name, optionally qualified as in "Portland, OR, US",
+	// or a coordinate pair such as "52.52,13.41".
+	Text string
+	// Filter narrows down the results of a search by name.
+	Filter Filter
+	// Pick chooses between several matches. The zero Pick makes multiple
+	// matches an *AmbiguousError.
+	Pick Pick
+	// Options are passed to the geocoder.
+	Options models.SearchOptions
+}
+
+// Resolver resolves queries into a single location without asking the
+// user, for callers that are not attached to a terminal.
+type Resolver struct {
+	// Geocoder searches places by name.
+	Geocoder models.GeocodingService
+	// Reverse, if set, names the place nearest to coordinates.
+	Reverse models.ReverseGeocodingService
+}
+
+// Resolve returns the location q refers to. A query matching nothing
+// returns ErrLocationNotFound, and one matching several locations that
+// q.Pick does not choose between returns an *AmbiguousError.
+func (r Resolver) Resolve(ctx context.Context, q Query) (models.Location, error) {
+	lat, lon, err := ParseCoordinates(q.Text)
+	if err == nil {
+		return r.Locate(ctx, lat, lon), nil
+	}
+	if !errors.Is(err, ErrNotCoordinates) {
+		return models.Location{}, err
+	}
+
+	locations, err := SearchQuery(ctx, r.Geocoder, q.Text, q.Filter, q.Options)
+	if err != nil {
+		return models.Location{}, err
+	}
+	switch {
+	case len(locations) == 0:
+		return models.Location{}, fmt.Errorf("%w: %s", ErrLocationNotFound, q.Text)
+	case len(locations) == 1:
+		return locations[0], nil
+	case q.Pick.IsZero():
+		return models.Location{}, &AmbiguousError{Query: q.Text, Candidates: locations}
+	}
+
+	i, err := q.Pick.Choose(locations)
+	if err != nil {
+		return models.Location{}, &AmbiguousError{Query: q.Text, Candidates: locations, Err: err}
+	}
+	return locations[i], nil
+}
+
+// Locate returns the location for a coordinate pair, naming the nearest
+// place when the Resolver has a reverse geocoder that knows one. Reverse
+// geocoding failures are not fatal; the location is returned without the
+// nearby place.
+func (r Resolver) Locate(ctx context.Context, lat, lon float64) models.Location {
+	loc := CoordinateLocation(lat, lon)
+	if r.Reverse == nil {
+		return loc
+	}
+	if near, err := r.Reverse.Reverse(ctx, lat, lon); err == nil {
+		loc.Near = &near
+	}
+	return loc
+}
@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var resolvePortlands = []models.Location{
+	{ID: 5746545, Name: "Portland", Country: "United States", CountryCode: "US", Region: "Oregon", Population: 632309},
+	{ID: 4975802, Name: "Portland", Country: "United States", CountryCode: "US", Region: "Maine", Population: 66881},
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	geocoder := &countingSearcher{locations: resolvePortlands}
+	r := Resolver{Geocoder: geocoder}
+
+	loc, err := r.Resolve(context.Background(), Query{Text: "Portland, ME"})
+	require.NoError(t, err)
+	assert.Equal(t, "Maine", loc.Region)
+
+	loc, err = r.Resolve(context.Background(), Query{Text: "Portland", Filter: Filter{Region: "OR"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Oregon", loc.Region)
+
+	_, err = r.Resolve(context.Background(), Query{Text: "Portland, TX"})
+	assert.ErrorIs(t, err, ErrLocationNotFound)
+	assert.EqualError(t, err, "location not found: Portland, TX")
+}
+
+func TestResolver_Ambiguous(t *testing.T) {
+	r := Resolver{Geocoder: &countingSearcher{locations: resolvePortlands}}
+
+	_, err := r.Resolve(context.Background(), Query{Text: "Portland"})
+	var ambiguous *AmbiguousError
+	require.ErrorAs(t, err, &ambiguous)
+	assert.Equal(t, resolvePortlands, ambiguous.Candidates)
+	assert.EqualError(t, err, `"Portland" matches 2 locations`)
+
+	pick, err := ParsePick(PickMostPopulous)
+	require.NoError(t, err)
+	loc, err := r.Resolve(context.Background(), Query{Text: "Portland", Pick: pick})
+	require.NoError(t, err)
+	assert.Equal(t, "Oregon", loc.Region)
+
+	pick, err = ParsePick("3")
+	require.NoError(t, err)
+	_, err = r.Resolve(context.Background(), Query{Text: "Portland", Pick: pick})
+	require.ErrorAs(t, err, &ambiguous)
+	assert.Len(t, ambiguous.Candidates, 2)
+	assert.EqualError(t, err, "cannot pick location 3: only 2 locations found")
+}
+
+func TestResolver_Coordinates(t *testing.T) {
+	geocoder := &countingSearcher{}
+	berlin := models.Location{Name: "Berlin"}
+	r := Resolver{Geocoder: geocoder, Reverse: backendFunc(func(context.Context, float64, float64) (models.Location, error) {
+		return berlin, nil
+	})}
+
+	loc, err := r.Resolve(context.Background(), Query{Text: "52.52,13.41"})
+	require.NoError(t, err)
+	assert.Equal(t, 52.52, loc.Latitude)
+	assert.Equal(t, 13.41, loc.Longitude)
+	assert.Equal(t, &berlin, loc.Near)
+	assert.Zero(t, geocoder.calls, "coordinates are not searched")
+
+	_, err = r.Resolve(context.Background(), Query{Text: "95,13"})
+	assert.EqualError(t, err, "invalid latitude 95: must be between -90 and 90")
+}
+
+func TestResolver_SearchError(t *testing.T) {
+	searchErr := &SearchError{Kind: ErrUpstream, Err: errors.New("boom")}
+	r := Resolver{Geocoder: &countingSearcher{err: searchErr}}
+
+	_, err := r.Resolve(context.Background(), Query{Text: "Berlin"})
+	assert.ErrorIs(t, err, ErrUpstream)
+}
+
+func TestResolver_LocateWithoutReverse(t *testing.T) {
+	r := Resolver{Reverse: backendFunc(func(context.Context, float64, float64) (models.Location, error) {
+		return models.Location{}, ErrNoNearbyPlace
+	})}
+
+	loc := r.Locate(context.Background(), 1, 2)
+	assert.Equal(t, CoordinateLocation(1, 2), loc)
+	assert.Equal(t, CoordinateLocation(1, 2), Resolver{}.Locate(context.Background(), 1, 2))
+}
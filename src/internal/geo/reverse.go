@@ -0,0 +1,142 @@
+package geo
+
+import (
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"weather-reporter/src/internal/models"
+)
+
+// ErrNoNearbyPlace is returned when no known place is close enough to the
+// requested coordinates.
+var ErrNoNearbyPlace = errors.New("no nearby place found")
+
+// maxOfflineDistanceKm is the largest distance at which a place from the
+// offline dataset is still reported as nearby.
+const maxOfflineDistanceKm = 250
+
+//go:embed data/cities.csv
+var citiesCSV string
+
+// ReverseGeocoder implements models.ReverseGeocodingService. It asks a
+// pluggable backend for the nearest populated place and falls back to a
+// small offline dataset of major cities when the backend is unavailable or
+// fails.
+type ReverseGeocoder struct {
+	backend models.ReverseGeocodingService
+	offline *offlineDataset
+}
+
+// NewReverseGeocoder creates a reverse geocoder using the given backend.
+// If backend is nil, only the offline dataset is used.
+func NewReverseGeocoder(backend models.ReverseGeocodingService) *ReverseGeocoder {
+	return &ReverseGeocoder{
+		backend: backend,
+		offline: defaultOfflineDataset(),
+	}
+}
+
+// Reverse returns the populated place nearest to the given coordinates.
+func (r *ReverseGeocoder) Reverse(ctx context.Context, lat, lon float64) (models.Location, error) {
+	if err := ValidateCoordinates(lat, lon); err != nil {
+		return models.Location{}, err
+	}
+
+	if r.backend != nil {
+		loc, err := r.backend.Reverse(ctx, lat, lon)
+		if err == nil {
+			return loc, nil
+		}
+		if ctx.Err() != nil {
+			return models.Location{}, ctx.Err()
+		}
+	}
+
+	return r.offline.nearest(lat, lon)
+}
+
+// offlineDataset is a list of places searched by great-circle distance.
+type offlineDataset struct {
+	places []models.Location
+}
+
+var (
+	offlineOnce    sync.Once
+	offlineDefault *offlineDataset
+)
+
+// defaultOfflineDataset returns the embedded dataset, parsing it on first use.
+func defaultOfflineDataset() *offlineDataset {
+	offlineOnce.Do(func() {
+		places, err := parsePlaces(citiesCSV)
+		if err != nil {
+			panic(fmt.Sprintf("geo: invalid embedded cities dataset: %v", err))
+		}
+		offlineDefault = &offlineDataset{places: places}
+	})
+	return offlineDefault
+}
+
+// parsePlaces reads places from CSV with the header
+// name,country,admin1,latitude,longitude.
+func parsePlaces(data string) ([]models.Location, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("missing header")
+	}
+
+	places := make([]models.Location, 0, len(records)-1)
+	for i, rec := range records[1:] {
+		lat, latErr := strconv.ParseFloat(rec[3], 64)
+		lon, lonErr := strconv.ParseFloat(rec[4], 64)
+		if latErr != nil || lonErr != nil {
+			return nil, fmt.Errorf("line %d: invalid coordinates", i+2)
+		}
+		places = append(places, models.Location{
+			Name:      rec[0],
+			Country:   rec[1],
+			Region:    rec[2],
+			Latitude:  lat,
+			Longitude: lon,
+		})
+	}
+	return places, nil
+}
+
+// nearest returns the place closest to the given point, or
+// ErrNoNearbyPlace if none is within maxOfflineDistanceKm.
+func (d *offlineDataset) nearest(lat, lon float64) (models.Location, error) {
+	best, bestDistance := -1, math.Inf(1)
+	for i, p := range d.places {
+		if dist := distanceKm(lat, lon, p.Latitude, p.Longitude); dist < bestDistance {
+			best, bestDistance = i, dist
+		}
+	}
+	if best < 0 || bestDistance > maxOfflineDistanceKm {
+		return models.Location{}, ErrNoNearbyPlace
+	}
+	return d.places[best], nil
+}
+
+// distanceKm returns the great-circle distance between two points using the
+// haversine formula.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
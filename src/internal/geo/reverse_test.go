@@ -0,0 +1,96 @@
+package geo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type backendFunc func(ctx context.Context, lat, lon float64) (models.Location, error)
+
+func (f backendFunc) Reverse(ctx context.Context, lat, lon float64) (models.Location, error) {
+	return f(ctx, lat, lon)
+}
+
+func TestReverseGeocoder_Offline(t *testing.T) {
+	r := NewReverseGeocoder(nil)
+
+	loc, err := r.Reverse(context.Background(), 52.52, 13.41)
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", loc.Name)
+	assert.Equal(t, "Germany", loc.Country)
+	assert.Equal(t, "Berlin", loc.Region)
+
+	loc, err = r.Reverse(context.Background(), -33.86, 151.2)
+	require.NoError(t, err)
+	assert.Equal(t, "Sydney", loc.Name)
+}
+
+func TestReverseGeocoder_OfflineTooFar(t *testing.T) {
+	r := NewReverseGeocoder(nil)
+
+	// Point Nemo, the ocean pole of inaccessibility.
+	_, err := r.Reverse(context.Background(), -48.88, -123.39)
+	assert.ErrorIs(t, err, ErrNoNearbyPlace)
+}
+
+func TestReverseGeocoder_InvalidCoordinates(t *testing.T) {
+	r := NewReverseGeocoder(nil)
+
+	_, err := r.Reverse(context.Background(), 91, 0)
+	assert.Error(t, err)
+}
+
+func TestReverseGeocoder_Backend(t *testing.T) {
+	backend := backendFunc(func(ctx context.Context, lat, lon float64) (models.Location, error) {
+		return models.Location{Name: "Mitte", Country: "Germany", Region: "Berlin"}, nil
+	})
+	r := NewReverseGeocoder(backend)
+
+	loc, err := r.Reverse(context.Background(), 52.52, 13.41)
+	require.NoError(t, err)
+	assert.Equal(t, "Mitte", loc.Name)
+}
+
+func TestReverseGeocoder_BackendFailureFallsBack(t *testing.T) {
+	backend := backendFunc(func(ctx context.Context, lat, lon float64) (models.Location, error) {
+		return models.Location{}, errors.New("service unavailable")
+	})
+	r := NewReverseGeocoder(backend)
+
+	loc, err := r.Reverse(context.Background(), 48.86, 2.35)
+	require.NoError(t, err)
+	assert.Equal(t, "Paris", loc.Name)
+}
+
+func TestReverseGeocoder_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	backend := backendFunc(func(ctx context.Context, lat, lon float64) (models.Location, error) {
+		return models.Location{}, ctx.Err()
+	})
+	r := NewReverseGeocoder(backend)
+
+	_, err := r.Reverse(ctx, 52.52, 13.41)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParsePlaces(t *testing.T) {
+	places, err := parsePlaces("name,country,admin1,latitude,longitude\nBerlin,Germany,Berlin,52.5244,13.4105\n")
+	require.NoError(t, err)
+	assert.Equal(t, []models.Location{{Name: "Berlin", Country: "Germany", Region: "Berlin", Latitude: 52.5244, Longitude: 13.4105}}, places)
+
+	_, err = parsePlaces("name,country,admin1,latitude,longitude\nBerlin,Germany,Berlin,north,13.4105\n")
+	assert.Error(t, err)
+}
+
+func TestDistanceKm(t *testing.T) {
+	// Berlin to Paris is roughly 878 km.
+	assert.InDelta(t, 878, distanceKm(52.52, 13.405, 48.8566, 2.3522), 5)
+	assert.InDelta(t, 0, distanceKm(10, 10, 10, 10), 1e-9)
+}
@@ -0,0 +1,105 @@
+package geo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type countryKey struct{}
+
+// withCountry returns a context carrying the country to restrict a search
+// to.
+func withCountry(ctx context.Context, countryCode string) context.Context {
+	return context.WithValue(ctx, countryKey{}, strings.ToUpper(countryCode))
+}
+
+type recorderKey struct{}
+
+// responseRecorder keeps the status of a search response, which the SDK
+// does not report, and the body of a successful one, so that fields the
+// SDK does not decode can be read from it.
+type responseRecorder struct {
+	status int
+	body   []byte
+}
+
+// withRecorder returns a context whose search response is kept by the
+// returned recorder.
+func withRecorder(ctx context.Context) (context.Context, *responseRecorder) {
+	rec := &responseRecorder{}
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+// rawLocation holds the fields of a search result that the SDK drops.
+type rawLocation struct {
+	ID          int      `json:"id"`
+	Timezone    string   `json:"timezone"`
+	Population  int      `json:"population"`
+	Postcodes   []string `json:"postcodes"`
+	FeatureCode string   `json:"feature_code"`
+}
+
+// locations decodes the recorded response, keyed by location ID. It
+// returns nil if nothing was recorded or the body cannot be decoded; the
+// extra fields are then simply unknown.
+func (r *responseRecorder) locations() map[int]rawLocation {
+	var resp struct {
+		Results []rawLocation `json:"results"`
+	}
+	if len(r.body) == 0 || json.Unmarshal(r.body, &resp) != nil {
+		return nil
+	}
+	byID := make(map[int]rawLocation, len(resp.Results))
+	for _, loc := range resp.Results {
+		byID[loc.ID] = loc
+	}
+	return byID
+}
+
+// searchTransport lets per-search state flow through requests built by the
+// SDK, which has no options for it: it adds the country carried by the
+// request context to the query as the API's countryCode parameter, and
+// records the response for a recorder carried by the context.
+type searchTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *searchTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if country, ok := req.Context().Value(countryKey{}).(string); ok {
+		req = req.Clone(req.Context())
+		q := req.URL.Query()
+		q.Set("countryCode", country)
+		req.URL.RawQuery = q.Encode()
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rec, ok := req.Context().Value(recorderKey{}).(*responseRecorder)
+	if !ok {
+		return resp, nil
+	}
+	rec.status = resp.StatusCode
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	rec.body = body
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
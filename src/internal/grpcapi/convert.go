@@ -0,0 +1,100 @@
+package grpcapi
+
+import (
+	"time"
+
+	"weather-reporter/src/internal/grpcapi/weatherpb"
+	"weather-reporter/src/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toLocation(loc models.Location) *weatherpb.Location {
+	pb := &weatherpb.Location{
+		Id:          int64(loc.ID),
+		Name:        loc.Name,
+		Latitude:    loc.Latitude,
+		Longitude:   loc.Longitude,
+		Country:     loc.Country,
+		CountryCode: loc.CountryCode,
+		Admin1:      loc.Region,
+		Admin2:      loc.Admin2,
+		Admin3:      loc.Admin3,
+		Admin4:      loc.Admin4,
+		Timezone:    loc.Timezone,
+		Elevation:   loc.Elevation,
+		Population:  int64(loc.Population),
+		Postcodes:   loc.Postcodes,
+		FeatureCode: loc.FeatureCode,
+	}
+	if loc.Near != nil {
+		pb.Near = toLocation(*loc.Near)
+	}
+	return pb
+}
+
+func toLocations(locations []models.Location) []*weatherpb.Location {
+	pbs := make([]*weatherpb.Location, len(locations))
+	for i, loc := range locations {
+		pbs[i] = toLocation(loc)
+	}
+	return pbs
+}
+
+func toCurrentWeather(obs models.Observation) *weatherpb.CurrentWeather {
+	return &weatherpb.CurrentWeather{
+		Time:                toTimestamp(obs.Time),
+		Temperature:         toMeasurement(obs.Temperature),
+		ApparentTemperature: toMeasurement(obs.ApparentTemperature),
+		Humidity:            toMeasurement(obs.Humidity),
+		Precipitation:       toMeasurement(obs.Precipitation),
+		CloudCover:          toMeasurement(obs.CloudCover),
+		Pressure:            toMeasurement(obs.Pressure),
+		WindSpeed:           toMeasurement(obs.WindSpeed),
+		WindDirection:       toMeasurement(obs.WindDirection),
+		WindGusts:           toMeasurement(obs.WindGusts),
+	}
+}
+
+func toDailyForecasts(days []models.DailyForecast) []*weatherpb.DailyForecast {
+	pbs := make([]*weatherpb.DailyForecast, len(days))
+	for i, d := range days {
+		pbs[i] = &weatherpb.DailyForecast{
+			Date:             d.Date.Format(time.DateOnly),
+			TemperatureMin:   toMeasurement(d.TemperatureMin),
+			TemperatureMax:   toMeasurement(d.TemperatureMax),
+			PrecipitationSum: toMeasurement(d.PrecipitationSum),
+			WindSpeedMax:     toMeasurement(d.WindSpeedMax),
+		}
+	}
+	return pbs
+}
+
+func toHourlyForecasts(hours []models.HourlyForecast) []*weatherpb.HourlyForecast {
+	pbs := make([]*weatherpb.HourlyForecast, len(hours))
+	for i, h := range hours {
+		pbs[i] = &weatherpb.HourlyForecast{
+			Time:                     toTimestamp(h.Time),
+			Temperature:              toMeasurement(h.Temperature),
+			PrecipitationProbability: toMeasurement(h.PrecipitationProbability),
+			WindSpeed:                toMeasurement(h.WindSpeed),
+			WindDirection:            toMeasurement(h.WindDirection),
+		}
+	}
+	return pbs
+}
+
+func toMeasurement(m models.Measurement) *weatherpb.Measurement {
+	if m.Missing {
+		return nil
+	}
+	return &weatherpb.Measurement{Value: m.Value, Unit: m.Unit}
+}
+
+// toTimestamp converts t, leaving the zero time unset.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
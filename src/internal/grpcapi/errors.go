@@ -0,0 +1,63 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/grpcapi/weatherpb"
+	"weather-reporter/src/internal/weather"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus converts an error from the geocoding or weather services into a
+// gRPC status. Ambiguous locations fail with FailedPrecondition and an
+// AmbiguousLocation detail listing the candidates, so that the client can
+// choose one.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var ambiguous *geo.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		st := status.New(codes.FailedPrecondition, err.Error())
+		detailed, detailErr := st.WithDetails(&weatherpb.AmbiguousLocation{
+			Query:      ambiguous.Query,
+			Candidates: toLocations(ambiguous.Candidates),
+		})
+		if detailErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	}
+	return status.Error(errorCode(err), err.Error())
+}
+
+// errorCode returns the status code for a failed call. Requests the
+// upstream services rejected are invalid arguments, while failing,
+// unreachable or throttling services are unavailable. Errors of unknown
+// cause are reported as such.
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, geo.ErrBadRequest), errors.Is(err, weather.ErrBadRequest):
+		return codes.InvalidArgument
+	case errors.Is(err, geo.ErrLocationNotFound):
+		return codes.NotFound
+	case errors.Is(err, geo.ErrConcurrencyLimit), errors.Is(err, weather.ErrConcurrencyLimit):
+		return codes.ResourceExhausted
+	case errors.Is(err, breaker.ErrOpen),
+		errors.Is(err, geo.ErrRateLimited), errors.Is(err, weather.ErrRateLimited),
+		errors.Is(err, geo.ErrUpstream), errors.Is(err, weather.ErrUpstream),
+		errors.Is(err, geo.ErrNetwork), errors.Is(err, weather.ErrNetwork):
+		return codes.Unavailable
+	case errors.Is(err, geo.ErrTimeout), errors.Is(err, weather.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, geo.ErrCanceled), errors.Is(err, context.Canceled):
+		return codes.Canceled
+	}
+	return codes.Unknown
+}
@@ -0,0 +1,104 @@
+// Package grpcapi serves the geocoding and weather services over gRPC, as
+// defined in weatherpb/weather.proto, together with the standard health
+// checking and reflection services.
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/grpcapi/weatherpb"
+	"weather-reporter/src/internal/models"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Options configure the gRPC service.
+type Options struct {
+	// Geocoder searches locations by name.
+	Geocoder models.GeocodingService
+	// Reverse, if set, names the place nearest to requested coordinates.
+	Reverse models.ReverseGeocodingService
+	// Weather fetches current weather and forecasts.
+	Weather models.WeatherService
+	// Units are used when a request does not select any. The zero value
+	// means metric units.
+	Units models.Units
+	// RequestTimeout, if positive, bounds each call in addition to the
+	// client's deadline.
+	RequestTimeout time.Duration
+}
+
+// Server is a gRPC server for the WeatherReporter service.
+type Server struct {
+	grpc   *grpc.Server
+	health *health.Server
+}
+
+// New returns a server with the WeatherReporter, health and reflection
+// services registered. Health is reported for the empty service name and
+// for weatherreporter.v1.WeatherReporter.
+func New(opts Options) *Server {
+	var serverOpts []grpc.ServerOption
+	if opts.RequestTimeout > 0 {
+		serverOpts = append(serverOpts, grpc.UnaryInterceptor(timeoutInterceptor(opts.RequestTimeout)))
+	}
+	s := &Server{
+		grpc:   grpc.NewServer(serverOpts...),
+		health: health.NewServer(),
+	}
+
+	weatherpb.RegisterWeatherReporterServer(s.grpc, &service{
+		opts:     opts,
+		resolver: geo.Resolver{Geocoder: opts.Geocoder, Reverse: opts.Reverse},
+	})
+	s.health.SetServingStatus(weatherpb.WeatherReporter_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s.grpc, s.health)
+	reflection.Register(s.grpc)
+	return s
+}
+
+// Serve serves on ln until ctx is done, then shuts down gracefully: health
+// checks report NOT_SERVING, no new calls are accepted, and calls in
+// flight are given up to shutdownTimeout to finish before they are
+// canceled.
+func (s *Server) Serve(ctx context.Context, ln net.Listener, shutdownTimeout time.Duration) error {
+	errc := make(chan error, 1)
+	go func() { errc <- s.grpc.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.health.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(stopped)
+	}()
+	timer := time.NewTimer(shutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		s.grpc.Stop()
+		<-stopped
+	}
+	return <-errc
+}
+
+// timeoutInterceptor bounds every unary call by timeout.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
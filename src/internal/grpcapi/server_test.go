@@ -0,0 +1,142 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/grpcapi/weatherpb"
+	"weather-reporter/src/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer serves srv on an in-process listener until the test ends
+// and returns a client connection to it.
+func startServer(t *testing.T, srv *Server) *grpc.ClientConn {
+	t.Helper()
+	ln := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln, time.Second) }()
+
+	conn := dial(t, ln)
+	t.Cleanup(func() {
+		_ = conn.Close()
+		cancel()
+		assert.NoError(t, <-done)
+	})
+	return conn
+}
+
+func dial(t *testing.T, ln *bufconn.Listener) *grpc.ClientConn {
+	t.Helper()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	return conn
+}
+
+func TestServer_Health(t *testing.T) {
+	conn := startServer(t, New(Options{}))
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", "weatherreporter.v1.WeatherReporter"} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err, service)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus(), service)
+	}
+}
+
+func TestServer_Reflection(t *testing.T) {
+	conn := startServer(t, New(Options{}))
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+
+	var names []string
+	for _, s := range resp.GetListServicesResponse().GetService() {
+		names = append(names, s.GetName())
+	}
+	assert.Contains(t, names, "weatherreporter.v1.WeatherReporter")
+	assert.Contains(t, names, "grpc.health.v1.Health")
+}
+
+func TestServer_GracefulShutdown(t *testing.T) {
+	weather := &testutil.WeatherService{Delay: 200 * time.Millisecond}
+	srv := New(Options{Geocoder: &testutil.Geocoder{}, Weather: weather})
+	ln := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln, 5*time.Second) }()
+
+	conn := dial(t, ln)
+	defer conn.Close()
+	client := weatherpb.NewWeatherReporterClient(conn)
+	calls := make(chan error, 1)
+	go func() {
+		_, err := client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+			Location: byCoordinates(52.52, 13.41),
+		})
+		calls <- err
+	}()
+
+	// Shut down while the call is in flight; it still completes.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.NoError(t, <-calls)
+	assert.NoError(t, <-done)
+	assert.Equal(t, 52.52, weather.Lat)
+}
+
+func TestServer_ShutdownTimeout(t *testing.T) {
+	srv := New(Options{Geocoder: &testutil.Geocoder{}, Weather: &testutil.WeatherService{Delay: time.Minute}})
+	ln := bufconn.Listen(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln, 10*time.Millisecond) }()
+
+	conn := dial(t, ln)
+	defer conn.Close()
+	calls := make(chan error, 1)
+	go func() {
+		_, err := weatherpb.NewWeatherReporterClient(conn).GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+			Location: byCoordinates(52.52, 13.41),
+		})
+		calls <- err
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after the shutdown timeout")
+	}
+	assert.Error(t, <-calls, "the call in flight is canceled")
+}
+
+func TestServer_ListenerError(t *testing.T) {
+	ln := bufconn.Listen(1 << 20)
+	require.NoError(t, ln.Close())
+
+	assert.Error(t, New(Options{}).Serve(context.Background(), ln, time.Second))
+}
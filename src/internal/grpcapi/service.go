@@ -0,0 +1,185 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/grpcapi/weatherpb"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/params"
+	"weather-reporter/src/internal/weather"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// defaultForecastDays and defaultForecastHours are the forecast
+	// horizons used when a request does not give one.
+	defaultForecastDays  = 7
+	defaultForecastHours = 24
+)
+
+// service implements weatherpb.WeatherReporterServer.
+type service struct {
+	weatherpb.UnimplementedWeatherReporterServer
+	opts     Options
+	resolver geo.Resolver
+}
+
+// SearchLocations implements weatherpb.WeatherReporterServer.
+func (s *service) SearchLocations(ctx context.Context, req *weatherpb.SearchLocationsRequest) (*weatherpb.SearchLocationsResponse, error) {
+	name := strings.TrimSpace(req.GetName())
+	if name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	count := int(req.GetCount())
+	if count < 0 || count > config.MaxSearchCount {
+		return nil, status.Errorf(codes.InvalidArgument, "count must be between 1 and %d", config.MaxSearchCount)
+	}
+	query, err := params.Search{Country: req.GetCountry(), Region: req.GetRegion(), Language: req.GetLanguage()}.Query()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	query.Options.Count = count
+
+	locations, err := geo.SearchQuery(ctx, s.opts.Geocoder, name, query.Filter, query.Options)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	// Filtering may have requested more results than asked for.
+	if count > 0 && len(locations) > count {
+		locations = locations[:count]
+	}
+	return &weatherpb.SearchLocationsResponse{Locations: toLocations(locations)}, nil
+}
+
+// GetCurrentWeather implements weatherpb.WeatherReporterServer.
+func (s *service) GetCurrentWeather(ctx context.Context, req *weatherpb.GetCurrentWeatherRequest) (*weatherpb.GetCurrentWeatherResponse, error) {
+	units, err := s.units(req.GetUnits())
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ctx, req.GetLocation())
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.opts.Weather.GetCurrentWeather(ctx, loc.Latitude, loc.Longitude, units)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &weatherpb.GetCurrentWeatherResponse{
+		Location: toLocation(loc),
+		Current:  toCurrentWeather(current.Observation()),
+	}, nil
+}
+
+// GetDailyForecast implements weatherpb.WeatherReporterServer.
+func (s *service) GetDailyForecast(ctx context.Context, req *weatherpb.GetDailyForecastRequest) (*weatherpb.GetDailyForecastResponse, error) {
+	days, err := horizon("days", req.GetDays(), defaultForecastDays, weather.MaxForecastDays)
+	if err != nil {
+		return nil, err
+	}
+	units, err := s.units(req.GetUnits())
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ctx, req.GetLocation())
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := s.opts.Weather.GetDailyForecast(ctx, loc.Latitude, loc.Longitude, days, units)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &weatherpb.GetDailyForecastResponse{Location: toLocation(loc), Days: toDailyForecasts(forecast)}, nil
+}
+
+// GetHourlyForecast implements weatherpb.WeatherReporterServer.
+func (s *service) GetHourlyForecast(ctx context.Context, req *weatherpb.GetHourlyForecastRequest) (*weatherpb.GetHourlyForecastResponse, error) {
+	hours, err := horizon("hours", req.GetHours(), defaultForecastHours, weather.MaxForecastHours)
+	if err != nil {
+		return nil, err
+	}
+	units, err := s.units(req.GetUnits())
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.locate(ctx, req.GetLocation())
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := s.opts.Weather.GetHourlyForecast(ctx, loc.Latitude, loc.Longitude, hours, units)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &weatherpb.GetHourlyForecastResponse{Location: toLocation(loc), Hours: toHourlyForecasts(forecast)}, nil
+}
+
+// locate resolves q into a single location. Ambiguous names fail unless
+// q has a pick that chooses between the matches.
+func (s *service) locate(ctx context.Context, q *weatherpb.LocationQuery) (models.Location, error) {
+	switch l := q.GetLocation().(type) {
+	case *weatherpb.LocationQuery_Coordinates:
+		lat, lon := l.Coordinates.GetLatitude(), l.Coordinates.GetLongitude()
+		if err := geo.ValidateCoordinates(lat, lon); err != nil {
+			return models.Location{}, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return s.resolver.Locate(ctx, lat, lon), nil
+	case *weatherpb.LocationQuery_Name:
+		return s.locateName(ctx, q, strings.TrimSpace(l.Name))
+	}
+	return models.Location{}, status.Error(codes.InvalidArgument, "location name or coordinates are required")
+}
+
+// locateName resolves a location query by name.
+func (s *service) locateName(ctx context.Context, q *weatherpb.LocationQuery, name string) (models.Location, error) {
+	if name == "" {
+		return models.Location{}, status.Error(codes.InvalidArgument, "location name or coordinates are required")
+	}
+	query, err := params.Location{
+		Name:   name,
+		Search: params.Search{Country: q.GetCountry(), Region: q.GetRegion(), Language: q.GetLanguage()},
+		Pick:   q.GetPick(),
+	}.Query()
+	if err != nil {
+		return models.Location{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	loc, err := s.resolver.Resolve(ctx, query)
+	if err != nil {
+		return models.Location{}, toStatus(err)
+	}
+	return loc, nil
+}
+
+// units returns the units selected by u, starting from the configured
+// units.
+func (s *service) units(u *weatherpb.Units) (models.Units, error) {
+	units, err := params.Units{
+		System:        u.GetSystem(),
+		Temperature:   u.GetTemperature(),
+		WindSpeed:     u.GetWindSpeed(),
+		Precipitation: u.GetPrecipitation(),
+	}.Apply(s.opts.Units)
+	if err != nil {
+		return models.Units{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return units, nil
+}
+
+// horizon returns the forecast length n, or def if n is zero.
+func horizon(name string, n int32, def, maxN int) (int, error) {
+	if n == 0 {
+		return def, nil
+	}
+	if n < 1 || int(n) > maxN {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be between 1 and %d", name, maxN)
+	}
+	return int(n), nil
+}
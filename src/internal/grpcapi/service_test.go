@@ -0,0 +1,313 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/grpcapi/weatherpb"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/testutil"
+	"weather-reporter/src/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testService struct {
+	geo     *testutil.Geocoder
+	weather *testutil.WeatherService
+	client  weatherpb.WeatherReporterClient
+}
+
+func newTestService(t *testing.T, opts Options) *testService {
+	t.Helper()
+	s := &testService{
+		geo:     testutil.NewGeocoder(),
+		weather: &testutil.WeatherService{},
+	}
+	opts.Geocoder, opts.Weather, opts.Reverse = s.geo, s.weather, testutil.ReverseGeocoder{}
+	s.client = weatherpb.NewWeatherReporterClient(startServer(t, New(opts)))
+	return s
+}
+
+func byName(name string) *weatherpb.LocationQuery {
+	return &weatherpb.LocationQuery{Location: &weatherpb.LocationQuery_Name{Name: name}}
+}
+
+func byCoordinates(lat, lon float64) *weatherpb.LocationQuery {
+	return &weatherpb.LocationQuery{Location: &weatherpb.LocationQuery_Coordinates{
+		Coordinates: &weatherpb.Coordinates{Latitude: lat, Longitude: lon},
+	}}
+}
+
+func TestSearchLocations(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.SearchLocations(context.Background(), &weatherpb.SearchLocationsRequest{
+		Name: "Portland", Count: 1, Language: "de",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetLocations(), 1)
+	assert.Equal(t, "Oregon", resp.GetLocations()[0].GetAdmin1())
+	assert.Equal(t, int64(632309), resp.GetLocations()[0].GetPopulation())
+	assert.Equal(t, "de", s.geo.Opts.Language)
+}
+
+func TestSearchLocations_Filter(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.SearchLocations(context.Background(), &weatherpb.SearchLocationsRequest{
+		Name: "Portland", Country: "us", Region: "ME",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetLocations(), 1)
+	assert.Equal(t, "Maine", resp.GetLocations()[0].GetAdmin1())
+	assert.Equal(t, "US", s.geo.Opts.CountryCode)
+}
+
+func TestSearchLocations_NoResults(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.SearchLocations(context.Background(), &weatherpb.SearchLocationsRequest{Name: "Atlantis"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetLocations())
+}
+
+func TestGetCurrentWeather(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+		Location: byName("Berlin"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", resp.GetLocation().GetName())
+	assert.Equal(t, "Europe/Berlin", resp.GetLocation().GetTimezone())
+	assert.Equal(t, 2.5, resp.GetCurrent().GetTemperature().GetValue())
+	assert.Equal(t, "°C", resp.GetCurrent().GetTemperature().GetUnit())
+	assert.Equal(t, time.Date(2026, 1, 1, 14, 15, 0, 0, time.UTC), resp.GetCurrent().GetTime().AsTime())
+	assert.Equal(t, models.MetricUnits(), s.weather.Units)
+}
+
+func TestGetCurrentWeather_Coordinates(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+		Location: byCoordinates(52.5, 13.4),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 52.5, resp.GetLocation().GetLatitude())
+	assert.Equal(t, "Berlin", resp.GetLocation().GetNear().GetName())
+	assert.Equal(t, 52.5, s.weather.Lat)
+	assert.Equal(t, 13.4, s.weather.Lon)
+}
+
+func TestGetCurrentWeather_CoordinateName(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+		Location: byName("52.5,13.4"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 13.4, resp.GetLocation().GetLongitude())
+}
+
+func TestGetCurrentWeather_Units(t *testing.T) {
+	s := newTestService(t, Options{Units: models.ImperialUnits()})
+
+	_, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+		Location: byName("Berlin"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ImperialUnits(), s.weather.Units)
+
+	_, err = s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+		Location: byName("Berlin"),
+		Units:    &weatherpb.Units{System: "metric", WindSpeed: "kn"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.Celsius, s.weather.Units.Temperature)
+	assert.Equal(t, models.Knots, s.weather.Units.WindSpeed)
+}
+
+func TestGetCurrentWeather_Ambiguous(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	_, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+		Location: byName("Portland"),
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	require.Len(t, st.Details(), 1)
+	detail, ok := st.Details()[0].(*weatherpb.AmbiguousLocation)
+	require.True(t, ok, "detail is %T", st.Details()[0])
+	assert.Equal(t, "Portland", detail.GetQuery())
+	require.Len(t, detail.GetCandidates(), 2)
+	assert.Equal(t, "Maine", detail.GetCandidates()[1].GetAdmin1())
+}
+
+func TestGetCurrentWeather_Pick(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	query := byName("Portland")
+	query.Pick = "2"
+	resp, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{Location: query})
+	require.NoError(t, err)
+	assert.Equal(t, "Maine", resp.GetLocation().GetAdmin1())
+	assert.Equal(t, -70.26, s.weather.Lon)
+}
+
+func TestGetDailyForecast(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.GetDailyForecast(context.Background(), &weatherpb.GetDailyForecastRequest{
+		Location: byName("Berlin"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 7, s.weather.N)
+	require.Len(t, resp.GetDays(), 1)
+	assert.Equal(t, "2026-01-01", resp.GetDays()[0].GetDate())
+	assert.Equal(t, 3.4, resp.GetDays()[0].GetTemperatureMax().GetValue())
+	assert.Nil(t, resp.GetDays()[0].GetWindSpeedMax(), "missing values are left unset")
+
+	_, err = s.client.GetDailyForecast(context.Background(), &weatherpb.GetDailyForecastRequest{
+		Location: byName("Berlin"), Days: 16,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 16, s.weather.N)
+}
+
+func TestGetHourlyForecast(t *testing.T) {
+	s := newTestService(t, Options{})
+
+	resp, err := s.client.GetHourlyForecast(context.Background(), &weatherpb.GetHourlyForecastRequest{
+		Location: byName("Berlin"), Hours: 48,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 48, s.weather.N)
+	require.Len(t, resp.GetHours(), 1)
+	assert.Equal(t, time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC), resp.GetHours()[0].GetTime().AsTime())
+	assert.Equal(t, 3.1, resp.GetHours()[0].GetTemperature().GetValue())
+}
+
+func TestInvalidArguments(t *testing.T) {
+	s := newTestService(t, Options{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"search without name", func() error {
+			_, err := s.client.SearchLocations(ctx, &weatherpb.SearchLocationsRequest{Name: " "})
+			return err
+		}},
+		{"search count", func() error {
+			_, err := s.client.SearchLocations(ctx, &weatherpb.SearchLocationsRequest{Name: "Berlin", Count: 101})
+			return err
+		}},
+		{"search language", func() error {
+			_, err := s.client.SearchLocations(ctx, &weatherpb.SearchLocationsRequest{Name: "Berlin", Language: "xx-invalid"})
+			return err
+		}},
+		{"search country", func() error {
+			_, err := s.client.SearchLocations(ctx, &weatherpb.SearchLocationsRequest{Name: "Berlin", Country: "Narnia"})
+			return err
+		}},
+		{"no location", func() error {
+			_, err := s.client.GetCurrentWeather(ctx, &weatherpb.GetCurrentWeatherRequest{})
+			return err
+		}},
+		{"empty name", func() error {
+			_, err := s.client.GetCurrentWeather(ctx, &weatherpb.GetCurrentWeatherRequest{Location: byName("")})
+			return err
+		}},
+		{"coordinates out of range", func() error {
+			_, err := s.client.GetCurrentWeather(ctx, &weatherpb.GetCurrentWeatherRequest{Location: byCoordinates(91, 0)})
+			return err
+		}},
+		{"coordinate name out of range", func() error {
+			_, err := s.client.GetCurrentWeather(ctx, &weatherpb.GetCurrentWeatherRequest{Location: byName("91,0")})
+			return err
+		}},
+		{"pick", func() error {
+			query := byName("Portland")
+			query.Pick = "nearest"
+			_, err := s.client.GetCurrentWeather(ctx, &weatherpb.GetCurrentWeatherRequest{Location: query})
+			return err
+		}},
+		{"units", func() error {
+			_, err := s.client.GetCurrentWeather(ctx, &weatherpb.GetCurrentWeatherRequest{
+				Location: byName("Berlin"), Units: &weatherpb.Units{Temperature: "kelvin"},
+			})
+			return err
+		}},
+		{"days", func() error {
+			_, err := s.client.GetDailyForecast(ctx, &weatherpb.GetDailyForecastRequest{Location: byName("Berlin"), Days: 17})
+			return err
+		}},
+		{"hours", func() error {
+			_, err := s.client.GetHourlyForecast(ctx, &weatherpb.GetHourlyForecastRequest{Location: byName("Berlin"), Hours: -1})
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), "%v", err)
+		})
+	}
+	assert.Zero(t, s.weather.Lat, "invalid requests must not fetch weather")
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		geoErr     error
+		weatherErr error
+		location   string
+		want       codes.Code
+	}{
+		{"not found", nil, nil, "Atlantis", codes.NotFound},
+		{"bad geocoding request", geo.ErrBadRequest, nil, "Bonn", codes.InvalidArgument},
+		{"rate limited", geo.ErrRateLimited, nil, "Bonn", codes.Unavailable},
+		{"too many searches", geo.ErrConcurrencyLimit, nil, "Bonn", codes.ResourceExhausted},
+		{"geocoding timeout", geo.ErrTimeout, nil, "Bonn", codes.DeadlineExceeded},
+		{"breaker open", nil, &breaker.OpenError{Name: "weather", RetryIn: time.Second}, "Berlin", codes.Unavailable},
+		{"too many weather requests", nil, &weather.RequestError{Kind: weather.ErrConcurrencyLimit, Err: errors.New("limit")}, "Berlin", codes.ResourceExhausted},
+		{"geocoding failure", &geo.SearchError{Kind: geo.ErrUpstream, StatusCode: 502, Err: errors.New("502")}, nil, "Bonn", codes.Unavailable},
+		{"geocoding unreachable", &geo.SearchError{Kind: geo.ErrNetwork, Err: errors.New("connection refused")}, nil, "Bonn", codes.Unavailable},
+		{"weather failure", nil, &weather.RequestError{Kind: weather.ErrUpstream, StatusCode: 500, Err: errors.New("API returned status 500")}, "Berlin", codes.Unavailable},
+		{"weather unreachable", nil, &weather.RequestError{Kind: weather.ErrNetwork, Err: errors.New("connection refused")}, "Berlin", codes.Unavailable},
+		{"weather rate limited", nil, &weather.RequestError{Kind: weather.ErrRateLimited, StatusCode: 429, Err: errors.New("API returned status 429")}, "Berlin", codes.Unavailable},
+		{"weather rejected", nil, &weather.RequestError{Kind: weather.ErrBadRequest, StatusCode: 400, Err: errors.New("API returned status 400")}, "Berlin", codes.InvalidArgument},
+		{"weather timeout", nil, &weather.RequestError{Kind: weather.ErrTimeout, Err: context.DeadlineExceeded}, "Berlin", codes.DeadlineExceeded},
+		{"unknown failure", nil, errors.New("received nil response from SDK"), "Berlin", codes.Unknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, Options{})
+			s.geo.Err, s.weather.Err = tt.geoErr, tt.weatherErr
+
+			_, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+				Location: byName(tt.location),
+			})
+			assert.Equal(t, tt.want, status.Code(err), "%v", err)
+		})
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	s := newTestService(t, Options{RequestTimeout: 10 * time.Millisecond})
+	s.weather.Delay = time.Minute
+
+	_, err := s.client.GetCurrentWeather(context.Background(), &weatherpb.GetCurrentWeatherRequest{
+		Location: byName("Berlin"),
+	})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err), "%v", err)
+}
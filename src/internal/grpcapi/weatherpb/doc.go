@@ -0,0 +1,5 @@
+// Package weatherpb contains the protocol buffer messages and gRPC service
+// generated from weather.proto.
+package weatherpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative weather.proto
@@ -0,0 +1,1512 @@
+// The WeatherReporter service searches locations and fetches current
+// weather and forecasts, like the weather-reporter command line.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: weather.proto
+
+package weatherpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Location is a place, as found by a location search or given by its
+// coordinates.
+type Location struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Geocoding ID; 0 for locations given by coordinates.
+	Id   int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Coordinates in decimal degrees.
+	Latitude  float64 `protobuf:"fixed64,3,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,4,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Country   string  `protobuf:"bytes,5,opt,name=country,proto3" json:"country,omitempty"`
+	// ISO 3166-1 alpha-2 code, such as "DE".
+	CountryCode string `protobuf:"bytes,6,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	// First- to fourth-order administrative areas, such as a state, county
+	// or municipality. Often empty.
+	Admin1 string `protobuf:"bytes,7,opt,name=admin1,proto3" json:"admin1,omitempty"`
+	Admin2 string `protobuf:"bytes,8,opt,name=admin2,proto3" json:"admin2,omitempty"`
+	Admin3 string `protobuf:"bytes,9,opt,name=admin3,proto3" json:"admin3,omitempty"`
+	Admin4 string `protobuf:"bytes,10,opt,name=admin4,proto3" json:"admin4,omitempty"`
+	// IANA time zone name, such as "Europe/Berlin".
+	Timezone string `protobuf:"bytes,11,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// Height above sea level in meters.
+	Elevation float64 `protobuf:"fixed64,12,opt,name=elevation,proto3" json:"elevation,omitempty"`
+	// Number of inhabitants, or 0 if unknown.
+	Population int64    `protobuf:"varint,13,opt,name=population,proto3" json:"population,omitempty"`
+	Postcodes  []string `protobuf:"bytes,14,rep,name=postcodes,proto3" json:"postcodes,omitempty"`
+	// GeoNames feature code, such as "PPLC" for a capital.
+	FeatureCode string `protobuf:"bytes,15,opt,name=feature_code,json=featureCode,proto3" json:"feature_code,omitempty"`
+	// Nearest known place, for locations given by coordinates.
+	Near          *Location `protobuf:"bytes,16,opt,name=near,proto3" json:"near,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_weather_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Location) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Location) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Location) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Location) GetCountryCode() string {
+	if x != nil {
+		return x.CountryCode
+	}
+	return ""
+}
+
+func (x *Location) GetAdmin1() string {
+	if x != nil {
+		return x.Admin1
+	}
+	return ""
+}
+
+func (x *Location) GetAdmin2() string {
+	if x != nil {
+		return x.Admin2
+	}
+	return ""
+}
+
+func (x *Location) GetAdmin3() string {
+	if x != nil {
+		return x.Admin3
+	}
+	return ""
+}
+
+func (x *Location) GetAdmin4() string {
+	if x != nil {
+		return x.Admin4
+	}
+	return ""
+}
+
+func (x *Location) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Location) GetElevation() float64 {
+	if x != nil {
+		return x.Elevation
+	}
+	return 0
+}
+
+func (x *Location) GetPopulation() int64 {
+	if x != nil {
+		return x.Population
+	}
+	return 0
+}
+
+func (x *Location) GetPostcodes() []string {
+	if x != nil {
+		return x.Postcodes
+	}
+	return nil
+}
+
+func (x *Location) GetFeatureCode() string {
+	if x != nil {
+		return x.FeatureCode
+	}
+	return ""
+}
+
+func (x *Location) GetNear() *Location {
+	if x != nil {
+		return x.Near
+	}
+	return nil
+}
+
+// LocationQuery selects a single location.
+type LocationQuery struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Location:
+	//
+	//	*LocationQuery_Name
+	//	*LocationQuery_Coordinates
+	Location isLocationQuery_Location `protobuf_oneof:"location"`
+	// Only consider places in this country, as an ISO 3166-1 code or name.
+	Country string `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	// Only consider places in this state or region, by name or abbreviation.
+	Region string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	// ISO 639-1 language of place names, such as "de".
+	Language string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	// Chooses between several matching places instead of failing: "first",
+	// a 1-based position such as "2", "most-populous" or
+	// "closest-to=LAT,LON".
+	Pick          string `protobuf:"bytes,6,opt,name=pick,proto3" json:"pick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LocationQuery) Reset() {
+	*x = LocationQuery{}
+	mi := &file_weather_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LocationQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LocationQuery) ProtoMessage() {}
+
+func (x *LocationQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LocationQuery.ProtoReflect.Descriptor instead.
+func (*LocationQuery) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LocationQuery) GetLocation() isLocationQuery_Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *LocationQuery) GetName() string {
+	if x != nil {
+		if x, ok := x.Location.(*LocationQuery_Name); ok {
+			return x.Name
+		}
+	}
+	return ""
+}
+
+func (x *LocationQuery) GetCoordinates() *Coordinates {
+	if x != nil {
+		if x, ok := x.Location.(*LocationQuery_Coordinates); ok {
+			return x.Coordinates
+		}
+	}
+	return nil
+}
+
+func (x *LocationQuery) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *LocationQuery) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *LocationQuery) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *LocationQuery) GetPick() string {
+	if x != nil {
+		return x.Pick
+	}
+	return ""
+}
+
+type isLocationQuery_Location interface {
+	isLocationQuery_Location()
+}
+
+type LocationQuery_Name struct {
+	// A place name, optionally qualified as in "Portland, OR, US", or a
+	// coordinate pair such as "52.52,13.41".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3,oneof"`
+}
+
+type LocationQuery_Coordinates struct {
+	Coordinates *Coordinates `protobuf:"bytes,2,opt,name=coordinates,proto3,oneof"`
+}
+
+func (*LocationQuery_Name) isLocationQuery_Location() {}
+
+func (*LocationQuery_Coordinates) isLocationQuery_Location() {}
+
+// Coordinates is a point in decimal degrees.
+type Coordinates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Coordinates) Reset() {
+	*x = Coordinates{}
+	mi := &file_weather_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Coordinates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Coordinates) ProtoMessage() {}
+
+func (x *Coordinates) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Coordinates.ProtoReflect.Descriptor instead.
+func (*Coordinates) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Coordinates) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Coordinates) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+// Units selects the units of the returned quantities. Empty fields use the
+// server's defaults.
+type Units struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "metric" or "imperial". The other fields override single units of the
+	// system.
+	System string `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	// "celsius" or "fahrenheit".
+	Temperature string `protobuf:"bytes,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	// "kmh", "ms", "mph" or "kn".
+	WindSpeed string `protobuf:"bytes,3,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	// "mm" or "inch".
+	Precipitation string `protobuf:"bytes,4,opt,name=precipitation,proto3" json:"precipitation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Units) Reset() {
+	*x = Units{}
+	mi := &file_weather_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Units) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Units) ProtoMessage() {}
+
+func (x *Units) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Units.ProtoReflect.Descriptor instead.
+func (*Units) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Units) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *Units) GetTemperature() string {
+	if x != nil {
+		return x.Temperature
+	}
+	return ""
+}
+
+func (x *Units) GetWindSpeed() string {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return ""
+}
+
+func (x *Units) GetPrecipitation() string {
+	if x != nil {
+		return x.Precipitation
+	}
+	return ""
+}
+
+// Measurement is a numeric value together with its unit. Values the
+// weather service did not report are left unset rather than sent as 0.
+type Measurement struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value float64                `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Such as "°C", "km/h" or "%".
+	Unit          string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Measurement) Reset() {
+	*x = Measurement{}
+	mi := &file_weather_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Measurement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Measurement) ProtoMessage() {}
+
+func (x *Measurement) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Measurement.ProtoReflect.Descriptor instead.
+func (*Measurement) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Measurement) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *Measurement) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// CurrentWeather is the weather observed at a location.
+type CurrentWeather struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Time                *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Temperature         *Measurement           `protobuf:"bytes,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	ApparentTemperature *Measurement           `protobuf:"bytes,3,opt,name=apparent_temperature,json=apparentTemperature,proto3" json:"apparent_temperature,omitempty"`
+	Humidity            *Measurement           `protobuf:"bytes,4,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	Precipitation       *Measurement           `protobuf:"bytes,5,opt,name=precipitation,proto3" json:"precipitation,omitempty"`
+	CloudCover          *Measurement           `protobuf:"bytes,6,opt,name=cloud_cover,json=cloudCover,proto3" json:"cloud_cover,omitempty"`
+	// Surface pressure.
+	Pressure  *Measurement `protobuf:"bytes,7,opt,name=pressure,proto3" json:"pressure,omitempty"`
+	WindSpeed *Measurement `protobuf:"bytes,8,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	// Direction the wind comes from, in degrees.
+	WindDirection *Measurement `protobuf:"bytes,9,opt,name=wind_direction,json=windDirection,proto3" json:"wind_direction,omitempty"`
+	WindGusts     *Measurement `protobuf:"bytes,10,opt,name=wind_gusts,json=windGusts,proto3" json:"wind_gusts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrentWeather) Reset() {
+	*x = CurrentWeather{}
+	mi := &file_weather_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrentWeather) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrentWeather) ProtoMessage() {}
+
+func (x *CurrentWeather) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrentWeather.ProtoReflect.Descriptor instead.
+func (*CurrentWeather) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CurrentWeather) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetTemperature() *Measurement {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetApparentTemperature() *Measurement {
+	if x != nil {
+		return x.ApparentTemperature
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetHumidity() *Measurement {
+	if x != nil {
+		return x.Humidity
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetPrecipitation() *Measurement {
+	if x != nil {
+		return x.Precipitation
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetCloudCover() *Measurement {
+	if x != nil {
+		return x.CloudCover
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetPressure() *Measurement {
+	if x != nil {
+		return x.Pressure
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetWindSpeed() *Measurement {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetWindDirection() *Measurement {
+	if x != nil {
+		return x.WindDirection
+	}
+	return nil
+}
+
+func (x *CurrentWeather) GetWindGusts() *Measurement {
+	if x != nil {
+		return x.WindGusts
+	}
+	return nil
+}
+
+// DailyForecast is the forecast for one day.
+type DailyForecast struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Day in the location's time zone, as YYYY-MM-DD.
+	Date             string       `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	TemperatureMin   *Measurement `protobuf:"bytes,2,opt,name=temperature_min,json=temperatureMin,proto3" json:"temperature_min,omitempty"`
+	TemperatureMax   *Measurement `protobuf:"bytes,3,opt,name=temperature_max,json=temperatureMax,proto3" json:"temperature_max,omitempty"`
+	PrecipitationSum *Measurement `protobuf:"bytes,4,opt,name=precipitation_sum,json=precipitationSum,proto3" json:"precipitation_sum,omitempty"`
+	WindSpeedMax     *Measurement `protobuf:"bytes,5,opt,name=wind_speed_max,json=windSpeedMax,proto3" json:"wind_speed_max,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DailyForecast) Reset() {
+	*x = DailyForecast{}
+	mi := &file_weather_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyForecast) ProtoMessage() {}
+
+func (x *DailyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyForecast.ProtoReflect.Descriptor instead.
+func (*DailyForecast) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DailyForecast) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DailyForecast) GetTemperatureMin() *Measurement {
+	if x != nil {
+		return x.TemperatureMin
+	}
+	return nil
+}
+
+func (x *DailyForecast) GetTemperatureMax() *Measurement {
+	if x != nil {
+		return x.TemperatureMax
+	}
+	return nil
+}
+
+func (x *DailyForecast) GetPrecipitationSum() *Measurement {
+	if x != nil {
+		return x.PrecipitationSum
+	}
+	return nil
+}
+
+func (x *DailyForecast) GetWindSpeedMax() *Measurement {
+	if x != nil {
+		return x.WindSpeedMax
+	}
+	return nil
+}
+
+// HourlyForecast is the forecast for one hour.
+type HourlyForecast struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Time                     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Temperature              *Measurement           `protobuf:"bytes,2,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	PrecipitationProbability *Measurement           `protobuf:"bytes,3,opt,name=precipitation_probability,json=precipitationProbability,proto3" json:"precipitation_probability,omitempty"`
+	WindSpeed                *Measurement           `protobuf:"bytes,4,opt,name=wind_speed,json=windSpeed,proto3" json:"wind_speed,omitempty"`
+	WindDirection            *Measurement           `protobuf:"bytes,5,opt,name=wind_direction,json=windDirection,proto3" json:"wind_direction,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *HourlyForecast) Reset() {
+	*x = HourlyForecast{}
+	mi := &file_weather_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HourlyForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HourlyForecast) ProtoMessage() {}
+
+func (x *HourlyForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HourlyForecast.ProtoReflect.Descriptor instead.
+func (*HourlyForecast) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *HourlyForecast) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *HourlyForecast) GetTemperature() *Measurement {
+	if x != nil {
+		return x.Temperature
+	}
+	return nil
+}
+
+func (x *HourlyForecast) GetPrecipitationProbability() *Measurement {
+	if x != nil {
+		return x.PrecipitationProbability
+	}
+	return nil
+}
+
+func (x *HourlyForecast) GetWindSpeed() *Measurement {
+	if x != nil {
+		return x.WindSpeed
+	}
+	return nil
+}
+
+func (x *HourlyForecast) GetWindDirection() *Measurement {
+	if x != nil {
+		return x.WindDirection
+	}
+	return nil
+}
+
+// AmbiguousLocation is attached to the FAILED_PRECONDITION error returned
+// when a name matches several locations and no pick chooses one.
+type AmbiguousLocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Candidates    []*Location            `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmbiguousLocation) Reset() {
+	*x = AmbiguousLocation{}
+	mi := &file_weather_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmbiguousLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmbiguousLocation) ProtoMessage() {}
+
+func (x *AmbiguousLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmbiguousLocation.ProtoReflect.Descriptor instead.
+func (*AmbiguousLocation) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AmbiguousLocation) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AmbiguousLocation) GetCandidates() []*Location {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+type SearchLocationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Maximum number of locations, 1-100. 0 uses the server's default.
+	Count         int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Country       string `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Region        string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Language      string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchLocationsRequest) Reset() {
+	*x = SearchLocationsRequest{}
+	mi := &file_weather_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLocationsRequest) ProtoMessage() {}
+
+func (x *SearchLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLocationsRequest.ProtoReflect.Descriptor instead.
+func (*SearchLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchLocationsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchLocationsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *SearchLocationsRequest) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SearchLocationsRequest) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *SearchLocationsRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type SearchLocationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Locations     []*Location            `protobuf:"bytes,1,rep,name=locations,proto3" json:"locations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchLocationsResponse) Reset() {
+	*x = SearchLocationsResponse{}
+	mi := &file_weather_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchLocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLocationsResponse) ProtoMessage() {}
+
+func (x *SearchLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLocationsResponse.ProtoReflect.Descriptor instead.
+func (*SearchLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchLocationsResponse) GetLocations() []*Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+type GetCurrentWeatherRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *LocationQuery         `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Units         *Units                 `protobuf:"bytes,2,opt,name=units,proto3" json:"units,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentWeatherRequest) Reset() {
+	*x = GetCurrentWeatherRequest{}
+	mi := &file_weather_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentWeatherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentWeatherRequest) ProtoMessage() {}
+
+func (x *GetCurrentWeatherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentWeatherRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentWeatherRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetCurrentWeatherRequest) GetLocation() *LocationQuery {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetCurrentWeatherRequest) GetUnits() *Units {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+type GetCurrentWeatherResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Current       *CurrentWeather        `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentWeatherResponse) Reset() {
+	*x = GetCurrentWeatherResponse{}
+	mi := &file_weather_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentWeatherResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentWeatherResponse) ProtoMessage() {}
+
+func (x *GetCurrentWeatherResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentWeatherResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentWeatherResponse) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetCurrentWeatherResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetCurrentWeatherResponse) GetCurrent() *CurrentWeather {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+type GetDailyForecastRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location *LocationQuery         `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Units    *Units                 `protobuf:"bytes,2,opt,name=units,proto3" json:"units,omitempty"`
+	// Number of days, 1-16. 0 means 7.
+	Days          int32 `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyForecastRequest) Reset() {
+	*x = GetDailyForecastRequest{}
+	mi := &file_weather_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyForecastRequest) ProtoMessage() {}
+
+func (x *GetDailyForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetDailyForecastRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetDailyForecastRequest) GetLocation() *LocationQuery {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetDailyForecastRequest) GetUnits() *Units {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *GetDailyForecastRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type GetDailyForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Days          []*DailyForecast       `protobuf:"bytes,2,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyForecastResponse) Reset() {
+	*x = GetDailyForecastResponse{}
+	mi := &file_weather_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyForecastResponse) ProtoMessage() {}
+
+func (x *GetDailyForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyForecastResponse.ProtoReflect.Descriptor instead.
+func (*GetDailyForecastResponse) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetDailyForecastResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetDailyForecastResponse) GetDays() []*DailyForecast {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type GetHourlyForecastRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location *LocationQuery         `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Units    *Units                 `protobuf:"bytes,2,opt,name=units,proto3" json:"units,omitempty"`
+	// Number of hours, 1-168. 0 means 24.
+	Hours         int32 `protobuf:"varint,3,opt,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHourlyForecastRequest) Reset() {
+	*x = GetHourlyForecastRequest{}
+	mi := &file_weather_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHourlyForecastRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHourlyForecastRequest) ProtoMessage() {}
+
+func (x *GetHourlyForecastRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHourlyForecastRequest.ProtoReflect.Descriptor instead.
+func (*GetHourlyForecastRequest) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetHourlyForecastRequest) GetLocation() *LocationQuery {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetHourlyForecastRequest) GetUnits() *Units {
+	if x != nil {
+		return x.Units
+	}
+	return nil
+}
+
+func (x *GetHourlyForecastRequest) GetHours() int32 {
+	if x != nil {
+		return x.Hours
+	}
+	return 0
+}
+
+type GetHourlyForecastResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Hours         []*HourlyForecast      `protobuf:"bytes,2,rep,name=hours,proto3" json:"hours,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHourlyForecastResponse) Reset() {
+	*x = GetHourlyForecastResponse{}
+	mi := &file_weather_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHourlyForecastResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHourlyForecastResponse) ProtoMessage() {}
+
+func (x *GetHourlyForecastResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_weather_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHourlyForecastResponse.ProtoReflect.Descriptor instead.
+func (*GetHourlyForecastResponse) Descriptor() ([]byte, []int) {
+	return file_weather_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetHourlyForecastResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *GetHourlyForecastResponse) GetHours() []*HourlyForecast {
+	if x != nil {
+		return x.Hours
+	}
+	return nil
+}
+
+var File_weather_proto protoreflect.FileDescriptor
+
+const file_weather_proto_rawDesc = "" +
+	"\n" +
+	"\rweather.proto\x12\x12weatherreporter.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd2\x03\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\blatitude\x18\x03 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x04 \x01(\x01R\tlongitude\x12\x18\n" +
+	"\acountry\x18\x05 \x01(\tR\acountry\x12!\n" +
+	"\fcountry_code\x18\x06 \x01(\tR\vcountryCode\x12\x16\n" +
+	"\x06admin1\x18\a \x01(\tR\x06admin1\x12\x16\n" +
+	"\x06admin2\x18\b \x01(\tR\x06admin2\x12\x16\n" +
+	"\x06admin3\x18\t \x01(\tR\x06admin3\x12\x16\n" +
+	"\x06admin4\x18\n" +
+	" \x01(\tR\x06admin4\x12\x1a\n" +
+	"\btimezone\x18\v \x01(\tR\btimezone\x12\x1c\n" +
+	"\televation\x18\f \x01(\x01R\televation\x12\x1e\n" +
+	"\n" +
+	"population\x18\r \x01(\x03R\n" +
+	"population\x12\x1c\n" +
+	"\tpostcodes\x18\x0e \x03(\tR\tpostcodes\x12!\n" +
+	"\ffeature_code\x18\x0f \x01(\tR\vfeatureCode\x120\n" +
+	"\x04near\x18\x10 \x01(\v2\x1c.weatherreporter.v1.LocationR\x04near\"\xd8\x01\n" +
+	"\rLocationQuery\x12\x14\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x12C\n" +
+	"\vcoordinates\x18\x02 \x01(\v2\x1f.weatherreporter.v1.CoordinatesH\x00R\vcoordinates\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12\x12\n" +
+	"\x04pick\x18\x06 \x01(\tR\x04pickB\n" +
+	"\n" +
+	"\blocation\"G\n" +
+	"\vCoordinates\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\"\x86\x01\n" +
+	"\x05Units\x12\x16\n" +
+	"\x06system\x18\x01 \x01(\tR\x06system\x12 \n" +
+	"\vtemperature\x18\x02 \x01(\tR\vtemperature\x12\x1d\n" +
+	"\n" +
+	"wind_speed\x18\x03 \x01(\tR\twindSpeed\x12$\n" +
+	"\rprecipitation\x18\x04 \x01(\tR\rprecipitation\"7\n" +
+	"\vMeasurement\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x01R\x05value\x12\x12\n" +
+	"\x04unit\x18\x02 \x01(\tR\x04unit\"\xa2\x05\n" +
+	"\x0eCurrentWeather\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12A\n" +
+	"\vtemperature\x18\x02 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\vtemperature\x12R\n" +
+	"\x14apparent_temperature\x18\x03 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\x13apparentTemperature\x12;\n" +
+	"\bhumidity\x18\x04 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\bhumidity\x12E\n" +
+	"\rprecipitation\x18\x05 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\rprecipitation\x12@\n" +
+	"\vcloud_cover\x18\x06 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\n" +
+	"cloudCover\x12;\n" +
+	"\bpressure\x18\a \x01(\v2\x1f.weatherreporter.v1.MeasurementR\bpressure\x12>\n" +
+	"\n" +
+	"wind_speed\x18\b \x01(\v2\x1f.weatherreporter.v1.MeasurementR\twindSpeed\x12F\n" +
+	"\x0ewind_direction\x18\t \x01(\v2\x1f.weatherreporter.v1.MeasurementR\rwindDirection\x12>\n" +
+	"\n" +
+	"wind_gusts\x18\n" +
+	" \x01(\v2\x1f.weatherreporter.v1.MeasurementR\twindGusts\"\xcc\x02\n" +
+	"\rDailyForecast\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12H\n" +
+	"\x0ftemperature_min\x18\x02 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\x0etemperatureMin\x12H\n" +
+	"\x0ftemperature_max\x18\x03 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\x0etemperatureMax\x12L\n" +
+	"\x11precipitation_sum\x18\x04 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\x10precipitationSum\x12E\n" +
+	"\x0ewind_speed_max\x18\x05 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\fwindSpeedMax\"\xe9\x02\n" +
+	"\x0eHourlyForecast\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12A\n" +
+	"\vtemperature\x18\x02 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\vtemperature\x12\\\n" +
+	"\x19precipitation_probability\x18\x03 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\x18precipitationProbability\x12>\n" +
+	"\n" +
+	"wind_speed\x18\x04 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\twindSpeed\x12F\n" +
+	"\x0ewind_direction\x18\x05 \x01(\v2\x1f.weatherreporter.v1.MeasurementR\rwindDirection\"g\n" +
+	"\x11AmbiguousLocation\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12<\n" +
+	"\n" +
+	"candidates\x18\x02 \x03(\v2\x1c.weatherreporter.v1.LocationR\n" +
+	"candidates\"\x90\x01\n" +
+	"\x16SearchLocationsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\"U\n" +
+	"\x17SearchLocationsResponse\x12:\n" +
+	"\tlocations\x18\x01 \x03(\v2\x1c.weatherreporter.v1.LocationR\tlocations\"\x8a\x01\n" +
+	"\x18GetCurrentWeatherRequest\x12=\n" +
+	"\blocation\x18\x01 \x01(\v2!.weatherreporter.v1.LocationQueryR\blocation\x12/\n" +
+	"\x05units\x18\x02 \x01(\v2\x19.weatherreporter.v1.UnitsR\x05units\"\x93\x01\n" +
+	"\x19GetCurrentWeatherResponse\x128\n" +
+	"\blocation\x18\x01 \x01(\v2\x1c.weatherreporter.v1.LocationR\blocation\x12<\n" +
+	"\acurrent\x18\x02 \x01(\v2\".weatherreporter.v1.CurrentWeatherR\acurrent\"\x9d\x01\n" +
+	"\x17GetDailyForecastRequest\x12=\n" +
+	"\blocation\x18\x01 \x01(\v2!.weatherreporter.v1.LocationQueryR\blocation\x12/\n" +
+	"\x05units\x18\x02 \x01(\v2\x19.weatherreporter.v1.UnitsR\x05units\x12\x12\n" +
+	"\x04days\x18\x03 \x01(\x05R\x04days\"\x8b\x01\n" +
+	"\x18GetDailyForecastResponse\x128\n" +
+	"\blocation\x18\x01 \x01(\v2\x1c.weatherreporter.v1.LocationR\blocation\x125\n" +
+	"\x04days\x18\x02 \x03(\v2!.weatherreporter.v1.DailyForecastR\x04days\"\xa0\x01\n" +
+	"\x18GetHourlyForecastRequest\x12=\n" +
+	"\blocation\x18\x01 \x01(\v2!.weatherreporter.v1.LocationQueryR\blocation\x12/\n" +
+	"\x05units\x18\x02 \x01(\v2\x19.weatherreporter.v1.UnitsR\x05units\x12\x14\n" +
+	"\x05hours\x18\x03 \x01(\x05R\x05hours\"\x8f\x01\n" +
+	"\x19GetHourlyForecastResponse\x128\n" +
+	"\blocation\x18\x01 \x01(\v2\x1c.weatherreporter.v1.LocationR\blocation\x128\n" +
+	"\x05hours\x18\x02 \x03(\v2\".weatherreporter.v1.HourlyForecastR\x05hours2\xd0\x03\n" +
+	"\x0fWeatherReporter\x12j\n" +
+	"\x0fSearchLocations\x12*.weatherreporter.v1.SearchLocationsRequest\x1a+.weatherreporter.v1.SearchLocationsResponse\x12p\n" +
+	"\x11GetCurrentWeather\x12,.weatherreporter.v1.GetCurrentWeatherRequest\x1a-.weatherreporter.v1.GetCurrentWeatherResponse\x12m\n" +
+	"\x10GetDailyForecast\x12+.weatherreporter.v1.GetDailyForecastRequest\x1a,.weatherreporter.v1.GetDailyForecastResponse\x12p\n" +
+	"\x11GetHourlyForecast\x12,.weatherreporter.v1.GetHourlyForecastRequest\x1a-.weatherreporter.v1.GetHourlyForecastResponseB1Z/weather-reporter/src/internal/grpcapi/weatherpbb\x06proto3"
+
+var (
+	file_weather_proto_rawDescOnce sync.Once
+	file_weather_proto_rawDescData []byte
+)
+
+func file_weather_proto_rawDescGZIP() []byte {
+	file_weather_proto_rawDescOnce.Do(func() {
+		file_weather_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_weather_proto_rawDesc), len(file_weather_proto_rawDesc)))
+	})
+	return file_weather_proto_rawDescData
+}
+
+var file_weather_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_weather_proto_goTypes = []any{
+	(*Location)(nil),                  // 0: weatherreporter.v1.Location
+	(*LocationQuery)(nil),             // 1: weatherreporter.v1.LocationQuery
+	(*Coordinates)(nil),               // 2: weatherreporter.v1.Coordinates
+	(*Units)(nil),                     // 3: weatherreporter.v1.Units
+	(*Measurement)(nil),               // 4: weatherreporter.v1.Measurement
+	(*CurrentWeather)(nil),            // 5: weatherreporter.v1.CurrentWeather
+	(*DailyForecast)(nil),             // 6: weatherreporter.v1.DailyForecast
+	(*HourlyForecast)(nil),            // 7: weatherreporter.v1.HourlyForecast
+	(*AmbiguousLocation)(nil),         // 8: weatherreporter.v1.AmbiguousLocation
+	(*SearchLocationsRequest)(nil),    // 9: weatherreporter.v1.SearchLocationsRequest
+	(*SearchLocationsResponse)(nil),   // 10: weatherreporter.v1.SearchLocationsResponse
+	(*GetCurrentWeatherRequest)(nil),  // 11: weatherreporter.v1.GetCurrentWeatherRequest
+	(*GetCurrentWeatherResponse)(nil), // 12: weatherreporter.v1.GetCurrentWeatherResponse
+	(*GetDailyForecastRequest)(nil),   // 13: weatherreporter.v1.GetDailyForecastRequest
+	(*GetDailyForecastResponse)(nil),  // 14: weatherreporter.v1.GetDailyForecastResponse
+	(*GetHourlyForecastRequest)(nil),  // 15: weatherreporter.v1.GetHourlyForecastRequest
+	(*GetHourlyForecastResponse)(nil), // 16: weatherreporter.v1.GetHourlyForecastResponse
+	(*timestamppb.Timestamp)(nil),     // 17: google.protobuf.Timestamp
+}
+var file_weather_proto_depIdxs = []int32{
+	0,  // 0: weatherreporter.v1.Location.near:type_name -> weatherreporter.v1.Location
+	2,  // 1: weatherreporter.v1.LocationQuery.coordinates:type_name -> weatherreporter.v1.Coordinates
+	17, // 2: weatherreporter.v1.CurrentWeather.time:type_name -> google.protobuf.Timestamp
+	4,  // 3: weatherreporter.v1.CurrentWeather.temperature:type_name -> weatherreporter.v1.Measurement
+	4,  // 4: weatherreporter.v1.CurrentWeather.apparent_temperature:type_name -> weatherreporter.v1.Measurement
+	4,  // 5: weatherreporter.v1.CurrentWeather.humidity:type_name -> weatherreporter.v1.Measurement
+	4,  // 6: weatherreporter.v1.CurrentWeather.precipitation:type_name -> weatherreporter.v1.Measurement
+	4,  // 7: weatherreporter.v1.CurrentWeather.cloud_cover:type_name -> weatherreporter.v1.Measurement
+	4,  // 8: weatherreporter.v1.CurrentWeather.pressure:type_name -> weatherreporter.v1.Measurement
+	4,  // 9: weatherreporter.v1.CurrentWeather.wind_speed:type_name -> weatherreporter.v1.Measurement
+	4,  // 10: weatherreporter.v1.CurrentWeather.wind_direction:type_name -> weatherreporter.v1.Measurement
+	4,  // 11: weatherreporter.v1.CurrentWeather.wind_gusts:type_name -> weatherreporter.v1.Measurement
+	4,  // 12: weatherreporter.v1.DailyForecast.temperature_min:type_name -> weatherreporter.v1.Measurement
+	4,  // 13: weatherreporter.v1.DailyForecast.temperature_max:type_name -> weatherreporter.v1.Measurement
+	4,  // 14: weatherreporter.v1.DailyForecast.precipitation_sum:type_name -> weatherreporter.v1.Measurement
+	4,  // 15: weatherreporter.v1.DailyForecast.wind_speed_max:type_name -> weatherreporter.v1.Measurement
+	17, // 16: weatherreporter.v1.HourlyForecast.time:type_name -> google.protobuf.Timestamp
+	4,  // 17: weatherreporter.v1.HourlyForecast.temperature:type_name -> weatherreporter.v1.Measurement
+	4,  // 18: weatherreporter.v1.HourlyForecast.precipitation_probability:type_name -> weatherreporter.v1.Measurement
+	4,  // 19: weatherreporter.v1.HourlyForecast.wind_speed:type_name -> weatherreporter.v1.Measurement
+	4,  // 20: weatherreporter.v1.HourlyForecast.wind_direction:type_name -> weatherreporter.v1.Measurement
+	0,  // 21: weatherreporter.v1.AmbiguousLocation.candidates:type_name -> weatherreporter.v1.Location
+	0,  // 22: weatherreporter.v1.SearchLocationsResponse.locations:type_name -> weatherreporter.v1.Location
+	1,  // 23: weatherreporter.v1.GetCurrentWeatherRequest.location:type_name -> weatherreporter.v1.LocationQuery
+	3,  // 24: weatherreporter.v1.GetCurrentWeatherRequest.units:type_name -> weatherreporter.v1.Units
+	0,  // 25: weatherreporter.v1.GetCurrentWeatherResponse.location:type_name -> weatherreporter.v1.Location
+	5,  // 26: weatherreporter.v1.GetCurrentWeatherResponse.current:type_name -> weatherreporter.v1.CurrentWeather
+	1,  // 27: weatherreporter.v1.GetDailyForecastRequest.location:type_name -> weatherreporter.v1.LocationQuery
+	3,  // 28: weatherreporter.v1.GetDailyForecastRequest.units:type_name -> weatherreporter.v1.Units
+	0,  // 29: weatherreporter.v1.GetDailyForecastResponse.location:type_name -> weatherreporter.v1.Location
+	6,  // 30: weatherreporter.v1.GetDailyForecastResponse.days:type_name -> weatherreporter.v1.DailyForecast
+	1,  // 31: weatherreporter.v1.GetHourlyForecastRequest.location:type_name -> weatherreporter.v1.LocationQuery
+	3,  // 32: weatherreporter.v1.GetHourlyForecastRequest.units:type_name -> weatherreporter.v1.Units
+	0,  // 33: weatherreporter.v1.GetHourlyForecastResponse.location:type_name -> weatherreporter.v1.Location
+	7,  // 34: weatherreporter.v1.GetHourlyForecastResponse.hours:type_name -> weatherreporter.v1.HourlyForecast
+	9,  // 35: weatherreporter.v1.WeatherReporter.SearchLocations:input_type -> weatherreporter.v1.SearchLocationsRequest
+	11, // 36: weatherreporter.v1.WeatherReporter.GetCurrentWeather:input_type -> weatherreporter.v1.GetCurrentWeatherRequest
+	13, // 37: weatherreporter.v1.WeatherReporter.GetDailyForecast:input_type -> weatherreporter.v1.GetDailyForecastRequest
+	15, // 38: weatherreporter.v1.WeatherReporter.GetHourlyForecast:input_type -> weatherreporter.v1.GetHourlyForecastRequest
+	10, // 39: weatherreporter.v1.WeatherReporter.SearchLocations:output_type -> weatherreporter.v1.SearchLocationsResponse
+	12, // 40: weatherreporter.v1.WeatherReporter.GetCurrentWeather:output_type -> weatherreporter.v1.GetCurrentWeatherResponse
+	14, // 41: weatherreporter.v1.WeatherReporter.GetDailyForecast:output_type -> weatherreporter.v1.GetDailyForecastResponse
+	16, // 42: weatherreporter.v1.WeatherReporter.GetHourlyForecast:output_type -> weatherreporter.v1.GetHourlyForecastResponse
+	39, // [39:43] is the sub-list for method output_type
+	35, // [35:39] is the sub-list for method input_type
+	35, // [35:35] is the sub-list for extension type_name
+	35, // [35:35] is the sub-list for extension extendee
+	0,  // [0:35] is the sub-list for field type_name
+}
+
+func init() { file_weather_proto_init() }
+func file_weather_proto_init() {
+	if File_weather_proto != nil {
+		return
+	}
+	file_weather_proto_msgTypes[1].OneofWrappers = []any{
+		(*LocationQuery_Name)(nil),
+		(*LocationQuery_Coordinates)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_weather_proto_rawDesc), len(file_weather_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_weather_proto_goTypes,
+		DependencyIndexes: file_weather_proto_depIdxs,
+		MessageInfos:      file_weather_proto_msgTypes,
+	}.Build()
+	File_weather_proto = out.File
+	file_weather_proto_goTypes = nil
+	file_weather_proto_depIdxs = nil
+}
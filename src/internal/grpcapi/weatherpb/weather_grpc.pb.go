@@ -0,0 +1,256 @@
+// The WeatherReporter service searches locations and fetches current
+// weather and forecasts, like the weather-reporter command line.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: weather.proto
+
+package weatherpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WeatherReporter_SearchLocations_FullMethodName   = "/weatherreporter.v1.WeatherReporter/SearchLocations"
+	WeatherReporter_GetCurrentWeather_FullMethodName = "/weatherreporter.v1.WeatherReporter/GetCurrentWeather"
+	WeatherReporter_GetDailyForecast_FullMethodName  = "/weatherreporter.v1.WeatherReporter/GetDailyForecast"
+	WeatherReporter_GetHourlyForecast_FullMethodName = "/weatherreporter.v1.WeatherReporter/GetHourlyForecast"
+)
+
+// WeatherReporterClient is the client API for WeatherReporter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// WeatherReporter is served by "weather-reporter serve --grpc-addr".
+type WeatherReporterClient interface {
+	// SearchLocations lists the locations matching a name.
+	SearchLocations(ctx context.Context, in *SearchLocationsRequest, opts ...grpc.CallOption) (*SearchLocationsResponse, error)
+	// GetCurrentWeather returns the current weather at a location. A name
+	// matching several locations fails with FAILED_PRECONDITION and an
+	// AmbiguousLocation detail listing the candidates.
+	GetCurrentWeather(ctx context.Context, in *GetCurrentWeatherRequest, opts ...grpc.CallOption) (*GetCurrentWeatherResponse, error)
+	// GetDailyForecast returns a per-day forecast, starting today.
+	GetDailyForecast(ctx context.Context, in *GetDailyForecastRequest, opts ...grpc.CallOption) (*GetDailyForecastResponse, error)
+	// GetHourlyForecast returns an hour-by-hour forecast, starting at the
+	// current hour.
+	GetHourlyForecast(ctx context.Context, in *GetHourlyForecastRequest, opts ...grpc.CallOption) (*GetHourlyForecastResponse, error)
+}
+
+type weatherReporterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWeatherReporterClient(cc grpc.ClientConnInterface) WeatherReporterClient {
+	return &weatherReporterClient{cc}
+}
+
+func (c *weatherReporterClient) SearchLocations(ctx context.Context, in *SearchLocationsRequest, opts ...grpc.CallOption) (*SearchLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchLocationsResponse)
+	err := c.cc.Invoke(ctx, WeatherReporter_SearchLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weatherReporterClient) GetCurrentWeather(ctx context.Context, in *GetCurrentWeatherRequest, opts ...grpc.CallOption) (*GetCurrentWeatherResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCurrentWeatherResponse)
+	err := c.cc.Invoke(ctx, WeatherReporter_GetCurrentWeather_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weatherReporterClient) GetDailyForecast(ctx context.Context, in *GetDailyForecastRequest, opts ...grpc.CallOption) (*GetDailyForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDailyForecastResponse)
+	err := c.cc.Invoke(ctx, WeatherReporter_GetDailyForecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *weatherReporterClient) GetHourlyForecast(ctx context.Context, in *GetHourlyForecastRequest, opts ...grpc.CallOption) (*GetHourlyForecastResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHourlyForecastResponse)
+	err := c.cc.Invoke(ctx, WeatherReporter_GetHourlyForecast_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WeatherReporterServer is the server API for WeatherReporter service.
+// All implementations must embed UnimplementedWeatherReporterServer
+// for forward compatibility.
+//
+// WeatherReporter is served by "weather-reporter serve --grpc-addr".
+type WeatherReporterServer interface {
+	// SearchLocations lists the locations matching a name.
+	SearchLocations(context.Context, *SearchLocationsRequest) (*SearchLocationsResponse, error)
+	// GetCurrentWeather returns the current weather at a location. A name
+	// matching several locations fails with FAILED_PRECONDITION and an
+	// AmbiguousLocation detail listing the candidates.
+	GetCurrentWeather(context.Context, *GetCurrentWeatherRequest) (*GetCurrentWeatherResponse, error)
+	// GetDailyForecast returns a per-day forecast, starting today.
+	GetDailyForecast(context.Context, *GetDailyForecastRequest) (*GetDailyForecastResponse, error)
+	// GetHourlyForecast returns an hour-by-hour forecast, starting at the
+	// current hour.
+	GetHourlyForecast(context.Context, *GetHourlyForecastRequest) (*GetHourlyForecastResponse, error)
+	mustEmbedUnimplementedWeatherReporterServer()
+}
+
+// UnimplementedWeatherReporterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWeatherReporterServer struct{}
+
+func (UnimplementedWeatherReporterServer) SearchLocations(context.Context, *SearchLocationsRequest) (*SearchLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchLocations not implemented")
+}
+func (UnimplementedWeatherReporterServer) GetCurrentWeather(context.Context, *GetCurrentWeatherRequest) (*GetCurrentWeatherResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentWeather not implemented")
+}
+func (UnimplementedWeatherReporterServer) GetDailyForecast(context.Context, *GetDailyForecastRequest) (*GetDailyForecastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyForecast not implemented")
+}
+func (UnimplementedWeatherReporterServer) GetHourlyForecast(context.Context, *GetHourlyForecastRequest) (*GetHourlyForecastResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHourlyForecast not implemented")
+}
+func (UnimplementedWeatherReporterServer) mustEmbedUnimplementedWeatherReporterServer() {}
+func (UnimplementedWeatherReporterServer) testEmbeddedByValue()                         {}
+
+// UnsafeWeatherReporterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WeatherReporterServer will
+// result in compilation errors.
+type UnsafeWeatherReporterServer interface {
+	mustEmbedUnimplementedWeatherReporterServer()
+}
+
+func RegisterWeatherReporterServer(s grpc.ServiceRegistrar, srv WeatherReporterServer) {
+	// If the following call pancis, it indicates UnimplementedWeatherReporterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WeatherReporter_ServiceDesc, srv)
+}
+
+func _WeatherReporter_SearchLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherReporterServer).SearchLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeatherReporter_SearchLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherReporterServer).SearchLocations(ctx, req.(*SearchLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeatherReporter_GetCurrentWeather_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCurrentWeatherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherReporterServer).GetCurrentWeather(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeatherReporter_GetCurrentWeather_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherReporterServer).GetCurrentWeather(ctx, req.(*GetCurrentWeatherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeatherReporter_GetDailyForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDailyForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherReporterServer).GetDailyForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeatherReporter_GetDailyForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherReporterServer).GetDailyForecast(ctx, req.(*GetDailyForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WeatherReporter_GetHourlyForecast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHourlyForecastRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WeatherReporterServer).GetHourlyForecast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WeatherReporter_GetHourlyForecast_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WeatherReporterServer).GetHourlyForecast(ctx, req.(*GetHourlyForecastRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WeatherReporter_ServiceDesc is the grpc.ServiceDesc for WeatherReporter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WeatherReporter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "weatherreporter.v1.WeatherReporter",
+	HandlerType: (*WeatherReporterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchLocations",
+			Handler:    _WeatherReporter_SearchLocations_Handler,
+		},
+		{
+			MethodName: "GetCurrentWeather",
+			Handler:    _WeatherReporter_GetCurrentWeather_Handler,
+		},
+		{
+			MethodName: "GetDailyForecast",
+			Handler:    _WeatherReporter_GetDailyForecast_Handler,
+		},
+		{
+			MethodName: "GetHourlyForecast",
+			Handler:    _WeatherReporter_GetHourlyForecast_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "weather.proto",
+}
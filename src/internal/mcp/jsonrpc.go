@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// message is a JSON-RPC request, notification or response. Notifications
+// have no ID, and responses sent by the client have no method.
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// isNotification reports whether m expects no response.
+func (m *message) isNotification() bool {
+	return len(m.ID) == 0
+}
+
+// response is a JSON-RPC response. Exactly one of Result and Error is set.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error returns the error message.
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+func newError(code int, format string, args ...any) *rpcError {
+	return &rpcError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// nullID identifies the response to a message whose ID could not be read.
+var nullID = json.RawMessage("null")
+
+// unmarshalParams decodes the params of a request into v. Missing params
+// leave v unchanged.
+func unmarshalParams(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return nil
+	}
+	return json.Unmarshal(params, v)
+}
@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+)
+
+// schemaFS holds the JSON schemas of the tools' arguments and results.
+// defs.json holds the definitions shared by the result schemas.
+//
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// inputSchema returns the schema of an object with the properties of all
+// the named schemas, which must all describe objects. Fields required by
+// any of them are required.
+func inputSchema(names ...string) json.RawMessage {
+	schema := loadSchema(names[0])
+	props, _ := schema["properties"].(map[string]any)
+	required, _ := schema["required"].([]any)
+	for _, name := range names[1:] {
+		more := loadSchema(name)
+		extraProps, _ := more["properties"].(map[string]any)
+		for k, v := range extraProps {
+			props[k] = v
+		}
+		extraRequired, _ := more["required"].([]any)
+		required = append(required, extraRequired...)
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return mustMarshal(schema)
+}
+
+// outputSchema returns the named schema, together with the shared
+// definitions it refers to.
+func outputSchema(name string) json.RawMessage {
+	schema := loadSchema(name)
+	schema["$defs"] = loadSchema("defs")
+	return mustMarshal(schema)
+}
+
+// loadSchema reads schemas/name.json. The schemas are part of the binary,
+// so failing to read one is a bug.
+func loadSchema(name string) map[string]any {
+	data, err := schemaFS.ReadFile("schemas/" + name + ".json")
+	if err != nil {
+		panic(fmt.Sprintf("mcp: schema %s: %v", name, err))
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		panic(fmt.Sprintf("mcp: schema %s: %v", name, err))
+	}
+	return schema
+}
+
+func mustMarshal(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("mcp: %v", err))
+	}
+	return data
+}
@@ -0,0 +1,214 @@
+// Package mcp serves the geocoding and weather services as tools for AI
+// assistants over the Model Context Protocol. Messages are exchanged as
+// JSON-RPC 2.0, one per line, as in the protocol's stdio transport.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+)
+
+// ProtocolVersion is the latest revision of the Model Context Protocol the
+// server implements.
+const ProtocolVersion = "2025-06-18"
+
+// supportedVersions are the protocol revisions a client may ask for. The
+// tools only use features common to all of them.
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// maxMessageSize bounds the length of a message from the client.
+const maxMessageSize = 1 << 20
+
+// Options configure the server.
+type Options struct {
+	// Geocoder searches locations by name.
+	Geocoder models.GeocodingService
+	// Reverse, if set, names the place nearest to requested coordinates.
+	Reverse models.ReverseGeocodingService
+	// Weather fetches current weather and forecasts.
+	Weather models.WeatherService
+	// Units are used when a tool call does not select any. The zero value
+	// means metric units.
+	Units models.Units
+	// RequestTimeout, if positive, bounds each tool call.
+	RequestTimeout time.Duration
+	// Version is reported to clients as the version of the server.
+	Version string
+	// ErrorLog, if set, receives the cause of every failed tool call.
+	ErrorLog io.Writer
+}
+
+// Server answers MCP requests.
+type Server struct {
+	opts     Options
+	resolver geo.Resolver
+	tools    []tool
+}
+
+// New returns a server offering the search_locations, get_current_weather
+// and get_forecast tools.
+func New(opts Options) *Server {
+	s := &Server{
+		opts:     opts,
+		resolver: geo.Resolver{Geocoder: opts.Geocoder, Reverse: opts.Reverse},
+	}
+	s.tools = s.newTools()
+	return s
+}
+
+// Serve reads messages from in and writes the responses to out until in
+// is exhausted or ctx is done. Requests are answered in the order they
+// arrive.
+func (s *Server) Serve(ctx context.Context, in io.Reader, out io.Writer) error {
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxMessageSize)
+		for scanner.Scan() {
+			select {
+			case lines <- bytes.Clone(scanner.Bytes()):
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-readErr:
+			return err
+		case line := <-lines:
+			resp := s.handleLine(ctx, line)
+			if resp == nil {
+				continue
+			}
+			data, err := json.Marshal(resp)
+			if err != nil {
+				return err
+			}
+			if _, err := out.Write(append(data, '\n')); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleLine handles one message and returns the response, or nil for
+// notifications, responses and blank lines.
+func (s *Server) handleLine(ctx context.Context, line []byte) *response {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return nil
+	}
+	if line[0] == '[' {
+		return &response{JSONRPC: "2.0", ID: nullID, Error: newError(codeInvalidRequest, "batches are not supported")}
+	}
+	var msg message
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return &response{JSONRPC: "2.0", ID: nullID, Error: newError(codeParseError, "invalid JSON: %v", err)}
+	}
+	if msg.Method == "" {
+		// A response to a request of ours; the server sends none.
+		return nil
+	}
+	if msg.isNotification() {
+		// Notifications such as notifications/initialized need no action.
+		return nil
+	}
+
+	resp := &response{JSONRPC: "2.0", ID: msg.ID}
+	result, err := s.handle(ctx, &msg)
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = newError(codeInternalError, "%v", err)
+		}
+		resp.Error = rpcErr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+// handle dispatches a request to its method.
+func (s *Server) handle(ctx context.Context, msg *message) (any, error) {
+	if msg.JSONRPC != "2.0" {
+		return nil, newError(codeInvalidRequest, "jsonrpc must be \"2.0\"")
+	}
+	switch msg.Method {
+	case "initialize":
+		return s.initialize(msg.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return toolList{Tools: s.tools}, nil
+	case "tools/call":
+		return s.callTool(ctx, msg.Params)
+	}
+	return nil, newError(codeMethodNotFound, "method not found: %s", msg.Method)
+}
+
+type initializeParams struct {
+	ProtocolVersion string `json:"protocolVersion"`
+}
+
+type initializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    serverCapabilities `json:"capabilities"`
+	ServerInfo      implementation     `json:"serverInfo"`
+	Instructions    string             `json:"instructions,omitempty"`
+}
+
+type serverCapabilities struct {
+	Tools struct {
+		ListChanged bool `json:"listChanged"`
+	} `json:"tools"`
+}
+
+type implementation struct {
+	Name    string `json:"name"`
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version"`
+}
+
+// instructions tell the client's model how the tools fit together.
+const instructions = "Use get_current_weather and get_forecast with a place name or coordinates. " +
+	"If a name matches several places, the call fails with the candidates; repeat it with a region, " +
+	"a country, a pick or the coordinates of the intended place. search_locations lists the places " +
+	"matching a name."
+
+// initialize answers the initialize request, agreeing on the client's
+// protocol version if the server supports it and offering the latest one
+// otherwise.
+func (s *Server) initialize(params json.RawMessage) (any, error) {
+	var p initializeParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, newError(codeInvalidParams, "invalid initialize params: %v", err)
+	}
+	version := ProtocolVersion
+	if slices.Contains(supportedVersions, p.ProtocolVersion) {
+		version = p.ProtocolVersion
+	}
+	serverVersion := s.opts.Version
+	if serverVersion == "" {
+		serverVersion = "dev"
+	}
+	return initializeResult{
+		ProtocolVersion: version,
+		ServerInfo:      implementation{Name: "weather-reporter", Title: "Weather Reporter", Version: serverVersion},
+		Instructions:    instructions,
+	}, nil
+}
@@ -0,0 +1,174 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testResponse is a JSON-RPC response as seen by a client.
+type testResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// session sends the scripted messages, one per line, and returns the
+// responses the server writes before the input ends.
+func session(t *testing.T, s *Server, messages ...string) []testResponse {
+	t.Helper()
+	var out bytes.Buffer
+	in := strings.NewReader(strings.Join(messages, "\n") + "\n")
+	require.NoError(t, s.Serve(context.Background(), in, &out))
+
+	var responses []testResponse
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var resp testResponse
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &resp), scanner.Text())
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+const initialize = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1.0"}}}`
+
+func TestServe_Initialize(t *testing.T) {
+	responses := session(t, New(Options{Version: "1.2.3"}),
+		initialize,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":"two","method":"ping"}`,
+	)
+	require.Len(t, responses, 2, "notifications are not answered")
+
+	assert.JSONEq(t, `1`, string(responses[0].ID))
+	var result struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		Capabilities    struct {
+			Tools map[string]any `json:"tools"`
+		} `json:"capabilities"`
+		ServerInfo struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"serverInfo"`
+		Instructions string `json:"instructions"`
+	}
+	require.NoError(t, json.Unmarshal(responses[0].Result, &result))
+	assert.Equal(t, "2025-06-18", result.ProtocolVersion)
+	assert.NotNil(t, result.Capabilities.Tools)
+	assert.Equal(t, "weather-reporter", result.ServerInfo.Name)
+	assert.Equal(t, "1.2.3", result.ServerInfo.Version)
+	assert.Contains(t, result.Instructions, "get_current_weather")
+
+	assert.JSONEq(t, `"two"`, string(responses[1].ID))
+	assert.JSONEq(t, `{}`, string(responses[1].Result))
+}
+
+func TestServe_ProtocolVersion(t *testing.T) {
+	tests := []struct {
+		requested, want string
+	}{
+		{"2024-11-05", "2024-11-05"},
+		{"2025-03-26", "2025-03-26"},
+		{"2099-01-01", ProtocolVersion},
+	}
+	for _, tt := range tests {
+		responses := session(t, New(Options{}),
+			`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"`+tt.requested+`"}}`)
+		require.Len(t, responses, 1)
+		var result struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		require.NoError(t, json.Unmarshal(responses[0].Result, &result))
+		assert.Equal(t, tt.want, result.ProtocolVersion, tt.requested)
+	}
+}
+
+func TestServe_ListTools(t *testing.T) {
+	responses := session(t, New(Options{}), initialize, `{"jsonrpc":"2.0","id":2,"method":"tools/list"}`)
+	require.Len(t, responses, 2)
+
+	var result struct {
+		Tools []struct {
+			Name         string         `json:"name"`
+			Description  string         `json:"description"`
+			InputSchema  map[string]any `json:"inputSchema"`
+			OutputSchema map[string]any `json:"outputSchema"`
+			Annotations  struct {
+				ReadOnlyHint bool `json:"readOnlyHint"`
+			} `json:"annotations"`
+		} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(responses[1].Result, &result))
+	var names []string
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+		assert.NotEmpty(t, tool.Description, tool.Name)
+		assert.Equal(t, "object", tool.InputSchema["type"], tool.Name)
+		assert.Equal(t, "object", tool.OutputSchema["type"], tool.Name)
+		assert.Contains(t, tool.OutputSchema["$defs"], "location", tool.Name)
+		assert.True(t, tool.Annotations.ReadOnlyHint, tool.Name)
+	}
+	assert.Equal(t, []string{"search_locations", "get_current_weather", "get_forecast"}, names)
+}
+
+func TestServe_ForecastInputSchema(t *testing.T) {
+	var schema struct {
+		Properties map[string]any `json:"properties"`
+		Required   []string       `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal(inputSchema("location_query", "get_forecast.input"), &schema))
+
+	assert.Contains(t, schema.Properties, "location")
+	assert.Contains(t, schema.Properties, "pick")
+	assert.Contains(t, schema.Properties, "days")
+	assert.Contains(t, schema.Properties, "granularity")
+	assert.Equal(t, []string{"location"}, schema.Required)
+}
+
+func TestServe_Errors(t *testing.T) {
+	responses := session(t, New(Options{}),
+		`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"get_horoscope"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":"Berlin"}`,
+		`{"jsonrpc":"1.0","id":4,"method":"ping"}`,
+		`{"jsonrpc":"2.0","id":5,"method":`,
+		`[{"jsonrpc":"2.0","id":6,"method":"ping"}]`,
+		``,
+		`{"jsonrpc":"2.0","id":7,"result":{}}`,
+	)
+	require.Len(t, responses, 6, "blank lines and responses are not answered")
+
+	wantCodes := []int{codeMethodNotFound, codeInvalidParams, codeInvalidParams, codeInvalidRequest, codeParseError, codeInvalidRequest}
+	for i, resp := range responses {
+		require.NotNil(t, resp.Error, "response %d", i)
+		assert.Equal(t, wantCodes[i], resp.Error.Code, "response %d: %s", i, resp.Error.Message)
+		assert.Nil(t, resp.Result, "response %d", i)
+	}
+	assert.Contains(t, responses[1].Error.Message, `unknown tool: "get_horoscope"`)
+	assert.JSONEq(t, `null`, string(responses[4].ID))
+}
+
+func TestServe_StopsWhenDone(t *testing.T) {
+	inR, inW := io.Pipe()
+	defer func() { _ = inW.Close() }()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- New(Options{}).Serve(ctx, inR, io.Discard) }()
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after the context was canceled")
+	}
+}
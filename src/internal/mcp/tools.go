@@ -0,0 +1,349 @@
+package mcp
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/params"
+	"weather-reporter/src/internal/ui"
+	"weather-reporter/src/internal/weather"
+)
+
+const (
+	// defaultForecastDays and defaultForecastHours are the forecast
+	// horizons used when a call does not give one.
+	defaultForecastDays  = 7
+	defaultForecastHours = 24
+)
+
+// tool is a tool offered to the client, as listed by tools/list.
+type tool struct {
+	Name         string          `json:"name"`
+	Title        string          `json:"title"`
+	Description  string          `json:"description"`
+	InputSchema  json.RawMessage `json:"inputSchema"`
+	OutputSchema json.RawMessage `json:"outputSchema"`
+	Annotations  toolAnnotations `json:"annotations"`
+
+	// call runs the tool with the arguments of a tools/call request and
+	// writes its result, a JSON document matching OutputSchema, to out.
+	call func(ctx context.Context, args json.RawMessage, out io.Writer) error
+}
+
+// toolAnnotations describe how a tool behaves.
+type toolAnnotations struct {
+	ReadOnlyHint  bool `json:"readOnlyHint"`
+	OpenWorldHint bool `json:"openWorldHint"`
+}
+
+type toolList struct {
+	Tools []tool `json:"tools"`
+}
+
+type callParams struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// callResult is the result of a tools/call request. Successful calls
+// return the document both as text and as structured content.
+type callResult struct {
+	Content           []textContent   `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+type textContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func (s *Server) newTools() []tool {
+	// The tools only read, from services outside the server.
+	annotations := toolAnnotations{ReadOnlyHint: true, OpenWorldHint: true}
+	return []tool{
+		{
+			Name:  "search_locations",
+			Title: "Search locations",
+			Description: "Lists the places matching a name, most relevant first, with their coordinates, " +
+				"region, country, time zone and population.",
+			InputSchema:  inputSchema("search_locations.input"),
+			OutputSchema: outputSchema("search_locations.output"),
+			Annotations:  annotations,
+			call:         s.searchLocations,
+		},
+		{
+			Name:  "get_current_weather",
+			Title: "Get current weather",
+			Description: "Returns the current temperature, humidity, precipitation, cloud cover, pressure " +
+				"and wind at a place given by name or coordinates.",
+			InputSchema:  inputSchema("location_query"),
+			OutputSchema: outputSchema("get_current_weather.output"),
+			Annotations:  annotations,
+			call:         s.currentWeather,
+		},
+		{
+			Name:  "get_forecast",
+			Title: "Get forecast",
+			Description: "Returns a daily forecast of up to 16 days, or an hourly forecast of up to 168 " +
+				"hours, for a place given by name or coordinates.",
+			InputSchema:  inputSchema("location_query", "get_forecast.input"),
+			OutputSchema: outputSchema("get_forecast.output"),
+			Annotations:  annotations,
+			call:         s.forecast,
+		},
+	}
+}
+
+// callTool answers a tools/call request. Failures of the tool itself,
+// including invalid arguments, are reported in the result so that the
+// client's model can correct the call.
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, error) {
+	var p callParams
+	if err := unmarshalParams(params, &p); err != nil {
+		return nil, newError(codeInvalidParams, "invalid tools/call params: %v", err)
+	}
+	i := slices.IndexFunc(s.tools, func(t tool) bool { return t.Name == p.Name })
+	if i < 0 {
+		return nil, newError(codeInvalidParams, "unknown tool: %q", p.Name)
+	}
+
+	if s.opts.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.RequestTimeout)
+		defer cancel()
+	}
+	var out bytes.Buffer
+	if err := s.tools[i].call(ctx, p.Arguments, &out); err != nil {
+		if s.opts.ErrorLog != nil {
+			_, _ = fmt.Fprintf(s.opts.ErrorLog, "%s: %s\n", p.Name, errorDetail(err))
+		}
+		return callResult{Content: []textContent{{Type: "text", Text: errorText(err)}}, IsError: true}, nil
+	}
+	return callResult{
+		Content:           []textContent{{Type: "text", Text: out.String()}},
+		StructuredContent: out.Bytes(),
+	}, nil
+}
+
+type searchArgs struct {
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+	Country  string `json:"country"`
+	Region   string `json:"region"`
+	Language string `json:"language"`
+}
+
+// searchLocations implements the search_locations tool.
+func (s *Server) searchLocations(ctx context.Context, raw json.RawMessage, out io.Writer) error {
+	var args searchArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return err
+	}
+	name := strings.TrimSpace(args.Name)
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if args.Count < 0 || args.Count > config.MaxSearchCount {
+		return fmt.Errorf("count must be between 1 and %d", config.MaxSearchCount)
+	}
+	query, err := params.Search{Country: args.Country, Region: args.Region, Language: args.Language}.Query()
+	if err != nil {
+		return err
+	}
+	query.Options.Count = args.Count
+
+	locations, err := geo.SearchQuery(ctx, s.opts.Geocoder, name, query.Filter, query.Options)
+	if err != nil {
+		return err
+	}
+	// Filtering may have requested more results than asked for.
+	if args.Count > 0 && len(locations) > args.Count {
+		locations = locations[:args.Count]
+	}
+	return ui.PrintLocationsJSON(out, name, locations)
+}
+
+// locationArgs are the arguments selecting a location and units.
+type locationArgs struct {
+	Location          string `json:"location"`
+	Country           string `json:"country"`
+	Region            string `json:"region"`
+	Language          string `json:"language"`
+	Pick              string `json:"pick"`
+	Units             string `json:"units"`
+	TemperatureUnit   string `json:"temperature_unit"`
+	WindSpeedUnit     string `json:"wind_speed_unit"`
+	PrecipitationUnit string `json:"precipitation_unit"`
+}
+
+// currentWeather implements the get_current_weather tool.
+func (s *Server) currentWeather(ctx context.Context, raw json.RawMessage, out io.Writer) error {
+	var args locationArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return err
+	}
+	units, err := s.units(args)
+	if err != nil {
+		return err
+	}
+	loc, err := s.locate(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.opts.Weather.GetCurrentWeather(ctx, loc.Latitude, loc.Longitude, units)
+	if err != nil {
+		return err
+	}
+	return ui.PrintWeatherJSON(out, loc, current)
+}
+
+type forecastArgs struct {
+	locationArgs
+	Granularity string `json:"granularity"`
+	Days        int    `json:"days"`
+	Hours       int    `json:"hours"`
+}
+
+// forecast implements the get_forecast tool.
+func (s *Server) forecast(ctx context.Context, raw json.RawMessage, out io.Writer) error {
+	var args forecastArgs
+	if err := decodeArgs(raw, &args); err != nil {
+		return err
+	}
+	hourly, err := args.hourly()
+	if err != nil {
+		return err
+	}
+	units, err := s.units(args.locationArgs)
+	if err != nil {
+		return err
+	}
+	loc, err := s.locate(ctx, args.locationArgs)
+	if err != nil {
+		return err
+	}
+
+	if hourly {
+		hours := cmp.Or(args.Hours, defaultForecastHours)
+		forecast, err := s.opts.Weather.GetHourlyForecast(ctx, loc.Latitude, loc.Longitude, hours, units)
+		if err != nil {
+			return err
+		}
+		return ui.PrintHourlyForecastJSON(out, loc, forecast)
+	}
+	days := cmp.Or(args.Days, defaultForecastDays)
+	forecast, err := s.opts.Weather.GetDailyForecast(ctx, loc.Latitude, loc.Longitude, days, units)
+	if err != nil {
+		return err
+	}
+	return ui.PrintDailyForecastJSON(out, loc, forecast)
+}
+
+// hourly validates the granularity and length of the forecast and reports
+// whether it is hourly.
+func (a *forecastArgs) hourly() (bool, error) {
+	switch a.Granularity {
+	case "", "daily":
+		if a.Hours != 0 {
+			return false, errors.New("hours applies to hourly forecasts; use days or granularity \"hourly\"")
+		}
+		if a.Days < 0 || a.Days > weather.MaxForecastDays {
+			return false, fmt.Errorf("days must be between 1 and %d", weather.MaxForecastDays)
+		}
+		return false, nil
+	case "hourly":
+		if a.Days != 0 {
+			return false, errors.New("days applies to daily forecasts; use hours or granularity \"daily\"")
+		}
+		if a.Hours < 0 || a.Hours > weather.MaxForecastHours {
+			return false, fmt.Errorf("hours must be between 1 and %d", weather.MaxForecastHours)
+		}
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid granularity %q: must be daily or hourly", a.Granularity)
+}
+
+// locate resolves the location of a call. Ambiguous names fail with a
+// *geo.AmbiguousError unless the pick argument chooses between the
+// matches.
+func (s *Server) locate(ctx context.Context, args locationArgs) (models.Location, error) {
+	if strings.TrimSpace(args.Location) == "" {
+		return models.Location{}, errors.New("location is required")
+	}
+	query, err := params.Location{
+		Name:   args.Location,
+		Search: params.Search{Country: args.Country, Region: args.Region, Language: args.Language},
+		Pick:   args.Pick,
+	}.Query()
+	if err != nil {
+		return models.Location{}, err
+	}
+	return s.resolver.Resolve(ctx, query)
+}
+
+// units returns the units selected by a call, starting from the configured
+// units.
+func (s *Server) units(args locationArgs) (models.Units, error) {
+	return params.Units{
+		System:        args.Units,
+		Temperature:   args.TemperatureUnit,
+		WindSpeed:     args.WindSpeedUnit,
+		Precipitation: args.PrecipitationUnit,
+	}.Apply(s.opts.Units)
+}
+
+// decodeArgs decodes the arguments of a tool call into v, rejecting
+// arguments the tool does not know.
+func decodeArgs(raw json.RawMessage, v any) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+// errorDetail returns the cause of err for the error log.
+func errorDetail(err error) string {
+	var searchErr *geo.SearchError
+	if errors.As(err, &searchErr) {
+		return searchErr.Detail()
+	}
+	return err.Error()
+}
+
+// errorText explains why a tool call failed. For ambiguous locations it
+// lists the candidates and how to choose one.
+func errorText(err error) string {
+	var ambiguous *geo.AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		return err.Error()
+	}
+	var b strings.Builder
+	if ambiguous.Err != nil {
+		fmt.Fprintf(&b, "%v\n", ambiguous.Err)
+	}
+	fmt.Fprintf(&b, "%q matches %d locations:\n", ambiguous.Query, len(ambiguous.Candidates))
+	for i, loc := range ambiguous.Candidates {
+		fmt.Fprintf(&b, "%d. %s at %s,%s\n", i+1, ui.FormatLocation(loc),
+			strconv.FormatFloat(loc.Latitude, 'f', -1, 64), strconv.FormatFloat(loc.Longitude, 'f', -1, 64))
+	}
+	b.WriteString(`Repeat the call with the coordinates of one of them as the location, a region, or a pick such as "2".`)
+	return b.String()
+}
@@ -0,0 +1,243 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	*Server
+	geo     *testutil.Geocoder
+	weather *testutil.WeatherService
+}
+
+func newTestServer(opts Options) *testServer {
+	s := &testServer{
+		geo:     testutil.NewGeocoder(),
+		weather: &testutil.WeatherService{},
+	}
+	opts.Geocoder, opts.Weather, opts.Reverse = s.geo, s.weather, testutil.ReverseGeocoder{}
+	s.Server = New(opts)
+	return s
+}
+
+// toolResult is the result of a tools/call request as seen by a client.
+type toolResult struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	StructuredContent map[string]any `json:"structuredContent"`
+	IsError           bool           `json:"isError"`
+}
+
+// call calls a tool with the given JSON arguments in a session.
+func (s *testServer) call(t *testing.T, name, args string) toolResult {
+	t.Helper()
+	responses := session(t, s.Server, initialize,
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":%q,"arguments":%s}}`, name, args))
+	require.Len(t, responses, 2)
+	require.Nil(t, responses[1].Error)
+
+	var result toolResult
+	require.NoError(t, json.Unmarshal(responses[1].Result, &result))
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "text", result.Content[0].Type)
+	if !result.IsError {
+		// The text is the structured content, for clients without support
+		// for it.
+		var fromText map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &fromText))
+		assert.Equal(t, result.StructuredContent, fromText)
+	}
+	return result
+}
+
+func TestSearchLocations(t *testing.T) {
+	s := newTestServer(Options{})
+
+	result := s.call(t, "search_locations", `{"name":"Portland","count":1,"language":"de"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, float64(1), result.StructuredContent["schema_version"])
+	assert.Equal(t, "Portland", result.StructuredContent["query"])
+	results := result.StructuredContent["results"].([]any)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Oregon", results[0].(map[string]any)["admin1"])
+	assert.Equal(t, "de", s.geo.Opts.Language)
+}
+
+func TestSearchLocations_Filter(t *testing.T) {
+	s := newTestServer(Options{})
+
+	result := s.call(t, "search_locations", `{"name":"Portland","country":"us","region":"ME"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	results := result.StructuredContent["results"].([]any)
+	require.Len(t, results, 1)
+	assert.Equal(t, "Maine", results[0].(map[string]any)["admin1"])
+}
+
+func TestGetCurrentWeather(t *testing.T) {
+	s := newTestServer(Options{Units: models.ImperialUnits()})
+
+	result := s.call(t, "get_current_weather", `{"location":"Berlin"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, "Berlin", result.StructuredContent["location"].(map[string]any)["name"])
+	temperature := result.StructuredContent["current"].(map[string]any)["temperature"]
+	assert.Equal(t, map[string]any{"value": 2.5, "unit": "°C"}, temperature)
+	assert.Equal(t, 52.52, s.weather.Lat)
+	assert.Equal(t, models.ImperialUnits(), s.weather.Units)
+}
+
+func TestGetCurrentWeather_Coordinates(t *testing.T) {
+	s := newTestServer(Options{})
+
+	result := s.call(t, "get_current_weather", `{"location":"45.5,-122.7","units":"metric","wind_speed_unit":"kn"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	location := result.StructuredContent["location"].(map[string]any)
+	assert.Equal(t, 45.5, location["latitude"])
+	assert.Equal(t, "Berlin", location["near"].(map[string]any)["name"])
+	assert.Equal(t, -122.7, s.weather.Lon)
+	assert.Equal(t, models.Knots, s.weather.Units.WindSpeed)
+}
+
+func TestGetCurrentWeather_Ambiguous(t *testing.T) {
+	s := newTestServer(Options{})
+
+	result := s.call(t, "get_current_weather", `{"location":"Portland"}`)
+	assert.True(t, result.IsError)
+	assert.Equal(t, `"Portland" matches 2 locations:
+1. Portland, United States (Oregon) at 45.52,-122.68
+2. Portland, United States (Maine) at 43.66,-70.26
+Repeat the call with the coordinates of one of them as the location, a region, or a pick such as "2".`, result.Content[0].Text)
+	assert.Zero(t, s.weather.Calls)
+
+	result = s.call(t, "get_current_weather", `{"location":"Portland","pick":"2"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, -70.26, s.weather.Lon)
+
+	result = s.call(t, "get_current_weather", `{"location":"Portland","region":"Oregon"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, -122.68, s.weather.Lon)
+}
+
+func TestGetForecast(t *testing.T) {
+	s := newTestServer(Options{})
+
+	result := s.call(t, "get_forecast", `{"location":"Berlin"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, 7, s.weather.N)
+	daily := result.StructuredContent["daily"].([]any)
+	require.Len(t, daily, 1)
+	assert.Equal(t, "2026-01-01", daily[0].(map[string]any)["date"])
+	assert.NotContains(t, result.StructuredContent, "hourly")
+
+	result = s.call(t, "get_forecast", `{"location":"Berlin","days":16}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, 16, s.weather.N)
+}
+
+func TestGetForecast_Hourly(t *testing.T) {
+	s := newTestServer(Options{})
+
+	result := s.call(t, "get_forecast", `{"location":"Berlin","granularity":"hourly"}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, 24, s.weather.N)
+	hourly := result.StructuredContent["hourly"].([]any)
+	require.Len(t, hourly, 1)
+	assert.Equal(t, "2026-01-01T14:00:00Z", hourly[0].(map[string]any)["time"])
+
+	result = s.call(t, "get_forecast", `{"location":"Berlin","granularity":"hourly","hours":168}`)
+	require.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, 168, s.weather.N)
+}
+
+func TestInvalidArguments(t *testing.T) {
+	tests := []struct {
+		tool, args, want string
+	}{
+		{"search_locations", `{}`, "name is required"},
+		{"search_locations", `{"name":"Berlin","count":101}`, "count must be between 1 and 100"},
+		{"search_locations", `{"name":"Berlin","language":"german"}`, "language: must be a two-letter"},
+		{"search_locations", `{"name":"Berlin","limit":5}`, `invalid arguments: json: unknown field "limit"`},
+		{"get_current_weather", `{}`, "location is required"},
+		{"get_current_weather", `{"location":"Berlin","units":"kelvin"}`, `unknown unit system "kelvin"`},
+		{"get_current_weather", `{"location":"Berlin","temperature_unit":"kelvin"}`, "kelvin"},
+		{"get_current_weather", `{"location":"91,0"}`, "latitude"},
+		{"get_current_weather", `{"location":"Portland","pick":"nearest"}`, `invalid pick "nearest"`},
+		{"get_current_weather", `{"location":"Berlin","days":3}`, `unknown field "days"`},
+		{"get_current_weather", `{"location":42}`, "invalid arguments"},
+		{"get_forecast", `{"location":"Berlin","days":17}`, "days must be between 1 and 16"},
+		{"get_forecast", `{"location":"Berlin","granularity":"hourly","hours":169}`, "hours must be between 1 and 168"},
+		{"get_forecast", `{"location":"Berlin","hours":12}`, "hours applies to hourly forecasts"},
+		{"get_forecast", `{"location":"Berlin","granularity":"hourly","days":2}`, "days applies to daily forecasts"},
+		{"get_forecast", `{"location":"Berlin","granularity":"weekly"}`, `invalid granularity "weekly"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool+" "+tt.args, func(t *testing.T) {
+			s := newTestServer(Options{})
+
+			result := s.call(t, tt.tool, tt.args)
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.want)
+			assert.Nil(t, result.StructuredContent)
+			assert.Zero(t, s.weather.Calls, "invalid calls must not fetch weather")
+		})
+	}
+}
+
+func TestToolErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		geoErr     error
+		weatherErr error
+		location   string
+		want       string
+	}{
+		{"not found", nil, nil, "Atlantis", "location not found: Atlantis"},
+		{"rate limited", geo.ErrRateLimited, nil, "Bonn", geo.ErrRateLimited.Error()},
+		{"breaker open", nil, &breaker.OpenError{Name: "weather", RetryIn: 20 * time.Second}, "Berlin", "retrying in 20s"},
+		{"weather failure", nil, errors.New("weather API returned status 500"), "Berlin", "status 500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(Options{})
+			s.geo.Err, s.weather.Err = tt.geoErr, tt.weatherErr
+
+			result := s.call(t, "get_current_weather", fmt.Sprintf(`{"location":%q}`, tt.location))
+			assert.True(t, result.IsError)
+			assert.Contains(t, result.Content[0].Text, tt.want)
+		})
+	}
+}
+
+func TestToolErrors_Log(t *testing.T) {
+	var log strings.Builder
+	s := newTestServer(Options{ErrorLog: &log})
+	s.weather.Err = errors.New("weather API returned status 500")
+
+	result := s.call(t, "get_current_weather", `{"location":"Berlin"}`)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "get_current_weather: weather API returned status 500\n", log.String())
+}
+
+func TestRequestTimeout(t *testing.T) {
+	s := newTestServer(Options{RequestTimeout: 10 * time.Millisecond})
+	s.weather.Delay = time.Minute
+
+	result := s.call(t, "get_current_weather", `{"location":"Berlin"}`)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, context.DeadlineExceeded.Error())
+}
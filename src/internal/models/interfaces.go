@@ -5,18 +5,47 @@ import "context"
 
 // GeocodingService defines the interface for finding locations.
 type GeocodingService interface {
-	// Search finds locations matching the given name.
-	Search(ctx context.Context, name string) ([]Location, error)
+	// Search finds locations matching the given name. Zero fields of opts
+	// use the service's defaults.
+	Search(ctx context.Context, name string, opts SearchOptions) ([]Location, error)
+}
+
+// SearchOptions narrows a location search.
+type SearchOptions struct {
+	// Count is the maximum number of results.
+	Count int
+	// Language is the ISO 639-1 code of the language of location names.
+	Language string
+	// CountryCode restricts results to an ISO 3166-1 alpha-2 country.
+	CountryCode string
+}
+
+// ReverseGeocodingService defines the interface for finding the place
+// nearest to a point.
+type ReverseGeocodingService interface {
+	// Reverse returns the populated place nearest to the given coordinates.
+	Reverse(ctx context.Context, lat, lon float64) (Location, error)
 }
 
 // WeatherService defines the interface for fetching weather.
 type WeatherService interface {
-	// GetCurrentWeather returns the current weather for the given coordinates.
-	GetCurrentWeather(ctx context.Context, lat, lon float64) (WeatherResponse, error)
+	// GetCurrentWeather returns the current weather for the given
+	// coordinates in the requested units.
+	GetCurrentWeather(ctx context.Context, lat, lon float64, units Units) (WeatherResponse, error)
+
+	// GetDailyForecast returns a per-day forecast for the given coordinates,
+	// starting today and covering the requested number of days.
+	GetDailyForecast(ctx context.Context, lat, lon float64, days int, units Units) ([]DailyForecast, error)
+
+	// GetHourlyForecast returns an hour-by-hour forecast for the given
+	// coordinates, starting at the current hour and covering the requested
+	// number of hours. Times are in the location's local timezone.
+	GetHourlyForecast(ctx context.Context, lat, lon float64, hours int, units Units) ([]HourlyForecast, error)
 }
 
 // WeatherResponse defines the interface for the weather data response.
-// It provides accessors that return formatted strings (value + unit).
+// It provides accessors that return formatted strings (value + unit) and
+// the same data as typed measurements.
 type WeatherResponse interface {
 	QuantityOfTemperature() string         // e.g., "10.5°C"
 	QuantityOfHumidity() string            // e.g., "85%"
@@ -27,4 +56,8 @@ type WeatherResponse interface {
 	QuantityOfWindSpeed() string           // e.g., "15.0 km/h"
 	QuantityOfWindDirection() string       // e.g., "180°"
 	QuantityOfWindGusts() string           // e.g., "25.0 km/h"
+
+	// Observation returns the numeric values, units and observation time
+	// behind the formatted accessors.
+	Observation() Observation
 }
@@ -1,11 +1,111 @@
 package models
 
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
 // Location represents a geographical location.
 type Location struct {
-	ID        int     `json:"id"`
-	Name      string  `json:"name"`
-	Latitude  float64 `json:"latitude"`
-	Longitude float64 `json:"longitude"`
-	Country   string  `json:"country"`
-	Region    string  `json:"admin1"`
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Country     string  `json:"country"`
+	CountryCode string  `json:"country_code"`
+	Region      string  `json:"admin1"`
+
+	// Admin2 to Admin4 are the second- to fourth-order administrative
+	// areas, such as a county or municipality. They are often empty.
+	Admin2 string `json:"admin2,omitempty"`
+	Admin3 string `json:"admin3,omitempty"`
+	Admin4 string `json:"admin4,omitempty"`
+
+	// Timezone is the IANA time zone name, such as "Europe/Berlin".
+	Timezone string `json:"timezone,omitempty"`
+
+	// Elevation is the height above sea level in meters.
+	Elevation float64 `json:"elevation,omitempty"`
+
+	// Population is the number of inhabitants, or 0 if it is unknown.
+	Population int `json:"population,omitempty"`
+
+	// Postcodes lists the postal codes of the place, if known.
+	Postcodes []string `json:"postcodes,omitempty"`
+
+	// FeatureCode is the GeoNames feature code, such as "PPLC" for a
+	// capital or "PPL" for a populated place.
+	FeatureCode string `json:"feature_code,omitempty"`
+
+	// Near is the nearest known place for a location given only by its
+	// coordinates. It is nil for locations found by name.
+	Near *Location `json:"near,omitempty"`
+}
+
+// DailyForecast represents the forecast summary for a single day.
+type DailyForecast struct {
+	Date             time.Time   `json:"date"`
+	TemperatureMin   Measurement `json:"temperature_min"`
+	TemperatureMax   Measurement `json:"temperature_max"`
+	PrecipitationSum Measurement `json:"precipitation_sum"`
+	WindSpeedMax     Measurement `json:"wind_speed_max"`
+}
+
+// HourlyForecast represents the forecast for a single hour.
+// Time is expressed in the location's local timezone.
+type HourlyForecast struct {
+	Time                     time.Time   `json:"time"`
+	Temperature              Measurement `json:"temperature"`
+	PrecipitationProbability Measurement `json:"precipitation_probability"`
+	WindSpeed                Measurement `json:"wind_speed"`
+	WindDirection            Measurement `json:"wind_direction"`
+}
+
+// Measurement is a numeric value together with its unit and the time it
+// was observed.
+type Measurement struct {
+	Value float64   `json:"value"`
+	Unit  string    `json:"unit"`
+	Time  time.Time `json:"time"`
+
+	// Missing is set when the API reported no value, in which case Value
+	// is meaningless.
+	Missing bool `json:"missing,omitempty"`
+}
+
+// String formats the measurement as value and unit, e.g. "10.5°C",
+// "85%" or "15.0 km/h". Percentages and bare degrees are shown without
+// decimals and inches with two; degree units and percentages are not
+// separated by a space. Missing measurements are formatted as "n/a".
+func (m Measurement) String() string {
+	if m.Missing {
+		return "n/a"
+	}
+	precision := 1
+	switch m.Unit {
+	case "%", "°":
+		precision = 0
+	case "inch":
+		precision = 2
+	}
+	separator := " "
+	if m.Unit == "" || strings.HasPrefix(m.Unit, "°") || m.Unit == "%" {
+		separator = ""
+	}
+	return strconv.FormatFloat(m.Value, 'f', precision, 64) + separator + m.Unit
+}
+
+// Observation holds the typed measurements of a current-weather report.
+type Observation struct {
+	Time                time.Time   `json:"time"`
+	Temperature         Measurement `json:"temperature"`
+	ApparentTemperature Measurement `json:"apparent_temperature"`
+	Humidity            Measurement `json:"humidity"`
+	Precipitation       Measurement `json:"precipitation"`
+	CloudCover          Measurement `json:"cloud_cover"`
+	Pressure            Measurement `json:"pressure"`
+	WindSpeed           Measurement `json:"wind_speed"`
+	WindDirection       Measurement `json:"wind_direction"`
+	WindGusts           Measurement `json:"wind_gusts"`
 }
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasurementString(t *testing.T) {
+	tests := []struct {
+		m    Measurement
+		want string
+	}{
+		{Measurement{Value: 10.5, Unit: "°C"}, "10.5°C"},
+		{Measurement{Value: -2.84, Unit: "°C"}, "-2.8°C"},
+		{Measurement{Value: 85, Unit: "%"}, "85%"},
+		{Measurement{Value: 0, Unit: "mm"}, "0.0 mm"},
+		{Measurement{Value: 1015, Unit: "hPa"}, "1015.0 hPa"},
+		{Measurement{Value: 15, Unit: "km/h"}, "15.0 km/h"},
+		{Measurement{Value: 179.6, Unit: "°"}, "180°"},
+		{Measurement{Value: 0.125, Unit: "inch"}, "0.12 inch"},
+		{Measurement{Value: 3.25}, "3.2"},
+		{Measurement{Unit: "°C", Missing: true}, "n/a"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.m.String())
+	}
+}
+
+func TestUnitsForSystem(t *testing.T) {
+	u, err := UnitsForSystem(UnitSystemImperial)
+	assert.NoError(t, err)
+	assert.Equal(t, Units{Temperature: Fahrenheit, WindSpeed: MilesPerHour, Precipitation: Inches}, u)
+
+	u, err = UnitsForSystem(UnitSystemMetric)
+	assert.NoError(t, err)
+	assert.Equal(t, MetricUnits(), u)
+
+	_, err = UnitsForSystem("custom")
+	assert.EqualError(t, err, `unknown unit system "custom" (must be metric or imperial)`)
+
+	_, err = UnitsForSystem("nautical")
+	assert.EqualError(t, err, `unknown unit system "nautical" (must be metric or imperial)`)
+}
+
+func TestUnitsOverride(t *testing.T) {
+	u := MetricUnits()
+	assert.NoError(t, u.Override("fahrenheit", "knots", ""))
+	assert.Equal(t, Units{Temperature: Fahrenheit, WindSpeed: Knots, Precipitation: Millimetres}, u)
+
+	assert.NoError(t, u.Override("", "m/s", "in"))
+	assert.Equal(t, Units{Temperature: Fahrenheit, WindSpeed: MetresPerSecond, Precipitation: Inches}, u)
+
+	assert.EqualError(t, u.Override("kelvin", "", ""), `unknown temperature unit "kelvin"`)
+	assert.EqualError(t, u.Override("", "beaufort", ""), `unknown wind speed unit "beaufort"`)
+	assert.EqualError(t, u.Override("", "", "cm"), `unknown precipitation unit "cm"`)
+}
+
+func TestUnitsSymbols(t *testing.T) {
+	var zero Units
+	assert.Equal(t, "°C", zero.TemperatureSymbol())
+	assert.Equal(t, "km/h", zero.WindSpeedSymbol())
+	assert.Equal(t, "mm", zero.PrecipitationSymbol())
+
+	imperial := ImperialUnits()
+	assert.Equal(t, "°F", imperial.TemperatureSymbol())
+	assert.Equal(t, "mph", imperial.WindSpeedSymbol())
+	assert.Equal(t, "inch", imperial.PrecipitationSymbol())
+
+	assert.Equal(t, "m/s", Units{WindSpeed: MetresPerSecond}.WindSpeedSymbol())
+	assert.Equal(t, "kn", Units{WindSpeed: Knots}.WindSpeedSymbol())
+}
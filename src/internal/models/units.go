@@ -0,0 +1,128 @@
+package models
+
+import "fmt"
+
+// Unit systems accepted by UnitsForSystem.
+const (
+	UnitSystemMetric   = "metric"
+	UnitSystemImperial = "imperial"
+)
+
+// Temperature units.
+const (
+	Celsius    = "celsius"
+	Fahrenheit = "fahrenheit"
+)
+
+// Wind speed units.
+const (
+	KilometresPerHour = "kmh"
+	MetresPerSecond   = "ms"
+	MilesPerHour      = "mph"
+	Knots             = "kn"
+)
+
+// Precipitation units.
+const (
+	Millimetres = "mm"
+	Inches      = "inch"
+)
+
+// Units selects the units in which weather data is requested. Values use
+// the upstream API's identifiers; the zero value means metric units
+// (°C, km/h, mm).
+type Units struct {
+	Temperature   string `json:"temperature,omitempty"`
+	WindSpeed     string `json:"wind_speed,omitempty"`
+	Precipitation string `json:"precipitation,omitempty"`
+}
+
+// MetricUnits returns the metric unit selection (°C, km/h, mm).
+func MetricUnits() Units {
+	return Units{Temperature: Celsius, WindSpeed: KilometresPerHour, Precipitation: Millimetres}
+}
+
+// ImperialUnits returns the imperial unit selection (°F, mph, inch).
+func ImperialUnits() Units {
+	return Units{Temperature: Fahrenheit, WindSpeed: MilesPerHour, Precipitation: Inches}
+}
+
+// UnitsForSystem returns the units of a named unit system. Individual
+// units of any system can then be replaced with Units.Override.
+func UnitsForSystem(system string) (Units, error) {
+	switch system {
+	case UnitSystemMetric:
+		return MetricUnits(), nil
+	case UnitSystemImperial:
+		return ImperialUnits(), nil
+	default:
+		return Units{}, fmt.Errorf("unknown unit system %q (must be %s or %s)", system, UnitSystemMetric, UnitSystemImperial)
+	}
+}
+
+// Override replaces individual units. Empty arguments leave the current
+// unit unchanged. Common aliases such as "f", "knots" or "in" are accepted.
+func (u *Units) Override(temperature, windSpeed, precipitation string) error {
+	aliases := []struct {
+		dst     *string
+		value   string
+		kind    string
+		allowed map[string]string
+	}{
+		{&u.Temperature, temperature, "temperature", map[string]string{
+			Celsius: Celsius, "c": Celsius,
+			Fahrenheit: Fahrenheit, "f": Fahrenheit,
+		}},
+		{&u.WindSpeed, windSpeed, "wind speed", map[string]string{
+			KilometresPerHour: KilometresPerHour, "km/h": KilometresPerHour,
+			MetresPerSecond: MetresPerSecond, "m/s": MetresPerSecond,
+			MilesPerHour: MilesPerHour,
+			Knots:        Knots, "knots": Knots,
+		}},
+		{&u.Precipitation, precipitation, "precipitation", map[string]string{
+			Millimetres: Millimetres,
+			Inches:      Inches, "in": Inches, "inches": Inches,
+		}},
+	}
+	for _, a := range aliases {
+		if a.value == "" {
+			continue
+		}
+		unit, ok := a.allowed[a.value]
+		if !ok {
+			return fmt.Errorf("unknown %s unit %q", a.kind, a.value)
+		}
+		*a.dst = unit
+	}
+	return nil
+}
+
+// TemperatureSymbol returns the display symbol of the temperature unit.
+func (u Units) TemperatureSymbol() string {
+	if u.Temperature == Fahrenheit {
+		return "°F"
+	}
+	return "°C"
+}
+
+// WindSpeedSymbol returns the display symbol of the wind speed unit.
+func (u Units) WindSpeedSymbol() string {
+	switch u.WindSpeed {
+	case MetresPerSecond:
+		return "m/s"
+	case MilesPerHour:
+		return "mph"
+	case Knots:
+		return "kn"
+	default:
+		return "km/h"
+	}
+}
+
+// PrecipitationSymbol returns the display symbol of the precipitation unit.
+func (u Units) PrecipitationSymbol() string {
+	if u.Precipitation == Inches {
+		return "inch"
+	}
+	return "mm"
+}
@@ -0,0 +1,107 @@
+// Package params parses the location and units parameters shared by the
+// HTTP, gRPC and MCP APIs, so that they accept the same values and reject
+// invalid ones with the same messages. Every error it returns is an invalid
+// parameter, which each API reports in its own way.
+package params
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+)
+
+// Search holds the parameters that narrow a location search. Empty ones
+// are not used.
+type Search struct {
+	// Country is an ISO 3166-1 alpha-2 country code.
+	Country string
+	// Region is the name or code of a first-level administrative area.
+	Region string
+	// Language is the ISO 639-1 code of the language of the results.
+	Language string
+}
+
+// Query returns the geocoding query for s. Its text is left to the
+// caller.
+func (s Search) Query() (geo.Query, error) {
+	var query geo.Query
+	if s.Language != "" {
+		lang, err := config.ParseLanguage(s.Language)
+		if err != nil {
+			return geo.Query{}, fmt.Errorf("language: %w", err)
+		}
+		query.Options.Language = lang
+	}
+	if s.Country != "" {
+		code, err := geo.ParseCountryCode(s.Country)
+		if err != nil {
+			return geo.Query{}, err
+		}
+		query.Filter.Country = code
+	}
+	query.Filter.Region = strings.TrimSpace(s.Region)
+	return query, nil
+}
+
+// Location holds the parameters naming a location.
+type Location struct {
+	// Name is a place name or a coordinate pair. Callers reject empty
+	// names, with a message naming their own parameters.
+	Name string
+	Search
+	// Pick chooses between the matches of an ambiguous name, and may be
+	// empty.
+	Pick string
+}
+
+// Query returns the geocoding query for l. A name that is a coordinate
+// pair out of range is invalid.
+func (l Location) Query() (geo.Query, error) {
+	name := strings.TrimSpace(l.Name)
+	if _, _, err := geo.ParseCoordinates(name); err != nil && !errors.Is(err, geo.ErrNotCoordinates) {
+		return geo.Query{}, err
+	}
+	query, err := l.Search.Query()
+	if err != nil {
+		return geo.Query{}, err
+	}
+	query.Text = name
+	if l.Pick != "" {
+		if query.Pick, err = geo.ParsePick(l.Pick); err != nil {
+			return geo.Query{}, err
+		}
+	}
+	return query, nil
+}
+
+// Units holds the parameters selecting units: a unit system and overrides
+// for single quantities. Empty ones are not used.
+type Units struct {
+	System        string
+	Temperature   string
+	WindSpeed     string
+	Precipitation string
+}
+
+// Apply returns the units selected by u, starting from base. The zero base
+// means metric units.
+func (u Units) Apply(base models.Units) (models.Units, error) {
+	units := base
+	if units == (models.Units{}) {
+		units = models.MetricUnits()
+	}
+	if u.System != "" {
+		var err error
+		if units, err = models.UnitsForSystem(u.System); err != nil {
+			return models.Units{}, err
+		}
+	}
+	if err := units.Override(u.Temperature, u.WindSpeed, u.Precipitation); err != nil {
+		return models.Units{}, err
+	}
+	return units, nil
+}
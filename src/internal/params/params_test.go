@@ -0,0 +1,81 @@
+package params
+
+import (
+	"testing"
+
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocation_Query(t *testing.T) {
+	query, err := Location{
+		Name:   "  Portland ",
+		Search: Search{Country: "us", Region: " Maine ", Language: "DE"},
+		Pick:   "2",
+	}.Query()
+
+	require.NoError(t, err)
+	assert.Equal(t, "Portland", query.Text)
+	assert.Equal(t, "US", query.Filter.Country)
+	assert.Equal(t, "Maine", query.Filter.Region)
+	assert.Equal(t, "de", query.Options.Language)
+	pick, err := geo.ParsePick("2")
+	require.NoError(t, err)
+	assert.Equal(t, pick, query.Pick)
+
+	query, err = Location{Name: "52.52,13.41"}.Query()
+	require.NoError(t, err)
+	assert.Equal(t, "52.52,13.41", query.Text, "coordinates are resolved by the caller")
+}
+
+func TestLocation_QueryErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		loc  Location
+		err  string
+	}{
+		{"coordinates out of range", Location{Name: "95,13.41"}, "latitude"},
+		{"language", Location{Name: "Berlin", Search: Search{Language: "german"}}, "language: must be a two-letter ISO 639-1 language code"},
+		{"country", Location{Name: "Berlin", Search: Search{Country: "Germany"}}, `invalid country code "Germany"`},
+		{"pick", Location{Name: "Berlin", Pick: "nearest"}, `invalid pick "nearest"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.loc.Query()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestUnits_Apply(t *testing.T) {
+	tests := []struct {
+		name  string
+		units Units
+		base  models.Units
+		want  models.Units
+	}{
+		{"metric by default", Units{}, models.Units{}, models.MetricUnits()},
+		{"configured units", Units{}, models.ImperialUnits(), models.ImperialUnits()},
+		{"system", Units{System: "imperial"}, models.MetricUnits(), models.ImperialUnits()},
+		{"override", Units{System: "imperial", Temperature: "celsius"}, models.Units{},
+			models.Units{Temperature: models.Celsius, WindSpeed: models.MilesPerHour, Precipitation: models.Inches}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.units.Apply(tt.base)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := Units{System: "kelvin"}.Apply(models.Units{})
+	assert.ErrorContains(t, err, `unknown unit system "kelvin"`)
+	_, err = Units{WindSpeed: "furlongs"}.Apply(models.Units{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+// Package retry retries failed requests to the upstream APIs with
+// exponential backoff and jitter. It is an http.RoundTripper, so the
+// geocoding and weather clients share it by sharing an http.Client.
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Policy configures how requests are retried.
+type Policy struct {
+	// Attempts is the maximum number of attempts, including the first.
+	// Values below 2 disable retries.
+	Attempts int
+	// Backoff is the delay before the first retry. Each further retry
+	// waits twice as long, up to MaxDelay, with random jitter.
+	Backoff time.Duration
+	// MaxDelay caps the delay between attempts. A Retry-After longer than
+	// MaxDelay is not waited for; the response is returned instead.
+	MaxDelay time.Duration
+	// AttemptTimeout bounds each attempt, including reading the response
+	// body. Zero means attempts are only bounded by the request context.
+	AttemptTimeout time.Duration
+}
+
+// DefaultPolicy is the policy used when none is configured.
+var DefaultPolicy = Policy{
+	Attempts: 3,
+	Backoff:  500 * time.Millisecond,
+	MaxDelay: 10 * time.Second,
+}
+
+// Transport is an http.RoundTripper that retries idempotent requests
+// which fail with a network error, a timed-out attempt or a status
+// that signals a transient failure: 408, 429, 500, 502, 503 or 504.
+// Retries stop when the request context is done, and are not started
+// when the delay would end after its deadline.
+type Transport struct {
+	base   http.RoundTripper
+	policy Policy
+
+	// now, sleep and jitter are replaced in tests.
+	now    func() time.Time
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(d time.Duration) time.Duration
+}
+
+// NewTransport returns a Transport that sends requests through base, or
+// http.DefaultTransport if base is nil.
+func NewTransport(base http.RoundTripper, policy Policy) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:   base,
+		policy: policy,
+		now:    time.Now,
+		sleep:  sleep,
+		jitter: equalJitter,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.canRetry(req) {
+		return t.attempt(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.attempt(req)
+		if attempt >= t.policy.Attempts || !t.retryable(ctx, resp, err) {
+			return resp, err
+		}
+
+		delay, ok := t.delay(ctx, attempt, resp)
+		if !ok {
+			return resp, err
+		}
+		if resp != nil && resp.Body != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+		if err := t.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// canRetry reports whether req may be sent more than once.
+func (t *Transport) canRetry(req *http.Request) bool {
+	if t.policy.Attempts < 2 {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// attempt sends req once, bounded by the policy's AttemptTimeout.
+func (t *Transport) attempt(req *http.Request) (*http.Response, error) {
+	if t.policy.AttemptTimeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.policy.AttemptTimeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The timeout also covers reading the body, so it ends when the body
+	// is closed.
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// retryable reports whether a failed attempt is worth repeating.
+func (t *Transport) retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded) ||
+			errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before the attempt after attempt. It
+// returns false if the wait is longer than MaxDelay or would end after the
+// deadline of ctx.
+func (t *Transport) delay(ctx context.Context, attempt int, resp *http.Response) (time.Duration, bool) {
+	backoff := t.policy.Backoff << (attempt - 1)
+	if backoff <= 0 || (t.policy.MaxDelay > 0 && backoff > t.policy.MaxDelay) {
+		backoff = t.policy.MaxDelay
+	}
+	d := t.jitter(backoff)
+
+	if resp != nil {
+		if after, ok := t.retryAfter(resp.Header.Get("Retry-After")); ok {
+			if t.policy.MaxDelay > 0 && after > t.policy.MaxDelay {
+				return 0, false
+			}
+			d = max(d, after)
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && t.now().Add(d).After(deadline) {
+		return 0, false
+	}
+	return d, true
+}
+
+// retryAfter parses a Retry-After header, given in seconds or as an HTTP
+// date.
+func (t *Transport) retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(v); err == nil {
+		return max(when.Sub(t.now()), 0), true
+	}
+	return 0, false
+}
+
+// rewind returns req ready to be sent again, with a fresh body.
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = body
+	return req, nil
+}
+
+// equalJitter returns a random duration between d/2 and d, so that clients
+// failing together do not retry in lockstep.
+func equalJitter(d time.Duration) time.Duration {
+	if d <= 1 {
+		return d
+	}
+	half := d / 2
+	return half + rand.N(d-half)
+}
+
+// sleep waits for d, returning early with the error of ctx if it ends first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelBody cancels the context of an attempt once its body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and ends the attempt's context.
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,275 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRoundTripper answers requests with scripted results, in order, and
+// records the requests it received.
+type fakeRoundTripper struct {
+	results  []result
+	requests []*http.Request
+	bodies   []string
+}
+
+type result struct {
+	status int
+	header http.Header
+	err    error
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+	if req.Body != nil {
+		body, _ := io.ReadAll(req.Body)
+		f.bodies = append(f.bodies, string(body))
+	}
+	r := f.results[len(f.requests)-1]
+	if r.err != nil {
+		return nil, r.err
+	}
+	header := r.header
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: r.status, Header: header, Body: io.NopCloser(strings.NewReader("body")), Request: req}, nil
+}
+
+// newTestTransport returns a Transport around fake that records its
+// delays instead of sleeping and does not add jitter.
+func newTestTransport(fake *fakeRoundTripper, policy Policy) (*Transport, *[]time.Duration) {
+	var delays []time.Duration
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	t := NewTransport(fake, policy)
+	t.now = func() time.Time { return now }
+	t.jitter = func(d time.Duration) time.Duration { return d }
+	t.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return t, &delays
+}
+
+var testPolicy = Policy{Attempts: 4, Backoff: 100 * time.Millisecond, MaxDelay: time.Second}
+
+func get(t *testing.T, ctx context.Context, rt http.RoundTripper) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.example.com/v1/forecast", nil)
+	require.NoError(t, err)
+	return rt.RoundTrip(req)
+}
+
+func TestTransport_RetriesTransientFailures(t *testing.T) {
+	connReset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	fake := &fakeRoundTripper{results: []result{
+		{status: http.StatusBadGateway},
+		{err: connReset},
+		{status: http.StatusServiceUnavailable},
+		{status: http.StatusOK},
+	}}
+	rt, delays := newTestTransport(fake, testPolicy)
+
+	resp, err := get(t, context.Background(), rt)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, fake.requests, 4)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}, *delays)
+}
+
+func TestTransport_GivesUpAfterAttempts(t *testing.T) {
+	fake := &fakeRoundTripper{results: []result{
+		{status: http.StatusBadGateway},
+		{status: http.StatusBadGateway},
+		{status: http.StatusGatewayTimeout},
+	}}
+	rt, _ := newTestTransport(fake, Policy{Attempts: 3, Backoff: 100 * time.Millisecond, MaxDelay: time.Second})
+
+	resp, err := get(t, context.Background(), rt)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode, "the last response is returned")
+	assert.Len(t, fake.requests, 3)
+}
+
+func TestTransport_DoesNotRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		result result
+	}{
+		{name: "success", result: result{status: http.StatusOK}},
+		{name: "not found", result: result{status: http.StatusNotFound}},
+		{name: "bad request", result: result{status: http.StatusBadRequest}},
+		{name: "not implemented", result: result{status: http.StatusNotImplemented}},
+		{name: "permanent error", result: result{err: errors.New("unsupported protocol scheme")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeRoundTripper{results: []result{tt.result, {status: http.StatusOK}}}
+			rt, _ := newTestTransport(fake, testPolicy)
+
+			_, _ = get(t, context.Background(), rt)
+			assert.Len(t, fake.requests, 1)
+		})
+	}
+}
+
+func TestTransport_OnlyRetriesIdempotentRequests(t *testing.T) {
+	fake := &fakeRoundTripper{results: []result{{status: http.StatusBadGateway}, {status: http.StatusOK}}}
+	rt, _ := newTestTransport(fake, testPolicy)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.example.com/v1/forecast", strings.NewReader("q"))
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Len(t, fake.requests, 1)
+}
+
+func TestTransport_ReplaysBody(t *testing.T) {
+	fake := &fakeRoundTripper{results: []result{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}}}
+	rt, _ := newTestTransport(fake, testPolicy)
+
+	req, err := http.NewRequest(http.MethodPut, "https://api.example.com/v1/item", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"payload", "payload"}, fake.bodies)
+}
+
+func TestTransport_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		retryAfter string
+		want       []time.Duration
+		requests   int
+	}{
+		{name: "seconds", retryAfter: "1", want: []time.Duration{time.Second}, requests: 2},
+		{name: "shorter than the backoff", retryAfter: "0", want: []time.Duration{100 * time.Millisecond}, requests: 2},
+		{name: "http date", retryAfter: "Thu, 01 Jan 2026 12:00:01 GMT", want: []time.Duration{time.Second}, requests: 2},
+		{name: "longer than MaxDelay", retryAfter: "120", want: nil, requests: 1},
+		{name: "invalid", retryAfter: "soon", want: []time.Duration{100 * time.Millisecond}, requests: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeRoundTripper{results: []result{
+				{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": {tt.retryAfter}}},
+				{status: http.StatusOK},
+			}}
+			rt, delays := newTestTransport(fake, testPolicy)
+
+			_, err := get(t, context.Background(), rt)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *delays)
+			assert.Len(t, fake.requests, tt.requests)
+		})
+	}
+}
+
+func TestTransport_BackoffIsCapped(t *testing.T) {
+	fake := &fakeRoundTripper{results: []result{
+		{status: http.StatusBadGateway}, {status: http.StatusBadGateway}, {status: http.StatusBadGateway}, {status: http.StatusOK},
+	}}
+	rt, delays := newTestTransport(fake, Policy{Attempts: 4, Backoff: 400 * time.Millisecond, MaxDelay: time.Second})
+
+	_, err := get(t, context.Background(), rt)
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{400 * time.Millisecond, 800 * time.Millisecond, time.Second}, *delays)
+}
+
+func TestTransport_StopsAtDeadline(t *testing.T) {
+	fake := &fakeRoundTripper{results: []result{{status: http.StatusBadGateway}, {status: http.StatusBadGateway}, {status: http.StatusOK}}}
+	rt, delays := newTestTransport(fake, testPolicy)
+	rt.now = time.Now
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	resp, err := get(t, ctx, rt)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode, "the second retry would end after the deadline")
+	assert.Equal(t, []time.Duration{100 * time.Millisecond}, *delays)
+	assert.Len(t, fake.requests, 2)
+}
+
+func TestTransport_StopsWhenCanceled(t *testing.T) {
+	fake := &fakeRoundTripper{results: []result{{status: http.StatusBadGateway}, {status: http.StatusOK}}}
+	rt := NewTransport(fake, Policy{Attempts: 3, Backoff: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	rt.sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return sleep(ctx, d)
+	}
+
+	_, err := get(t, ctx, rt)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, fake.requests, 1)
+}
+
+func TestTransport_AttemptTimeout(t *testing.T) {
+	slow := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+	calls := 0
+	rt := NewTransport(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return slow(req)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok"))}, nil
+	}), Policy{Attempts: 2, Backoff: time.Millisecond, AttemptTimeout: 10 * time.Millisecond})
+
+	resp, err := get(t, context.Background(), rt)
+
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err, "the body is readable until it is closed")
+	assert.Equal(t, "ok", string(body))
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, 2, calls)
+}
+
+func TestTransport_Disabled(t *testing.T) {
+	fake := &fakeRoundTripper{results: []result{{status: http.StatusBadGateway}, {status: http.StatusOK}}}
+	rt, _ := newTestTransport(fake, Policy{Attempts: 1})
+
+	resp, err := get(t, context.Background(), rt)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Len(t, fake.requests, 1)
+}
+
+func TestEqualJitter(t *testing.T) {
+	for range 100 {
+		d := equalJitter(time.Second)
+		assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+		assert.Less(t, d, time.Second)
+	}
+	assert.Equal(t, time.Duration(0), equalJitter(0))
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
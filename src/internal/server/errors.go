@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/ui"
+	"weather-reporter/src/internal/weather"
+)
+
+// statusClientClosedRequest is the non-standard status logged for requests
+// whose client went away before they were answered.
+const statusClientClosedRequest = 499
+
+// errorDocument is the JSON body of a failed request.
+type errorDocument struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+// fail writes the response for an endpoint that returned err. Ambiguous
+// locations are answered with 300 Multiple Choices and the candidates in
+// the format of GET /v1/search, so that the client can choose one.
+func (a *api) fail(w http.ResponseWriter, r *http.Request, err error) {
+	var ambiguous *geo.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		respond(w, http.StatusMultipleChoices, func(out io.Writer) error {
+			return ui.PrintLocationsJSON(out, ambiguous.Query, ambiguous.Candidates)
+		})
+		return
+	}
+
+	status := errorStatus(err)
+	var openErr *breaker.OpenError
+	if errors.As(err, &openErr) && openErr.RetryIn > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(openErr.RetryIn.Seconds()))))
+	}
+	if status >= http.StatusInternalServerError && a.opts.ErrorLog != nil {
+		_, _ = fmt.Fprintf(a.opts.ErrorLog, "%s %s: %d %s\n", r.Method, r.URL.RequestURI(), status, errorDetail(err))
+	}
+	writeError(w, status, err.Error())
+}
+
+// errorStatus returns the HTTP status for a failed request. Errors not
+// caused by the request come from the upstream services.
+func errorStatus(err error) int {
+	var reqErr *requestError
+	switch {
+	case errors.As(err, &reqErr), errors.Is(err, geo.ErrBadRequest), errors.Is(err, weather.ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, geo.ErrLocationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, geo.ErrConcurrencyLimit), errors.Is(err, weather.ErrConcurrencyLimit):
+		return http.StatusTooManyRequests
+	case errors.Is(err, breaker.ErrOpen), errors.Is(err, geo.ErrRateLimited), errors.Is(err, weather.ErrRateLimited):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, geo.ErrTimeout), errors.Is(err, weather.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, geo.ErrCanceled), errors.Is(err, context.Canceled):
+		return statusClientClosedRequest
+	}
+	return http.StatusBadGateway
+}
+
+// errorDetail returns the cause of err for the error log.
+func errorDetail(err error) string {
+	var searchErr *geo.SearchError
+	if errors.As(err, &searchErr) {
+		return searchErr.Detail()
+	}
+	return err.Error()
+}
+
+// writeError writes an error response.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	respond(w, status, func(out io.Writer) error {
+		return json.NewEncoder(out).Encode(errorDocument{Status: status, Error: msg})
+	})
+}
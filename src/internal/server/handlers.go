@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"weather-reporter/src/internal/config"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/params"
+	"weather-reporter/src/internal/ui"
+	"weather-reporter/src/internal/weather"
+)
+
+const (
+	// defaultForecastDays and defaultForecastHours are the forecast
+	// horizons used when a request does not give one.
+	defaultForecastDays  = 7
+	defaultForecastHours = 24
+)
+
+// api implements the endpoints.
+type api struct {
+	opts     Options
+	resolver geo.Resolver
+}
+
+// handle adapts an endpoint to http.Handler. The endpoint runs with the
+// request timeout, and the error it returns is written as the response.
+func (a *api) handle(endpoint func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), a.opts.RequestTimeout)
+		defer cancel()
+		if err := endpoint(w, r.WithContext(ctx)); err != nil {
+			a.fail(w, r, err)
+		}
+	})
+}
+
+// search implements GET /v1/search.
+func (a *api) search(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	name := strings.TrimSpace(q.Get("q"))
+	if name == "" {
+		return badRequest("q is required")
+	}
+	count, err := intParam(q, "count", 0, 1, config.MaxSearchCount)
+	if err != nil {
+		return err
+	}
+	query, err := searchParams(q).Query()
+	if err != nil {
+		return badRequest("%v", err)
+	}
+	query.Options.Count = count
+
+	locations, err := geo.SearchQuery(r.Context(), a.opts.Geocoder, name, query.Filter, query.Options)
+	if err != nil {
+		return err
+	}
+	// Filtering may have requested more results than asked for.
+	if count > 0 && len(locations) > count {
+		locations = locations[:count]
+	}
+	respond(w, http.StatusOK, func(out io.Writer) error {
+		return ui.PrintLocationsJSON(out, name, locations)
+	})
+	return nil
+}
+
+// weather implements GET /v1/weather.
+func (a *api) weather(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	units, err := a.units(q)
+	if err != nil {
+		return err
+	}
+	loc, err := a.locate(r.Context(), q)
+	if err != nil {
+		return err
+	}
+
+	current, err := a.opts.Weather.GetCurrentWeather(r.Context(), loc.Latitude, loc.Longitude, units)
+	if err != nil {
+		return err
+	}
+	respond(w, http.StatusOK, func(out io.Writer) error {
+		return ui.PrintWeatherJSON(out, loc, current)
+	})
+	return nil
+}
+
+// dailyForecast implements GET /v1/forecast/daily.
+func (a *api) dailyForecast(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	days, err := intParam(q, "days", defaultForecastDays, 1, weather.MaxForecastDays)
+	if err != nil {
+		return err
+	}
+	units, err := a.units(q)
+	if err != nil {
+		return err
+	}
+	loc, err := a.locate(r.Context(), q)
+	if err != nil {
+		return err
+	}
+
+	forecast, err := a.opts.Weather.GetDailyForecast(r.Context(), loc.Latitude, loc.Longitude, days, units)
+	if err != nil {
+		return err
+	}
+	respond(w, http.StatusOK, func(out io.Writer) error {
+		return ui.PrintDailyForecastJSON(out, loc, forecast)
+	})
+	return nil
+}
+
+// hourlyForecast implements GET /v1/forecast/hourly.
+func (a *api) hourlyForecast(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	hours, err := intParam(q, "hours", defaultForecastHours, 1, weather.MaxForecastHours)
+	if err != nil {
+		return err
+	}
+	units, err := a.units(q)
+	if err != nil {
+		return err
+	}
+	loc, err := a.locate(r.Context(), q)
+	if err != nil {
+		return err
+	}
+
+	forecast, err := a.opts.Weather.GetHourlyForecast(r.Context(), loc.Latitude, loc.Longitude, hours, units)
+	if err != nil {
+		return err
+	}
+	respond(w, http.StatusOK, func(out io.Writer) error {
+		return ui.PrintHourlyForecastJSON(out, loc, forecast)
+	})
+	return nil
+}
+
+// locate resolves the location given by the location parameter, or by lat
+// and lon. Ambiguous names fail with a *geo.AmbiguousError unless a pick
+// parameter chooses between the matches.
+func (a *api) locate(ctx context.Context, q url.Values) (models.Location, error) {
+	text := strings.TrimSpace(q.Get("location"))
+	if q.Has("lat") || q.Has("lon") {
+		if text != "" {
+			return models.Location{}, badRequest("location cannot be combined with lat and lon")
+		}
+		lat, lon, err := coordinates(q)
+		if err != nil {
+			return models.Location{}, err
+		}
+		return a.resolver.Locate(ctx, lat, lon), nil
+	}
+	if text == "" {
+		return models.Location{}, badRequest("location, or lat and lon, is required")
+	}
+	query, err := params.Location{Name: text, Search: searchParams(q), Pick: q.Get("pick")}.Query()
+	if err != nil {
+		return models.Location{}, badRequest("%v", err)
+	}
+	return a.resolver.Resolve(ctx, query)
+}
+
+// searchParams returns the lang, country and region parameters.
+func searchParams(q url.Values) params.Search {
+	return params.Search{Country: q.Get("country"), Region: q.Get("region"), Language: q.Get("lang")}
+}
+
+// coordinates parses the lat and lon parameters.
+func coordinates(q url.Values) (lat, lon float64, err error) {
+	if q.Get("lat") == "" || q.Get("lon") == "" {
+		return 0, 0, badRequest("lat and lon must be used together")
+	}
+	if lat, err = strconv.ParseFloat(q.Get("lat"), 64); err != nil {
+		return 0, 0, badRequest("invalid lat %q: must be a number in decimal degrees", q.Get("lat"))
+	}
+	if lon, err = strconv.ParseFloat(q.Get("lon"), 64); err != nil {
+		return 0, 0, badRequest("invalid lon %q: must be a number in decimal degrees", q.Get("lon"))
+	}
+	if err := geo.ValidateCoordinates(lat, lon); err != nil {
+		return 0, 0, badRequest("%v", err)
+	}
+	return lat, lon, nil
+}
+
+// units returns the units selected by the units, temp_unit, wind_unit and
+// precip_unit parameters, starting from the configured units.
+func (a *api) units(q url.Values) (models.Units, error) {
+	units, err := params.Units{
+		System:        q.Get("units"),
+		Temperature:   q.Get("temp_unit"),
+		WindSpeed:     q.Get("wind_unit"),
+		Precipitation: q.Get("precip_unit"),
+	}.Apply(a.opts.Units)
+	if err != nil {
+		return models.Units{}, badRequest("%v", err)
+	}
+	return units, nil
+}
+
+// intParam parses the integer parameter name, which must be between lo
+// and hi. It returns def if the parameter is missing.
+func intParam(q url.Values, name string, def, lo, hi int) (int, error) {
+	v := q.Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < lo || n > hi {
+		return 0, badRequest("%s must be between %d and %d", name, lo, hi)
+	}
+	return n, nil
+}
+
+// respond writes a JSON response with the given status, written by
+// write. Errors writing it can only be caused by the client and are
+// ignored.
+func respond(w http.ResponseWriter, status int, write func(io.Writer) error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = write(w)
+}
+
+// requestError is an invalid request.
+type requestError struct {
+	msg string
+}
+
+// Error returns the message for the client.
+func (e *requestError) Error() string {
+	return e.msg
+}
+
+func badRequest(format string, args ...any) error {
+	return &requestError{msg: fmt.Sprintf(format, args...)}
+}
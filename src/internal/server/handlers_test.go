@@ -0,0 +1,293 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/testutil"
+	"weather-reporter/src/internal/weather"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAPI struct {
+	geo     *testutil.Geocoder
+	weather *testutil.WeatherService
+	log     bytes.Buffer
+	handler http.Handler
+}
+
+func newTestAPI(opts Options) *testAPI {
+	a := &testAPI{
+		geo:     testutil.NewGeocoder(),
+		weather: &testutil.WeatherService{},
+	}
+	opts.Geocoder, opts.Weather, opts.Reverse, opts.ErrorLog = a.geo, a.weather, testutil.ReverseGeocoder{}, &a.log
+	a.handler = NewHandler(opts)
+	return a
+}
+
+// get sends a GET request for target and decodes the JSON response.
+func (a *testAPI) get(t *testing.T, target string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	a.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc), rec.Body.String())
+	return rec, doc
+}
+
+func TestWeather(t *testing.T) {
+	a := newTestAPI(Options{})
+
+	rec, doc := a.get(t, "/v1/weather?location=Berlin")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.EqualValues(t, 1, doc["schema_version"])
+	assert.Equal(t, "Berlin", doc["location"].(map[string]any)["name"])
+	assert.Equal(t, 2.5, doc["current"].(map[string]any)["temperature"].(map[string]any)["value"])
+	assert.Equal(t, models.MetricUnits(), a.weather.Units)
+	assert.Equal(t, 52.52, a.weather.Lat)
+}
+
+func TestWeather_Coordinates(t *testing.T) {
+	a := newTestAPI(Options{})
+
+	rec, doc := a.get(t, "/v1/weather?lat=52.5&lon=13.4")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	location := doc["location"].(map[string]any)
+	assert.Equal(t, 52.5, location["latitude"])
+	assert.Equal(t, "Berlin", location["near"].(map[string]any)["name"])
+	assert.Equal(t, 13.4, a.weather.Lon)
+}
+
+func TestWeather_Units(t *testing.T) {
+	a := newTestAPI(Options{Units: models.ImperialUnits()})
+
+	a.get(t, "/v1/weather?location=Berlin")
+	assert.Equal(t, models.ImperialUnits(), a.weather.Units, "configured units")
+
+	a.get(t, "/v1/weather?location=Berlin&units=metric&wind_unit=knots")
+	assert.Equal(t, models.Units{Temperature: models.Celsius, WindSpeed: models.Knots, Precipitation: models.Millimetres}, a.weather.Units)
+}
+
+func TestWeather_Ambiguous(t *testing.T) {
+	a := newTestAPI(Options{})
+
+	rec, doc := a.get(t, "/v1/weather?location=Portland")
+
+	assert.Equal(t, http.StatusMultipleChoices, rec.Code)
+	assert.Equal(t, "Portland", doc["query"])
+	require.Len(t, doc["results"], 2)
+	assert.Equal(t, "Maine", doc["results"].([]any)[1].(map[string]any)["admin1"])
+	assert.Zero(t, a.weather.Lat, "no weather is fetched")
+
+	rec, doc = a.get(t, "/v1/weather?location=Portland&pick=2")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Maine", doc["location"].(map[string]any)["admin1"])
+
+	rec, _ = a.get(t, "/v1/weather?location=Portland&region=OR")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 45.52, a.weather.Lat)
+
+	rec, doc = a.get(t, "/v1/weather?location=Portland&pick=5")
+	assert.Equal(t, http.StatusMultipleChoices, rec.Code, "a pick out of range still lists the candidates")
+	assert.Len(t, doc["results"], 2)
+}
+
+func TestWeather_NotFound(t *testing.T) {
+	a := newTestAPI(Options{})
+
+	rec, doc := a.get(t, "/v1/weather?location=Atlantis")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, map[string]any{"status": 404.0, "error": "location not found: Atlantis"}, doc)
+}
+
+func TestWeather_BadRequest(t *testing.T) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{"", "location, or lat and lon, is required"},
+		{"location=Berlin&lat=1&lon=2", "location cannot be combined with lat and lon"},
+		{"lat=52.5", "lat and lon must be used together"},
+		{"lat=north&lon=2", `invalid lat "north": must be a number in decimal degrees`},
+		{"lat=95&lon=2", "invalid latitude 95: must be between -90 and 90"},
+		{"location=95,13", "invalid latitude 95: must be between -90 and 90"},
+		{"location=Berlin&units=kelvin", `unknown unit system "kelvin" (must be metric or imperial)`},
+		{"location=Berlin&temp_unit=k", `unknown temperature unit "k"`},
+		{"location=Berlin&pick=best", `invalid pick "best": must be first, a position such as 2, most-populous or closest-to=LAT,LON`},
+		{"location=Berlin&lang=english", `language: must be a two-letter ISO 639-1 language code such as en or de, got "english"`},
+	}
+	a := newTestAPI(Options{})
+
+	for _, tt := range tests {
+		rec, doc := a.get(t, "/v1/weather?"+tt.query)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code, tt.query)
+		assert.Equal(t, tt.err, doc["error"], tt.query)
+	}
+	assert.Empty(t, a.log.String(), "client errors are not logged")
+}
+
+func TestForecast(t *testing.T) {
+	a := newTestAPI(Options{})
+
+	rec, doc := a.get(t, "/v1/forecast/daily?location=Berlin")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, defaultForecastDays, a.weather.N)
+	assert.Equal(t, "2026-01-01", doc["daily"].([]any)[0].(map[string]any)["date"])
+
+	rec, _ = a.get(t, "/v1/forecast/daily?location=Berlin&days=3")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 3, a.weather.N)
+
+	rec, doc = a.get(t, "/v1/forecast/hourly?lat=52.5&lon=13.4&hours=48")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 48, a.weather.N)
+	assert.Len(t, doc["hourly"], 1)
+
+	rec, doc = a.get(t, "/v1/forecast/daily?location=Berlin&days=17")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "days must be between 1 and 16", doc["error"])
+
+	rec, doc = a.get(t, "/v1/forecast/hourly?location=Berlin&hours=0")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "hours must be between 1 and 168", doc["error"])
+}
+
+func TestSearch(t *testing.T) {
+	a := newTestAPI(Options{})
+
+	rec, doc := a.get(t, "/v1/search?q=Portland&lang=DE&country=us&count=1")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "Portland", doc["query"])
+	assert.Len(t, doc["results"], 1)
+	assert.Equal(t, models.SearchOptions{Count: 1, Language: "de", CountryCode: "US"}, a.geo.Opts)
+
+	rec, doc = a.get(t, "/v1/search?q=Atlantis")
+	assert.Equal(t, http.StatusOK, rec.Code, "no match is not an error")
+	assert.Empty(t, doc["results"])
+
+	rec, doc = a.get(t, "/v1/search?q=")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "q is required", doc["error"])
+
+	rec, _ = a.get(t, "/v1/search?q=Berlin&count=101")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestUpstreamErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		logged string
+	}{
+		{"search failed", &geo.SearchError{Kind: geo.ErrUpstream, StatusCode: 502, Err: errors.New("unexpected status code: 502")},
+			http.StatusBadGateway, "GET /v1/weather?location=Berlin: 502 geocoding service error (HTTP 502): unexpected status code: 502\n"},
+		{"search rejected", &geo.SearchError{Kind: geo.ErrBadRequest, Err: errors.New("bad")}, http.StatusBadRequest, ""},
+		{"rate limited", &geo.SearchError{Kind: geo.ErrRateLimited, Err: errors.New("429")}, http.StatusServiceUnavailable, "GET /v1/weather?location=Berlin: 503 rate limited: 429\n"},
+		{"too many searches", &geo.SearchError{Kind: geo.ErrConcurrencyLimit, Err: errors.New("limit")}, http.StatusTooManyRequests, ""},
+		{"search timed out", &geo.SearchError{Kind: geo.ErrTimeout, Err: context.DeadlineExceeded}, http.StatusGatewayTimeout, "GET /v1/weather?location=Berlin: 504 timeout: context deadline exceeded\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAPI(Options{})
+			a.geo.Err = tt.err
+
+			rec, doc := a.get(t, "/v1/weather?location=Berlin")
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.err.Error(), doc["error"])
+			assert.Equal(t, tt.logged, a.log.String())
+		})
+	}
+}
+
+func TestWeatherErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"unknown failure", errors.New("weather API returned 500"), http.StatusBadGateway},
+		{"service failed", &weather.RequestError{Kind: weather.ErrUpstream, StatusCode: 500, Err: errors.New("API returned status 500")}, http.StatusBadGateway},
+		{"request rejected", &weather.RequestError{Kind: weather.ErrBadRequest, Err: errors.New("invalid number of days: 17")}, http.StatusBadRequest},
+		{"rate limited", &weather.RequestError{Kind: weather.ErrRateLimited, StatusCode: 429, Err: errors.New("API returned status 429")}, http.StatusServiceUnavailable},
+		{"timed out", &weather.RequestError{Kind: weather.ErrTimeout, Err: errors.New("i/o timeout")}, http.StatusGatewayTimeout},
+		{"too many requests", &weather.RequestError{Kind: weather.ErrConcurrencyLimit, Err: errors.New("limit")}, http.StatusTooManyRequests},
+		{"breaker open", &breaker.OpenError{Name: "weather", RetryIn: time.Second}, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAPI(Options{})
+			a.weather.Err = tt.err
+
+			rec, doc := a.get(t, "/v1/weather?location=Berlin")
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.err.Error(), doc["error"])
+		})
+	}
+}
+
+func TestWeatherErrors_RetryAfter(t *testing.T) {
+	a := newTestAPI(Options{})
+	a.weather.Err = &breaker.OpenError{Name: "weather", RetryIn: 12500 * time.Millisecond}
+	rec, _ := a.get(t, "/v1/weather?location=Berlin")
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "13", rec.Header().Get("Retry-After"))
+
+	a.weather.Err = &breaker.OpenError{Name: "weather", Trial: true}
+	rec, _ = a.get(t, "/v1/weather?location=Berlin")
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+}
+
+func TestRequestTimeout(t *testing.T) {
+	a := newTestAPI(Options{RequestTimeout: 10 * time.Millisecond})
+	a.weather.Delay = time.Second
+
+	rec, doc := a.get(t, "/v1/weather?location=Berlin")
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Equal(t, "context deadline exceeded", doc["error"])
+}
+
+func TestHealthAndUnknownEndpoints(t *testing.T) {
+	a := newTestAPI(Options{})
+
+	rec, doc := a.get(t, "/healthz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", doc["status"])
+
+	rec, doc = a.get(t, "/v2/weather")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "no such endpoint: /v2/weather", doc["error"])
+
+	rec, _ = a.get(t, "/debug/vars")
+	assert.Equal(t, http.StatusNotFound, rec.Code, "metrics are only served by the debug handler")
+
+	rec = httptest.NewRecorder()
+	a.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/weather", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
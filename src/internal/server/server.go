@@ -0,0 +1,143 @@
+// Package server exposes the geocoding and weather services as a JSON HTTP
+// API, so that weather-reporter can run as a long-lived service.
+package server
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"weather-reporter/src/internal/geo"
+	"weather-reporter/src/internal/models"
+)
+
+const (
+	// DefaultRequestTimeout bounds a request when Options.RequestTimeout
+	// is zero.
+	DefaultRequestTimeout = 30 * time.Second
+
+	// writeTimeoutMargin is added to the request timeout for the server's
+	// write timeout, so that a request that times out can still be
+	// answered.
+	writeTimeoutMargin = 5 * time.Second
+)
+
+// Options configure the API.
+type Options struct {
+	// Geocoder searches locations by name.
+	Geocoder models.GeocodingService
+	// Reverse, if set, names the place nearest to requested coordinates.
+	Reverse models.ReverseGeocodingService
+	// Weather fetches current weather and forecasts.
+	Weather models.WeatherService
+	// Units are used when a request does not select any. The zero value
+	// means metric units.
+	Units models.Units
+	// RequestTimeout bounds each request, including all upstream calls.
+	RequestTimeout time.Duration
+	// ErrorLog, if set, receives a line for every request that fails with
+	// a server or upstream error.
+	ErrorLog io.Writer
+}
+
+// NewHandler returns the handler serving the API:
+//
+//	GET /v1/search?q=NAME
+//	GET /v1/weather?location=NAME or ?lat=LAT&lon=LON
+//	GET /v1/forecast/daily?location=NAME&days=N
+//	GET /v1/forecast/hourly?location=NAME&hours=N
+//	GET /healthz
+//
+// The parameters are documented in README.md. The runtime metrics are
+// served separately by NewDebugHandler.
+func NewHandler(opts Options) http.Handler {
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = DefaultRequestTimeout
+	}
+	a := &api{
+		opts:     opts,
+		resolver: geo.Resolver{Geocoder: opts.Geocoder, Reverse: opts.Reverse},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /v1/search", a.handle(a.search))
+	mux.Handle("GET /v1/weather", a.handle(a.weather))
+	mux.Handle("GET /v1/forecast/daily", a.handle(a.dailyForecast))
+	mux.Handle("GET /v1/forecast/hourly", a.handle(a.hourlyForecast))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, _ = io.WriteString(w, `{"status":"ok"}`+"\n")
+	})
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no such endpoint: "+r.URL.Path)
+	})
+	return mux
+}
+
+// NewDebugHandler returns the handler serving the runtime metrics at
+// GET /debug/vars. They include the command line and memory statistics of
+// the process, so it belongs on an address that only operators can reach.
+func NewDebugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "no such endpoint: "+r.URL.Path)
+	})
+	return mux
+}
+
+// NewDebug returns an HTTP server for the runtime metrics listening on
+// addr.
+func NewDebug(addr string) *http.Server {
+	return &http.Server{
+		Addr:              addr,
+		Handler:           NewDebugHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      DefaultRequestTimeout,
+		IdleTimeout:       2 * time.Minute,
+	}
+}
+
+// New returns an HTTP server for the API listening on addr, with timeouts
+// derived from opts.RequestTimeout.
+func New(addr string, opts Options) *http.Server {
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = DefaultRequestTimeout
+	}
+	return &http.Server{
+		Addr:              addr,
+		Handler:           NewHandler(opts),
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      opts.RequestTimeout + writeTimeoutMargin,
+		IdleTimeout:       2 * time.Minute,
+	}
+}
+
+// Serve serves srv on ln until ctx is done, then shuts it down
+// gracefully: it stops accepting connections and waits up to
+// shutdownTimeout for requests in flight to finish.
+func Serve(ctx context.Context, srv *http.Server, ln net.Listener, shutdownTimeout time.Duration) error {
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
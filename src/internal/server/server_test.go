@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	srv := New("localhost:8080", Options{RequestTimeout: 20 * time.Second})
+
+	assert.Equal(t, "localhost:8080", srv.Addr)
+	assert.Equal(t, 25*time.Second, srv.WriteTimeout)
+	assert.Positive(t, srv.ReadHeaderTimeout)
+
+	srv = New(":0", Options{})
+	assert.Equal(t, DefaultRequestTimeout+writeTimeoutMargin, srv.WriteTimeout)
+}
+
+func TestNewDebugHandler(t *testing.T) {
+	h := NewDebugHandler()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"memstats"`)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/weather", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestServe_GracefulShutdown(t *testing.T) {
+	a := newTestAPI(Options{})
+	a.weather.Delay = 200 * time.Millisecond
+	srv := &http.Server{Handler: a.handler}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- Serve(ctx, srv, ln, 5*time.Second) }()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	inFlight := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/v1/weather?location=Berlin")
+		if err != nil {
+			inFlight <- result{err: err}
+			return
+		}
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		inFlight <- result{status: resp.StatusCode, body: string(body), err: err}
+	}()
+
+	// Shut down while the request waits for the weather.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	require.NoError(t, <-served)
+	r := <-inFlight
+	require.NoError(t, r.err)
+	assert.Equal(t, http.StatusOK, r.status, "requests in flight are finished")
+	assert.Contains(t, r.body, `"current"`)
+
+	_, err = http.Get("http://" + ln.Addr().String() + "/healthz")
+	assert.Error(t, err, "no new connections are accepted")
+}
+
+func TestServe_ListenerError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+
+	err = Serve(context.Background(), &http.Server{}, ln, time.Second)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+// Package testutil provides fake geocoding and weather services for the
+// tests of the packages serving the APIs.
+package testutil
+
+import (
+	"context"
+	"time"
+
+	"weather-reporter/src/internal/models"
+)
+
+var (
+	// Berlin is the single match of "Berlin", and the place that
+	// ReverseGeocoder names for every point.
+	Berlin = models.Location{ID: 2950159, Name: "Berlin", Latitude: 52.52, Longitude: 13.41, Country: "Germany", CountryCode: "DE", Region: "Berlin", Timezone: "Europe/Berlin"}
+	// Portlands are the two matches of the ambiguous "Portland".
+	Portlands = []models.Location{
+		{ID: 5746545, Name: "Portland", Latitude: 45.52, Longitude: -122.68, Country: "United States", CountryCode: "US", Region: "Oregon", Population: 632309},
+		{ID: 4975802, Name: "Portland", Latitude: 43.66, Longitude: -70.26, Country: "United States", CountryCode: "US", Region: "Maine", Population: 66881},
+	}
+)
+
+// Geocoder returns the locations registered for a name.
+type Geocoder struct {
+	Results map[string][]models.Location
+	Err     error
+	Opts    models.SearchOptions // of the last search
+}
+
+// NewGeocoder returns a Geocoder that knows Berlin and Portland.
+func NewGeocoder() *Geocoder {
+	return &Geocoder{Results: map[string][]models.Location{
+		"Berlin":   {Berlin},
+		"Portland": Portlands,
+	}}
+}
+
+// Search returns the locations registered for name, or Err.
+func (g *Geocoder) Search(_ context.Context, name string, opts models.SearchOptions) ([]models.Location, error) {
+	g.Opts = opts
+	return g.Results[name], g.Err
+}
+
+// ReverseGeocoder names every point Berlin.
+type ReverseGeocoder struct{}
+
+// Reverse returns Berlin.
+func (ReverseGeocoder) Reverse(context.Context, float64, float64) (models.Location, error) {
+	return Berlin, nil
+}
+
+// WeatherService returns fixed data, recording the arguments of the last
+// call.
+type WeatherService struct {
+	Err   error
+	Delay time.Duration
+
+	Lat, Lon float64
+	N        int // days or hours
+	Units    models.Units
+	Calls    int
+}
+
+func (f *WeatherService) record(ctx context.Context, lat, lon float64, n int, units models.Units) error {
+	f.Calls++
+	f.Lat, f.Lon, f.N, f.Units = lat, lon, n, units
+	if f.Delay > 0 {
+		select {
+		case <-time.After(f.Delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return f.Err
+}
+
+// GetCurrentWeather returns a WeatherResponse.
+func (f *WeatherService) GetCurrentWeather(ctx context.Context, lat, lon float64, units models.Units) (models.WeatherResponse, error) {
+	if err := f.record(ctx, lat, lon, 0, units); err != nil {
+		return nil, err
+	}
+	return WeatherResponse{}, nil
+}
+
+// GetDailyForecast returns a single day, whose maximum wind speed is
+// missing.
+func (f *WeatherService) GetDailyForecast(ctx context.Context, lat, lon float64, days int, units models.Units) ([]models.DailyForecast, error) {
+	if err := f.record(ctx, lat, lon, days, units); err != nil {
+		return nil, err
+	}
+	return []models.DailyForecast{{
+		Date:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		TemperatureMin: models.Measurement{Value: -1.5, Unit: "°C"},
+		TemperatureMax: models.Measurement{Value: 3.4, Unit: "°C"},
+		WindSpeedMax:   models.Measurement{Unit: "km/h", Missing: true},
+	}}, nil
+}
+
+// GetHourlyForecast returns a single hour.
+func (f *WeatherService) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int, units models.Units) ([]models.HourlyForecast, error) {
+	if err := f.record(ctx, lat, lon, hours, units); err != nil {
+		return nil, err
+	}
+	return []models.HourlyForecast{{
+		Time:        time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC),
+		Temperature: models.Measurement{Value: 3.1, Unit: "°C"},
+	}}, nil
+}
+
+// WeatherResponse is the current weather returned by WeatherService. Its
+// quantities are fixed.
+type WeatherResponse struct{}
+
+// QuantityOfTemperature returns the temperature.
+func (WeatherResponse) QuantityOfTemperature() string { return "2.5°C" }
+
+// QuantityOfHumidity returns the relative humidity.
+func (WeatherResponse) QuantityOfHumidity() string { return "76%" }
+
+// QuantityOfApparentTemperature returns the apparent temperature.
+func (WeatherResponse) QuantityOfApparentTemperature() string { return "-2.8°C" }
+
+// QuantityOfPrecipitation returns the precipitation.
+func (WeatherResponse) QuantityOfPrecipitation() string { return "0.0 mm" }
+
+// QuantityOfCloudCover returns the cloud cover.
+func (WeatherResponse) QuantityOfCloudCover() string { return "99%" }
+
+// QuantityOfPressure returns the surface pressure.
+func (WeatherResponse) QuantityOfPressure() string { return "997.4 hPa" }
+
+// QuantityOfWindSpeed returns the wind speed.
+func (WeatherResponse) QuantityOfWindSpeed() string { return "20.2 km/h" }
+
+// QuantityOfWindDirection returns the wind direction.
+func (WeatherResponse) QuantityOfWindDirection() string { return "239°" }
+
+// QuantityOfWindGusts returns the wind gusts.
+func (WeatherResponse) QuantityOfWindGusts() string { return "46.1 km/h" }
+
+// Observation returns the observation time and temperature.
+func (WeatherResponse) Observation() models.Observation {
+	return models.Observation{
+		Time:        time.Date(2026, 1, 1, 14, 15, 0, 0, time.UTC),
+		Temperature: models.Measurement{Value: 2.5, Unit: "°C"},
+	}
+}
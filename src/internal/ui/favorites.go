@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"weather-reporter/src/internal/favorites"
+)
+
+// PrintFavorites prints the saved favorites as a table of alias, location
+// and coordinates.
+func PrintFavorites(out io.Writer, list []favorites.Favorite) error {
+	if len(list) == 0 {
+		_, err := fmt.Fprintln(out, "No favorites saved. Add one with: weather-reporter favorites add <alias> <location>")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, f := range list {
+		if _, err := fmt.Fprintf(tw, "@%s\t%s\t%.4f, %.4f\n",
+			f.Alias, FormatLocation(f.Location), f.Location.Latitude, f.Location.Longitude); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+
+	"weather-reporter/src/internal/favorites"
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintFavorites(t *testing.T) {
+	list := []favorites.Favorite{
+		{Alias: "home", Location: models.Location{Name: "Paris", Country: "France", Region: "Île-de-France", Latitude: 48.85341, Longitude: 2.3488}},
+		{Alias: "office", Location: models.Location{Name: "London", Country: "United Kingdom", Region: "England", Latitude: 51.50853, Longitude: -0.12574}},
+	}
+	var out bytes.Buffer
+
+	require.NoError(t, PrintFavorites(&out, list))
+
+	assert.Equal(t, ""+
+		"@home    Paris, France (Île-de-France)     48.8534, 2.3488\n"+
+		"@office  London, United Kingdom (England)  51.5085, -0.1257\n", out.String())
+}
+
+func TestPrintFavorites_Empty(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, PrintFavorites(&out, nil))
+
+	assert.Contains(t, out.String(), "No favorites saved.")
+}
+
+func TestPrintFavorites_Error(t *testing.T) {
+	err := PrintFavorites(errorWriter{}, []favorites.Favorite{{Alias: "home"}})
+	assert.EqualError(t, err, "write error")
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+
+	"weather-reporter/src/internal/models"
+)
+
+// PrintDailyForecast prints a per-day forecast table to the output writer,
+// with labels and numbers written for l.
+func PrintDailyForecast(out io.Writer, l Locale, loc models.Location, days []models.DailyForecast) error {
+	msg := l.msg()
+	if err := printHeader(out, l, msg.ForecastFor, loc); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", msg.Date, msg.Min, msg.Max, msg.Precipitation, msg.MaxWind); err != nil {
+		return err
+	}
+	for _, d := range days {
+		if _, err := fmt.Fprintf(tw, "%s %s\t%s\t%s\t%s\t%s\n",
+			l.weekday(d.Date), d.Date.Format("2006-01-02"), l.measurement(d.TemperatureMin), l.measurement(d.TemperatureMax),
+			l.measurement(d.PrecipitationSum), l.measurement(d.WindSpeedMax)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// PrintHourlyForecast prints a compact hour-by-hour forecast table to the
+// output writer, with labels and numbers written for l. Times are shown in
+// the timezone carried by each entry, which is the location's local
+// timezone.
+func PrintHourlyForecast(out io.Writer, l Locale, loc models.Location, hours []models.HourlyForecast) error {
+	msg := l.msg()
+	if err := printHeader(out, l, msg.HourlyForecastFor, loc); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", msg.Time, msg.Temp, msg.PrecipShort, msg.Wind); err != nil {
+		return err
+	}
+	for _, h := range hours {
+		if _, err := fmt.Fprintf(tw, "%s %s\t%s\t%s\t%s %s\n",
+			l.weekday(h.Time), h.Time.Format("15:04 MST"), l.measurement(h.Temperature), l.measurement(h.PrecipitationProbability),
+			l.measurement(h.WindSpeed), l.windDirection(h.WindDirection)); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// measurement formats m for l, or the placeholder for missing values.
+func (l Locale) measurement(m models.Measurement) string {
+	if m.Missing {
+		return l.msg().NotAvailable
+	}
+	return l.localizeNumbers(m.String())
+}
+
+// windDirection formats a wind direction as a compass point, or the
+// placeholder for missing values.
+func (l Locale) windDirection(m models.Measurement) string {
+	if m.Missing {
+		return l.msg().NotAvailable
+	}
+	return l.compassPoint(m.Value)
+}
+
+// compassPoint converts a direction in degrees to one of the eight
+// principal compass points.
+func (l Locale) compassPoint(degrees float64) string {
+	points := l.msg().CompassPoints
+	index := int(math.Round(math.Mod(degrees, 360)/45)) % len(points)
+	if index < 0 {
+		index += len(points)
+	}
+	return points[index]
+}
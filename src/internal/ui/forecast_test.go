@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func celsius(v float64) models.Measurement { return models.Measurement{Value: v, Unit: "°C"} }
+func mm(v float64) models.Measurement      { return models.Measurement{Value: v, Unit: "mm"} }
+func kmh(v float64) models.Measurement     { return models.Measurement{Value: v, Unit: "km/h"} }
+func percent(v float64) models.Measurement { return models.Measurement{Value: v, Unit: "%"} }
+func degrees(v float64) models.Measurement { return models.Measurement{Value: v, Unit: "°"} }
+
+func TestPrintDailyForecast(t *testing.T) {
+	loc := models.Location{Name: "Berlin", Country: "Germany", Region: "Berlin"}
+	days := []models.DailyForecast{
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), TemperatureMin: celsius(-1.5), TemperatureMax: celsius(3.4), PrecipitationSum: mm(0), WindSpeedMax: kmh(18.3)},
+		{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC), TemperatureMin: celsius(0.2), TemperatureMax: celsius(5.1), PrecipitationSum: mm(2.7), WindSpeedMax: kmh(22)},
+	}
+	var out bytes.Buffer
+
+	err := PrintDailyForecast(&out, Locale{}, loc, days)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "Forecast for Berlin, Germany (Berlin)", lines[0])
+	assert.Equal(t, "Date            Min     Max    Precipitation  Max Wind", strings.TrimRight(lines[2], " "))
+	assert.Equal(t, "Thu 2026-01-01  -1.5°C  3.4°C  0.0 mm         18.3 km/h", lines[3])
+	assert.Equal(t, "Fri 2026-01-02  0.2°C   5.1°C  2.7 mm         22.0 km/h", lines[4])
+}
+
+func TestPrintDailyForecast_ImperialUnits(t *testing.T) {
+	days := []models.DailyForecast{{
+		Date:             time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		TemperatureMin:   models.Measurement{Value: 28.4, Unit: "°F"},
+		TemperatureMax:   models.Measurement{Value: 41.2, Unit: "°F"},
+		PrecipitationSum: models.Measurement{Value: 0.12, Unit: "inch"},
+		WindSpeedMax:     models.Measurement{Value: 15.5, Unit: "mph"},
+	}}
+	var out bytes.Buffer
+
+	assert.NoError(t, PrintDailyForecast(&out, Locale{}, models.Location{Name: "New York"}, days))
+	assert.Contains(t, out.String(), "Thu 2026-01-01  28.4°F  41.2°F  0.12 inch      15.5 mph")
+}
+
+func TestPrintDailyForecast_Error(t *testing.T) {
+	err := PrintDailyForecast(errorWriter{}, Locale{}, models.Location{Name: "Test"}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "write error", err.Error())
+}
+
+func TestPrintHourlyForecast(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	loc := models.Location{Name: "Berlin", Country: "Germany", Region: "Berlin"}
+	hours := []models.HourlyForecast{
+		{Time: time.Date(2026, 1, 1, 14, 0, 0, 0, berlin), Temperature: celsius(3.1), PrecipitationProbability: percent(10), WindSpeed: kmh(12.4), WindDirection: degrees(230)},
+		{Time: time.Date(2026, 1, 1, 15, 0, 0, 0, berlin), Temperature: celsius(-0.5), PrecipitationProbability: percent(100), WindSpeed: kmh(8), WindDirection: degrees(355)},
+	}
+	var out bytes.Buffer
+
+	err := PrintHourlyForecast(&out, Locale{}, loc, hours)
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	assert.Len(t, lines, 5)
+	assert.Equal(t, "Hourly forecast for Berlin, Germany (Berlin)", lines[0])
+	assert.Equal(t, "Time           Temp    Precip.  Wind", lines[2])
+	assert.Equal(t, "Thu 14:00 CET  3.1°C   10%      12.4 km/h SW", lines[3])
+	assert.Equal(t, "Thu 15:00 CET  -0.5°C  100%     8.0 km/h N", lines[4])
+}
+
+func TestPrintForecast_Missing(t *testing.T) {
+	missing := models.Measurement{Unit: "km/h", Missing: true}
+	days := []models.DailyForecast{
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), TemperatureMin: celsius(-1.5), TemperatureMax: celsius(3.4), PrecipitationSum: mm(0), WindSpeedMax: missing},
+	}
+	var out bytes.Buffer
+
+	require.NoError(t, PrintDailyForecast(&out, Locale{}, models.Location{Name: "Berlin"}, days))
+	assert.Contains(t, out.String(), "Thu 2026-01-01  -1.5°C  3.4°C  0.0 mm         n/a")
+
+	hours := []models.HourlyForecast{
+		{Time: time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC), Temperature: celsius(3.1), PrecipitationProbability: percent(10), WindSpeed: missing, WindDirection: models.Measurement{Unit: "°", Missing: true}},
+	}
+	de, err := ParseLocale("de")
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, PrintHourlyForecast(&out, de, models.Location{Name: "Berlin"}, hours))
+	assert.Contains(t, out.String(), "Do 14:00 UTC  3,1°C  10%          k. A. k. A.")
+}
+
+func TestPrintHourlyForecast_Error(t *testing.T) {
+	err := PrintHourlyForecast(errorWriter{}, Locale{}, models.Location{Name: "Test"}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "write error", err.Error())
+}
+
+func TestCompassPoint(t *testing.T) {
+	tests := map[float64]string{0: "N", 22: "N", 23: "NE", 90: "E", 180: "S", 225: "SW", 337.5: "N", 360: "N", -90: "W"}
+	for degrees, want := range tests {
+		assert.Equal(t, want, Locale{}.compassPoint(degrees), "compassPoint(%v)", degrees)
+	}
+}
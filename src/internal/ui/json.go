@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"weather-reporter/src/internal/models"
+)
+
+// WeatherSchemaVersion is the version of the JSON document written by
+// PrintWeatherJSON. It is incremented whenever a field is renamed, removed
+// or changes meaning; adding fields does not change the version.
+const WeatherSchemaVersion = 1
+
+// ForecastSchemaVersion is the version of the JSON documents written by
+// PrintDailyForecastJSON and PrintHourlyForecastJSON. It follows the same
+// rules as WeatherSchemaVersion.
+const ForecastSchemaVersion = 1
+
+// weatherDocument is the JSON representation of a current-weather report.
+type weatherDocument struct {
+	SchemaVersion int              `json:"schema_version"`
+	Location      locationDocument `json:"location"`
+	Current       currentDocument  `json:"current"`
+}
+
+type locationDocument struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Country     string  `json:"country"`
+	CountryCode string  `json:"country_code"`
+	Admin1      string  `json:"admin1"`
+
+	// Details known for places found by name; empty ones are left out.
+	Admin2      string   `json:"admin2,omitempty"`
+	Admin3      string   `json:"admin3,omitempty"`
+	Admin4      string   `json:"admin4,omitempty"`
+	Timezone    string   `json:"timezone,omitempty"`
+	Elevation   float64  `json:"elevation,omitempty"`
+	Population  int      `json:"population,omitempty"`
+	Postcodes   []string `json:"postcodes,omitempty"`
+	FeatureCode string   `json:"feature_code,omitempty"`
+
+	// Near is the nearest known place for coordinate-based locations.
+	Near *locationDocument `json:"near,omitempty"`
+}
+
+type currentDocument struct {
+	Time                time.Time `json:"time"`
+	Temperature         quantity  `json:"temperature"`
+	ApparentTemperature quantity  `json:"apparent_temperature"`
+	Humidity            quantity  `json:"humidity"`
+	Precipitation       quantity  `json:"precipitation"`
+	CloudCover          quantity  `json:"cloud_cover"`
+	Pressure            quantity  `json:"pressure"`
+	WindSpeed           quantity  `json:"wind_speed"`
+	WindDirection       quantity  `json:"wind_direction"`
+	WindGusts           quantity  `json:"wind_gusts"`
+}
+
+// dailyDocument is the JSON representation of a daily forecast.
+type dailyDocument struct {
+	SchemaVersion int              `json:"schema_version"`
+	Location      locationDocument `json:"location"`
+	Daily         []dayDocument    `json:"daily"`
+}
+
+type dayDocument struct {
+	Date             string   `json:"date"` // YYYY-MM-DD in the location's time zone
+	TemperatureMin   quantity `json:"temperature_min"`
+	TemperatureMax   quantity `json:"temperature_max"`
+	PrecipitationSum quantity `json:"precipitation_sum"`
+	WindSpeedMax     quantity `json:"wind_speed_max"`
+}
+
+// hourlyDocument is the JSON representation of an hourly forecast.
+type hourlyDocument struct {
+	SchemaVersion int              `json:"schema_version"`
+	Location      locationDocument `json:"location"`
+	Hourly        []hourDocument   `json:"hourly"`
+}
+
+type hourDocument struct {
+	Time                     time.Time `json:"time"`
+	Temperature              quantity  `json:"temperature"`
+	PrecipitationProbability quantity  `json:"precipitation_probability"`
+	WindSpeed                quantity  `json:"wind_speed"`
+	WindDirection            quantity  `json:"wind_direction"`
+}
+
+// quantity is a numeric value together with its unit. Value is null when
+// the API reported no value.
+type quantity struct {
+	Value *float64 `json:"value"`
+	Unit  string   `json:"unit"`
+}
+
+// PrintWeatherJSON writes the weather information as a versioned JSON
+// document to the output writer. The schema is documented in README.md.
+func PrintWeatherJSON(out io.Writer, loc models.Location, w models.WeatherResponse) error {
+	doc := weatherDocument{
+		SchemaVersion: WeatherSchemaVersion,
+		Location:      newLocationDocument(loc),
+		Current:       newCurrentDocument(w.Observation()),
+	}
+	return writeJSON(out, doc)
+}
+
+// PrintDailyForecastJSON writes a daily forecast as a versioned JSON
+// document. The schema is documented in README.md.
+func PrintDailyForecastJSON(out io.Writer, loc models.Location, days []models.DailyForecast) error {
+	doc := dailyDocument{
+		SchemaVersion: ForecastSchemaVersion,
+		Location:      newLocationDocument(loc),
+		Daily:         make([]dayDocument, 0, len(days)),
+	}
+	for _, day := range days {
+		doc.Daily = append(doc.Daily, dayDocument{
+			Date:             day.Date.Format(time.DateOnly),
+			TemperatureMin:   newQuantity(day.TemperatureMin),
+			TemperatureMax:   newQuantity(day.TemperatureMax),
+			PrecipitationSum: newQuantity(day.PrecipitationSum),
+			WindSpeedMax:     newQuantity(day.WindSpeedMax),
+		})
+	}
+	return writeJSON(out, doc)
+}
+
+// PrintHourlyForecastJSON writes an hourly forecast as a versioned JSON
+// document. Times carry the offset of the location's time zone.
+func PrintHourlyForecastJSON(out io.Writer, loc models.Location, hours []models.HourlyForecast) error {
+	doc := hourlyDocument{
+		SchemaVersion: ForecastSchemaVersion,
+		Location:      newLocationDocument(loc),
+		Hourly:        make([]hourDocument, 0, len(hours)),
+	}
+	for _, hour := range hours {
+		doc.Hourly = append(doc.Hourly, hourDocument{
+			Time:                     hour.Time,
+			Temperature:              newQuantity(hour.Temperature),
+			PrecipitationProbability: newQuantity(hour.PrecipitationProbability),
+			WindSpeed:                newQuantity(hour.WindSpeed),
+			WindDirection:            newQuantity(hour.WindDirection),
+		})
+	}
+	return writeJSON(out, doc)
+}
+
+// writeJSON writes doc as indented JSON.
+func writeJSON(out io.Writer, doc any) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+func newLocationDocument(loc models.Location) locationDocument {
+	doc := locationDocument{
+		ID:          loc.ID,
+		Name:        loc.Name,
+		Latitude:    loc.Latitude,
+		Longitude:   loc.Longitude,
+		Country:     loc.Country,
+		CountryCode: loc.CountryCode,
+		Admin1:      loc.Region,
+		Admin2:      loc.Admin2,
+		Admin3:      loc.Admin3,
+		Admin4:      loc.Admin4,
+		Timezone:    loc.Timezone,
+		Elevation:   loc.Elevation,
+		Population:  loc.Population,
+		Postcodes:   loc.Postcodes,
+		FeatureCode: loc.FeatureCode,
+	}
+	if loc.Near != nil {
+		near := newLocationDocument(*loc.Near)
+		doc.Near = &near
+	}
+	return doc
+}
+
+func newCurrentDocument(obs models.Observation) currentDocument {
+	return currentDocument{
+		Time:                obs.Time,
+		Temperature:         newQuantity(obs.Temperature),
+		ApparentTemperature: newQuantity(obs.ApparentTemperature),
+		Humidity:            newQuantity(obs.Humidity),
+		Precipitation:       newQuantity(obs.Precipitation),
+		CloudCover:          newQuantity(obs.CloudCover),
+		Pressure:            newQuantity(obs.Pressure),
+		WindSpeed:           newQuantity(obs.WindSpeed),
+		WindDirection:       newQuantity(obs.WindDirection),
+		WindGusts:           newQuantity(obs.WindGusts),
+	}
+}
+
+func newQuantity(m models.Measurement) quantity {
+	if m.Missing {
+		return quantity{Unit: m.Unit}
+	}
+	return quantity{Value: &m.Value, Unit: m.Unit}
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// assertGolden compares got with the named file in testdata, rewriting the
+// file instead when the -update flag is set.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+type berlinWeatherResponse struct{}
+
+func (berlinWeatherResponse) QuantityOfTemperature() string         { return "2.5°C" }
+func (berlinWeatherResponse) QuantityOfHumidity() string            { return "76%" }
+func (berlinWeatherResponse) QuantityOfApparentTemperature() string { return "-2.8°C" }
+func (berlinWeatherResponse) QuantityOfPrecipitation() string       { return "0.0 mm" }
+func (berlinWeatherResponse) QuantityOfCloudCover() string          { return "99%" }
+func (berlinWeatherResponse) QuantityOfPressure() string            { return "997.4 hPa" }
+func (berlinWeatherResponse) QuantityOfWindSpeed() string           { return "20.2 km/h" }
+func (berlinWeatherResponse) QuantityOfWindDirection() string       { return "239°" }
+func (berlinWeatherResponse) QuantityOfWindGusts() string           { return "46.1 km/h" }
+func (berlinWeatherResponse) Observation() models.Observation {
+	observed := time.Date(2026, 1, 1, 6, 30, 0, 0, time.UTC)
+	measure := func(value float64, unit string) models.Measurement {
+		return models.Measurement{Value: value, Unit: unit, Time: observed}
+	}
+	return models.Observation{
+		Time:                observed,
+		Temperature:         measure(2.5, "°C"),
+		ApparentTemperature: measure(-2.8, "°C"),
+		Humidity:            measure(76, "%"),
+		Precipitation:       measure(0, "mm"),
+		CloudCover:          measure(99, "%"),
+		Pressure:            measure(997.4, "hPa"),
+		WindSpeed:           measure(20.2, "km/h"),
+		WindDirection:       measure(239, "°"),
+		WindGusts:           measure(46.1, "km/h"),
+	}
+}
+
+func TestPrintWeatherJSON(t *testing.T) {
+	loc := models.Location{ID: 2950159, Name: "Berlin", Latitude: 52.52437, Longitude: 13.41053, Country: "Germany", CountryCode: "DE", Region: "Berlin"}
+	var out bytes.Buffer
+
+	err := PrintWeatherJSON(&out, loc, berlinWeatherResponse{})
+	require.NoError(t, err)
+
+	assertGolden(t, "weather.golden.json", out.Bytes())
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.EqualValues(t, WeatherSchemaVersion, doc["schema_version"])
+}
+
+func TestPrintWeatherJSON_Error(t *testing.T) {
+	err := PrintWeatherJSON(errorWriter{}, models.Location{Name: "Test"}, berlinWeatherResponse{})
+	assert.EqualError(t, err, "write error")
+}
+
+func TestPrintWeatherJSON_Near(t *testing.T) {
+	loc := models.Location{
+		Name:      "52.5200°N, 13.4100°E",
+		Latitude:  52.52,
+		Longitude: 13.41,
+		Near:      &models.Location{Name: "Berlin", Country: "Germany", Region: "Berlin", Latitude: 52.5244, Longitude: 13.4105},
+	}
+	var out bytes.Buffer
+
+	require.NoError(t, PrintWeatherJSON(&out, loc, berlinWeatherResponse{}))
+
+	var doc struct {
+		Location struct {
+			Near *struct {
+				Name    string `json:"name"`
+				Country string `json:"country"`
+			} `json:"near"`
+		} `json:"location"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	require.NotNil(t, doc.Location.Near)
+	assert.Equal(t, "Berlin", doc.Location.Near.Name)
+	assert.Equal(t, "Germany", doc.Location.Near.Country)
+}
+
+func TestPrintDailyForecastJSON(t *testing.T) {
+	loc := models.Location{ID: 2950159, Name: "Berlin", Latitude: 52.52437, Longitude: 13.41053, Country: "Germany", CountryCode: "DE", Region: "Berlin", Timezone: "Europe/Berlin"}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	days := []models.DailyForecast{
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, berlin), TemperatureMin: celsius(-1.5), TemperatureMax: celsius(3.4), PrecipitationSum: mm(0), WindSpeedMax: kmh(18.3)},
+		{Date: time.Date(2026, 1, 2, 0, 0, 0, 0, berlin), TemperatureMin: celsius(0.2), TemperatureMax: celsius(5.1), PrecipitationSum: mm(2.7), WindSpeedMax: kmh(22)},
+	}
+	var out bytes.Buffer
+
+	require.NoError(t, PrintDailyForecastJSON(&out, loc, days))
+
+	assertGolden(t, "daily.golden.json", out.Bytes())
+}
+
+func TestPrintHourlyForecastJSON(t *testing.T) {
+	loc := models.Location{ID: 2950159, Name: "Berlin", Latitude: 52.52437, Longitude: 13.41053, Country: "Germany", CountryCode: "DE", Region: "Berlin", Timezone: "Europe/Berlin"}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	hours := []models.HourlyForecast{
+		{Time: time.Date(2026, 1, 1, 14, 0, 0, 0, berlin), Temperature: celsius(3.1), PrecipitationProbability: percent(10), WindSpeed: kmh(12.4), WindDirection: degrees(230)},
+	}
+	var out bytes.Buffer
+
+	require.NoError(t, PrintHourlyForecastJSON(&out, loc, hours))
+
+	assertGolden(t, "hourly.golden.json", out.Bytes())
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.EqualValues(t, ForecastSchemaVersion, doc["schema_version"])
+}
+
+func TestPrintDailyForecastJSON_Missing(t *testing.T) {
+	days := []models.DailyForecast{
+		{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), TemperatureMin: celsius(-1.5), WindSpeedMax: models.Measurement{Unit: "km/h", Missing: true}},
+	}
+	var out bytes.Buffer
+
+	require.NoError(t, PrintDailyForecastJSON(&out, models.Location{Name: "Test"}, days))
+
+	var doc struct {
+		Daily []struct {
+			TemperatureMin map[string]any `json:"temperature_min"`
+			WindSpeedMax   map[string]any `json:"wind_speed_max"`
+		} `json:"daily"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	require.Len(t, doc.Daily, 1)
+	assert.Equal(t, map[string]any{"value": nil, "unit": "km/h"}, doc.Daily[0].WindSpeedMax)
+	assert.Equal(t, map[string]any{"value": -1.5, "unit": "°C"}, doc.Daily[0].TemperatureMin)
+}
+
+func TestPrintForecastJSON_Empty(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, PrintDailyForecastJSON(&out, models.Location{Name: "Test"}, nil))
+	assert.Contains(t, out.String(), `"daily": []`)
+
+	out.Reset()
+	require.NoError(t, PrintHourlyForecastJSON(&out, models.Location{Name: "Test"}, nil))
+	assert.Contains(t, out.String(), `"hourly": []`)
+}
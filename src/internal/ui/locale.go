@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Locale selects the language of the human-readable output and how
+// numbers are written. The zero Locale is English.
+type Locale struct {
+	tag      string
+	messages *catalog
+	decimal  string // decimal separator
+	group    string // thousands separator
+}
+
+// locales are the supported locales, keyed by ISO 639-1 language code.
+var locales = map[string]Locale{
+	"en": {tag: "en", messages: &english, decimal: ".", group: ","},
+	"de": {tag: "de", messages: &german, decimal: ",", group: "."},
+	"fr": {tag: "fr", messages: &french, decimal: ",", group: "\u202f"},
+	"es": {tag: "es", messages: &spanish, decimal: ",", group: "."},
+}
+
+// Locales returns the language codes of the supported locales, sorted.
+func Locales() []string {
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// ParseLocale returns the locale for a language code such as "de" or a
+// POSIX locale name such as "de_DE.UTF-8".
+func ParseLocale(s string) (Locale, error) {
+	tag := strings.ToLower(s)
+	if i := strings.IndexAny(tag, "_-.@"); i >= 0 {
+		tag = tag[:i]
+	}
+	l, ok := locales[tag]
+	if !ok {
+		return Locale{}, fmt.Errorf("unsupported locale %q (supported: %s)", s, strings.Join(Locales(), ", "))
+	}
+	return l, nil
+}
+
+// String returns the language code of l.
+func (l Locale) String() string {
+	if l.tag == "" {
+		return "en"
+	}
+	return l.tag
+}
+
+func (l Locale) msg() *catalog {
+	if l.messages == nil {
+		return &english
+	}
+	return l.messages
+}
+
+// localizeNumbers rewrites the decimal points in s, such as in a formatted
+// measurement "997.4 hPa", with the locale's decimal separator. Only dots
+// between two digits are replaced.
+func (l Locale) localizeNumbers(s string) string {
+	if l.decimal == "" || l.decimal == "." {
+		return s
+	}
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if r == '.' && i > 0 && i < len(runes)-1 && unicode.IsDigit(runes[i-1]) && unicode.IsDigit(runes[i+1]) {
+			b.WriteString(l.decimal)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// formatCount formats n with the locale's thousands separator between
+// groups of three digits.
+func (l Locale) formatCount(n int) string {
+	group := l.group
+	if group == "" {
+		group = ","
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + group + s[i:]
+	}
+	return s
+}
+
+// weekday returns the abbreviated name of the day of t.
+func (l Locale) weekday(t time.Time) string {
+	return l.msg().Weekdays[t.Weekday()]
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catalogStrings returns every string in c keyed by field name, with the
+// elements of array fields keyed as Field[i].
+func catalogStrings(c *catalog) map[string]string {
+	strs := map[string]string{}
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, field := v.Type().Field(i).Name, v.Field(i)
+		if field.Kind() == reflect.Array {
+			for j := 0; j < field.Len(); j++ {
+				strs[fmt.Sprintf("%s[%d]", name, j)] = field.Index(j).String()
+			}
+			continue
+		}
+		strs[name] = field.String()
+	}
+	return strs
+}
+
+func TestCatalogs_Complete(t *testing.T) {
+	want := catalogStrings(&english)
+	for tag, l := range locales {
+		got := catalogStrings(l.msg())
+		for name, en := range want {
+			assert.NotEmpty(t, got[name], "%s: %s is missing", tag, name)
+			assert.Equal(t, strings.Count(en, "%"), strings.Count(got[name], "%"), "%s: %s has different format verbs", tag, name)
+		}
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	for in, want := range map[string]string{"de": "de", "DE": "de", "de_DE.UTF-8": "de", "fr-CA": "fr", "es": "es", "en_US": "en"} {
+		l, err := ParseLocale(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, l.String())
+	}
+
+	_, err := ParseLocale("ja_JP")
+	assert.EqualError(t, err, `unsupported locale "ja_JP" (supported: de, en, es, fr)`)
+	assert.Equal(t, "en", Locale{}.String())
+}
+
+func TestLocale_LocalizeNumbers(t *testing.T) {
+	de := locales["de"]
+	assert.Equal(t, "997,4 hPa", de.localizeNumbers("997.4 hPa"))
+	assert.Equal(t, "-1,5°C", de.localizeNumbers("-1.5°C"))
+	assert.Equal(t, "v1.", de.localizeNumbers("v1."))
+	assert.Equal(t, "997.4 hPa", Locale{}.localizeNumbers("997.4 hPa"))
+}
+
+// decimalWeather reports quantities with a fractional part.
+type decimalWeather struct{ mockWeatherResponse }
+
+func (decimalWeather) QuantityOfTemperature() string { return "12.5°C" }
+func (decimalWeather) QuantityOfPressure() string    { return "997.4 hPa" }
+
+func TestPrintWeather_German(t *testing.T) {
+	w := decimalWeather{}
+	var out bytes.Buffer
+
+	err := PrintWeather(&out, locales["de"], models.Location{Name: "Berlin", Country: "Deutschland"}, w)
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, "Wetter für Berlin, Deutschland\n")
+	assert.Contains(t, output, "Temperatur:          12,5°C\n")
+	assert.Contains(t, output, "Luftdruck:           997,4 hPa\n")
+	assert.Contains(t, output, "Windgeschwindigkeit: 10km/h\n")
+}
+
+func TestPrintHourlyForecast_French(t *testing.T) {
+	hours := []models.HourlyForecast{
+		{Time: time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC), Temperature: celsius(3.4), PrecipitationProbability: percent(20), WindSpeed: kmh(12.3), WindDirection: degrees(270)},
+	}
+	var out bytes.Buffer
+
+	err := PrintHourlyForecast(&out, locales["fr"], models.Location{Name: "Paris", Country: "France"}, hours)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	require.Len(t, lines, 4)
+	assert.Equal(t, "Prévisions horaires pour Paris, France", lines[0])
+	assert.Equal(t, "Heure           Temp.  Précip.  Vent", strings.TrimRight(lines[2], " "))
+	assert.Equal(t, "jeu. 14:00 UTC  3,4°C  20%      12,3 km/h O", lines[3])
+}
+
+func TestSelectLocation_Spanish(t *testing.T) {
+	locations := []models.Location{
+		{Name: "Springfield", Country: "Estados Unidos", Population: 166810},
+		{Name: "Springfield", Country: "Estados Unidos"},
+	}
+	var out bytes.Buffer
+
+	loc, err := SelectLocation(locations, strings.NewReader("x\n2\n"), &out, true, locales["es"])
+	require.NoError(t, err)
+	assert.Equal(t, locations[1], loc)
+	assert.Equal(t, ""+
+		"Se encontraron varias ubicaciones:\n"+
+		"1. Springfield, Estados Unidos - 166.810 hab.\n"+
+		"2. Springfield, Estados Unidos\n"+
+		"Seleccione una ubicación [1-2]: "+
+		"Selección no válida. Introduzca un número entre 1 y 2.\n"+
+		"Seleccione una ubicación [1-2]: ", out.String())
+}
@@ -0,0 +1,226 @@
+package ui
+
+// catalog holds the text of the human-readable output in one language.
+// Every field must be set in every catalog; formats take the arguments
+// noted next to them.
+type catalog struct {
+	// Report headers, followed by the location.
+	WeatherFor        string
+	ForecastFor       string
+	HourlyForecastFor string
+	Near              string
+
+	// Current weather labels.
+	Temperature         string
+	ApparentTemperature string
+	Humidity            string
+	Precipitation       string
+	CloudCover          string
+	Pressure            string
+	WindSpeed           string
+	WindDirection       string
+	WindGusts           string
+
+	// Daily forecast columns.
+	Date    string
+	Min     string
+	Max     string
+	MaxWind string
+
+	// Hourly forecast columns.
+	Time        string
+	Temp        string
+	PrecipShort string
+	Wind        string
+
+	// NotAvailable stands in for values the API did not report.
+	NotAvailable string
+
+	// Location selection.
+	MultipleLocations string
+	SelectPrompt      string // format: number of choices
+	InvalidSelection  string // format: number of choices
+	Population        string // format: formatted population
+	// BeMoreSpecific is the error when several locations match and no
+	// prompt can be shown.
+	BeMoreSpecific string
+
+	// Interactive picker.
+	Filter     string
+	PickerHint string
+	NoMatches  string
+
+	// Weekdays are abbreviated day names, starting with Sunday.
+	Weekdays [7]string
+	// CompassPoints are the eight principal points, starting with north
+	// and going clockwise.
+	CompassPoints [8]string
+}
+
+var english = catalog{
+	WeatherFor:        "Weather for",
+	ForecastFor:       "Forecast for",
+	HourlyForecastFor: "Hourly forecast for",
+	Near:              "Near",
+
+	Temperature:         "Temperature",
+	ApparentTemperature: "Apparent Temperature",
+	Humidity:            "Humidity",
+	Precipitation:       "Precipitation",
+	CloudCover:          "Cloud Cover",
+	Pressure:            "Pressure",
+	WindSpeed:           "Wind Speed",
+	WindDirection:       "Wind Direction",
+	WindGusts:           "Wind Gusts",
+
+	Date:    "Date",
+	Min:     "Min",
+	Max:     "Max",
+	MaxWind: "Max Wind",
+
+	Time:        "Time",
+	Temp:        "Temp",
+	PrecipShort: "Precip.",
+	Wind:        "Wind",
+
+	NotAvailable: "n/a",
+
+	MultipleLocations: "Multiple locations found:",
+	SelectPrompt:      "Select location [1-%d]: ",
+	InvalidSelection:  "Invalid selection. Please enter a number between 1 and %d.",
+	Population:        "pop. %s",
+	BeMoreSpecific:    "multiple locations found, please be more specific",
+
+	Filter:     "Filter",
+	PickerHint: "↑/↓ to move, Enter to select, Esc to cancel",
+	NoMatches:  "No matching locations",
+
+	Weekdays:      [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	CompassPoints: [8]string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"},
+}
+
+var german = catalog{
+	WeatherFor:        "Wetter für",
+	ForecastFor:       "Vorhersage für",
+	HourlyForecastFor: "Stündliche Vorhersage für",
+	Near:              "In der Nähe von",
+
+	Temperature:         "Temperatur",
+	ApparentTemperature: "Gefühlte Temperatur",
+	Humidity:            "Luftfeuchtigkeit",
+	Precipitation:       "Niederschlag",
+	CloudCover:          "Bewölkung",
+	Pressure:            "Luftdruck",
+	WindSpeed:           "Windgeschwindigkeit",
+	WindDirection:       "Windrichtung",
+	WindGusts:           "Windböen",
+
+	Date:    "Datum",
+	Min:     "Min.",
+	Max:     "Max.",
+	MaxWind: "Max. Wind",
+
+	Time:        "Zeit",
+	Temp:        "Temp.",
+	PrecipShort: "Niederschl.",
+	Wind:        "Wind",
+
+	NotAvailable: "k. A.",
+
+	MultipleLocations: "Mehrere Orte gefunden:",
+	SelectPrompt:      "Ort auswählen [1-%d]: ",
+	InvalidSelection:  "Ungültige Auswahl. Bitte eine Zahl zwischen 1 und %d eingeben.",
+	Population:        "%s Einw.",
+	BeMoreSpecific:    "mehrere Orte gefunden, bitte genauer angeben",
+
+	Filter:     "Filter",
+	PickerHint: "↑/↓ bewegen, Enter auswählen, Esc abbrechen",
+	NoMatches:  "Keine passenden Orte",
+
+	Weekdays:      [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+	CompassPoints: [8]string{"N", "NO", "O", "SO", "S", "SW", "W", "NW"},
+}
+
+var french = catalog{
+	WeatherFor:        "Météo pour",
+	ForecastFor:       "Prévisions pour",
+	HourlyForecastFor: "Prévisions horaires pour",
+	Near:              "Près de",
+
+	Temperature:         "Température",
+	ApparentTemperature: "Température ressentie",
+	Humidity:            "Humidité",
+	Precipitation:       "Précipitations",
+	CloudCover:          "Couverture nuageuse",
+	Pressure:            "Pression",
+	WindSpeed:           "Vitesse du vent",
+	WindDirection:       "Direction du vent",
+	WindGusts:           "Rafales",
+
+	Date:    "Date",
+	Min:     "Min",
+	Max:     "Max",
+	MaxWind: "Vent max",
+
+	Time:        "Heure",
+	Temp:        "Temp.",
+	PrecipShort: "Précip.",
+	Wind:        "Vent",
+
+	NotAvailable: "n/d",
+
+	MultipleLocations: "Plusieurs lieux trouvés :",
+	SelectPrompt:      "Choisissez un lieu [1-%d] : ",
+	InvalidSelection:  "Choix invalide. Veuillez saisir un nombre entre 1 et %d.",
+	Population:        "%s hab.",
+	BeMoreSpecific:    "plusieurs lieux trouvés, veuillez préciser",
+
+	Filter:     "Filtre",
+	PickerHint: "↑/↓ pour naviguer, Entrée pour choisir, Échap pour annuler",
+	NoMatches:  "Aucun lieu correspondant",
+
+	Weekdays:      [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	CompassPoints: [8]string{"N", "NE", "E", "SE", "S", "SO", "O", "NO"},
+}
+
+var spanish = catalog{
+	WeatherFor:        "El tiempo en",
+	ForecastFor:       "Pronóstico para",
+	HourlyForecastFor: "Pronóstico por horas para",
+	Near:              "Cerca de",
+
+	Temperature:         "Temperatura",
+	ApparentTemperature: "Sensación térmica",
+	Humidity:            "Humedad",
+	Precipitation:       "Precipitación",
+	CloudCover:          "Nubosidad",
+	Pressure:            "Presión",
+	WindSpeed:           "Velocidad del viento",
+	WindDirection:       "Dirección del viento",
+	WindGusts:           "Rachas de viento",
+
+	Date:    "Fecha",
+	Min:     "Mín.",
+	Max:     "Máx.",
+	MaxWind: "Viento máx.",
+
+	Time:        "Hora",
+	Temp:        "Temp.",
+	PrecipShort: "Precip.",
+	Wind:        "Viento",
+
+	NotAvailable: "n/d",
+
+	MultipleLocations: "Se encontraron varias ubicaciones:",
+	SelectPrompt:      "Seleccione una ubicación [1-%d]: ",
+	InvalidSelection:  "Selección no válida. Introduzca un número entre 1 y %d.",
+	Population:        "%s hab.",
+	BeMoreSpecific:    "se encontraron varias ubicaciones, sea más específico",
+
+	Filter:     "Filtro",
+	PickerHint: "↑/↓ para moverse, Intro para seleccionar, Esc para cancelar",
+	NoMatches:  "Ninguna ubicación coincide",
+
+	Weekdays:      [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+	CompassPoints: [8]string{"N", "NE", "E", "SE", "S", "SO", "O", "NO"},
+}
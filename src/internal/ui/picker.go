@@ -0,0 +1,357 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"weather-reporter/src/internal/models"
+
+	"golang.org/x/text/width"
+)
+
+// ErrSelectionCanceled is returned by PickLocation when the user cancels
+// the selection with Esc or Ctrl-C.
+var ErrSelectionCanceled = errors.New("location selection canceled")
+
+// pickerRows is the number of locations the picker shows at a time.
+const pickerRows = 10
+
+// key is a key press read by the picker.
+type key int
+
+const (
+	keyOther key = iota
+	keyRune
+	keyUp
+	keyDown
+	keyEnter
+	keyBackspace
+	keyCancel
+)
+
+// PickLocation lets the user choose one of locations on a terminal in raw
+// mode: typing filters the list by name, region and country, the arrow
+// keys move the selection, Enter chooses it and Esc cancels. The picker is
+// erased again before PickLocation returns.
+func PickLocation(t Terminal, locations []models.Location, l Locale) (loc models.Location, err error) {
+	if len(locations) == 0 {
+		return models.Location{}, fmt.Errorf("no locations to select from")
+	}
+
+	restore, err := t.MakeRaw()
+	if err != nil {
+		return models.Location{}, fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+	defer func() {
+		if rerr := restore(); rerr != nil && err == nil {
+			err = fmt.Errorf("failed to restore terminal: %w", rerr)
+		}
+	}()
+
+	p := newPicker(locations, l, t.Width())
+	in := bufio.NewReader(t)
+	for {
+		if err := p.draw(t); err != nil {
+			return models.Location{}, err
+		}
+		k, r, err := readKey(in)
+		if err != nil {
+			_ = p.clear(t)
+			return models.Location{}, fmt.Errorf("failed to read input: %w", err)
+		}
+		switch p.handle(k, r) {
+		case pickerChosen:
+			return locations[p.matches[p.cursor]], p.clear(t)
+		case pickerCanceled:
+			_ = p.clear(t)
+			return models.Location{}, ErrSelectionCanceled
+		}
+	}
+}
+
+// pickerState tells whether the picker is done after a key press.
+type pickerState int
+
+const (
+	pickerOpen pickerState = iota
+	pickerChosen
+	pickerCanceled
+)
+
+// picker is the state of PickLocation between key presses.
+type picker struct {
+	locations []models.Location
+	locale    Locale
+	width     int // of the terminal; 0 if unknown
+
+	query   []rune
+	matches []int // indexes into locations, best match first
+	cursor  int   // index into matches
+	top     int   // first visible match
+	drawn   int   // lines drawn last, erased before drawing again
+}
+
+func newPicker(locations []models.Location, l Locale, width int) *picker {
+	p := &picker{locations: locations, locale: l, width: width}
+	p.matches = filterLocations(locations, "")
+	return p
+}
+
+// handle updates the picker for a key press.
+func (p *picker) handle(k key, r rune) pickerState {
+	switch k {
+	case keyUp:
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case keyDown:
+		if p.cursor < len(p.matches)-1 {
+			p.cursor++
+		}
+	case keyRune:
+		p.query = append(p.query, r)
+		p.refilter()
+	case keyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.refilter()
+		}
+	case keyEnter:
+		if len(p.matches) > 0 {
+			return pickerChosen
+		}
+	case keyCancel:
+		return pickerCanceled
+	}
+
+	if p.cursor < p.top {
+		p.top = p.cursor
+	}
+	if p.cursor >= p.top+pickerRows {
+		p.top = p.cursor - pickerRows + 1
+	}
+	return pickerOpen
+}
+
+func (p *picker) refilter() {
+	p.matches = filterLocations(p.locations, string(p.query))
+	p.cursor, p.top = 0, 0
+}
+
+// lines returns the picker as text: the filter, the visible matches with
+// the selected one marked, and a hint on the keys.
+func (p *picker) lines() []string {
+	msg := p.locale.msg()
+	lines := []string{msg.Filter + ": " + string(p.query)}
+	if len(p.matches) == 0 {
+		lines = append(lines, "  "+msg.NoMatches)
+	}
+	for i := p.top; i < len(p.matches) && i < p.top+pickerRows; i++ {
+		loc := p.locations[p.matches[i]]
+		line := "  " + FormatLocation(loc)
+		if i == p.cursor {
+			line = "> " + FormatLocation(loc)
+		}
+		if details := locationDetails(p.locale, loc); details != "" {
+			line += " - " + details
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, fmt.Sprintf("%s (%d/%d)", msg.PickerHint, len(p.matches), len(p.locations)))
+
+	for i, line := range lines {
+		lines[i] = truncate(line, p.width)
+	}
+	return lines
+}
+
+// draw replaces the picker drawn last with its current state.
+func (p *picker) draw(w io.Writer) error {
+	lines := p.lines()
+	_, err := io.WriteString(w, p.eraseSequence()+strings.Join(lines, "\r\n"))
+	p.drawn = len(lines)
+	return err
+}
+
+// clear erases the picker, leaving the cursor where it started.
+func (p *picker) clear(w io.Writer) error {
+	_, err := io.WriteString(w, p.eraseSequence())
+	p.drawn = 0
+	return err
+}
+
+// eraseSequence returns the escape sequence that moves the cursor back to
+// the first line drawn and erases everything below it.
+func (p *picker) eraseSequence() string {
+	seq := "\r\x1b[J"
+	if p.drawn > 1 {
+		seq = fmt.Sprintf("\x1b[%dA", p.drawn-1) + seq
+	}
+	return seq
+}
+
+// truncate shortens s to fit in cols columns, so that no line wraps and
+// the picker can be erased line by line. The last column is left free, as
+// some terminals wrap a line that reaches it. A cols of 0 leaves s
+// unchanged.
+func truncate(s string, cols int) string {
+	if cols <= 0 || displayWidth(s) < cols {
+		return s
+	}
+	if cols < 2 {
+		return ""
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		w := runeWidth(r)
+		if used+w > cols-2 {
+			break
+		}
+		b.WriteRune(r)
+		used += w
+	}
+	return b.String() + "…"
+}
+
+// displayWidth returns the number of terminal columns s takes up.
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// runeWidth returns the number of terminal columns r takes up: two for
+// wide characters such as CJK ideographs, none for combining marks and
+// control characters, and one otherwise.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r), unicode.IsControl(r), r == '\u200b':
+		return 0
+	}
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	}
+	return 1
+}
+
+// readKey reads one key press from a terminal in raw mode. A lone Esc,
+// which is not followed by the rest of an escape sequence in the same
+// read, cancels.
+func readKey(in *bufio.Reader) (key, rune, error) {
+	r, _, err := in.ReadRune()
+	if err != nil {
+		return keyOther, 0, err
+	}
+
+	switch r {
+	case '\r', '\n':
+		return keyEnter, r, nil
+	case 0x7f, '\b':
+		return keyBackspace, r, nil
+	case 0x03: // Ctrl-C
+		return keyCancel, r, nil
+	case 0x10: // Ctrl-P
+		return keyUp, r, nil
+	case 0x0e: // Ctrl-N
+		return keyDown, r, nil
+	case 0x1b:
+		return readEscape(in)
+	}
+	if unicode.IsPrint(r) {
+		return keyRune, r, nil
+	}
+	return keyOther, r, nil
+}
+
+// readEscape reads the rest of an escape sequence such as "\x1b[A", the
+// up arrow, after its Esc.
+func readEscape(in *bufio.Reader) (key, rune, error) {
+	if in.Buffered() == 0 {
+		return keyCancel, 0x1b, nil
+	}
+	if next, _ := in.Peek(1); next[0] != '[' && next[0] != 'O' {
+		return keyCancel, 0x1b, nil
+	}
+	_, _ = in.ReadByte()
+
+	// Skip the parameters, as in "\x1b[1;5A", up to the final byte.
+	for {
+		b, err := in.ReadByte()
+		if err != nil {
+			return keyOther, 0, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			switch b {
+			case 'A':
+				return keyUp, 0, nil
+			case 'B':
+				return keyDown, 0, nil
+			}
+			return keyOther, 0, nil
+		}
+	}
+}
+
+// filterLocations returns the indexes of the locations whose name, region
+// and country contain every word of query, followed by those that only
+// contain the letters of every word in order, as in "pme" for "Portland,
+// Maine". Each group keeps the order of locations.
+func filterLocations(locations []models.Location, query string) []int {
+	words := strings.Fields(strings.ToLower(query))
+	var exact, fuzzy []int
+	for i, loc := range locations {
+		text := strings.ToLower(loc.Name + " " + loc.Region + " " + loc.Country)
+		switch matchWords(text, words) {
+		case matchExact:
+			exact = append(exact, i)
+		case matchFuzzy:
+			fuzzy = append(fuzzy, i)
+		}
+	}
+	return append(exact, fuzzy...)
+}
+
+// match is how well a location matches the filter of the picker.
+type match int
+
+const (
+	matchNone match = iota
+	matchFuzzy
+	matchExact
+)
+
+func matchWords(text string, words []string) match {
+	m := matchExact
+	for _, w := range words {
+		if strings.Contains(text, w) {
+			continue
+		}
+		if !isSubsequence(text, w) {
+			return matchNone
+		}
+		m = matchFuzzy
+	}
+	return m
+}
+
+// isSubsequence reports whether the runes of sub appear in s in order.
+func isSubsequence(s, sub string) bool {
+	want := []rune(sub)
+	for _, r := range s {
+		if len(want) == 0 {
+			break
+		}
+		if r == want[0] {
+			want = want[1:]
+		}
+	}
+	return len(want) == 0
+}
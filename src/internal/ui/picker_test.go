@@ -0,0 +1,248 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTerminal is a Terminal that reads scripted key presses and records
+// what is drawn.
+type fakeTerminal struct {
+	keys     *strings.Reader
+	out      bytes.Buffer
+	width    int
+	raw      bool
+	restored bool
+	rawErr   error
+}
+
+func newFakeTerminal(keys string) *fakeTerminal {
+	return &fakeTerminal{keys: strings.NewReader(keys)}
+}
+
+func (t *fakeTerminal) Read(p []byte) (int, error)  { return t.keys.Read(p) }
+func (t *fakeTerminal) Write(p []byte) (int, error) { return t.out.Write(p) }
+func (t *fakeTerminal) Width() int                  { return t.width }
+
+func (t *fakeTerminal) MakeRaw() (func() error, error) {
+	if t.rawErr != nil {
+		return nil, t.rawErr
+	}
+	t.raw = true
+	return func() error { t.restored = true; return nil }, nil
+}
+
+var pickerPortlands = []models.Location{
+	{ID: 5746545, Name: "Portland", Country: "United States", Region: "Oregon", Population: 652503},
+	{ID: 4975802, Name: "Portland", Country: "United States", Region: "Maine"},
+	{ID: 2152668, Name: "Portland", Country: "Australia", Region: "Victoria"},
+}
+
+const (
+	up   = "\x1b[A"
+	down = "\x1b[B"
+)
+
+func TestPickLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		keys string
+		want string
+	}{
+		{name: "enter picks the first", keys: "\r", want: "Oregon"},
+		{name: "arrow down", keys: down + down + "\r", want: "Victoria"},
+		{name: "arrow up", keys: down + down + up + "\r", want: "Maine"},
+		{name: "up at the top", keys: up + "\r", want: "Oregon"},
+		{name: "down at the bottom", keys: down + down + down + "\r", want: "Victoria"},
+		{name: "application mode arrows", keys: "\x1bOB\r", want: "Maine"},
+		{name: "ctrl-n and ctrl-p", keys: "\x0e\x0e\x10\r", want: "Maine"},
+		{name: "filter by region", keys: "maine\r", want: "Maine"},
+		{name: "filter by country", keys: "AUS\r", want: "Victoria"},
+		{name: "filter by several words", keys: "port states o\r", want: "Oregon"},
+		{name: "fuzzy filter", keys: "pvic\r", want: "Victoria"},
+		{name: "backspace", keys: "maine\x7f\x7f\x7f\x7f\x7f\r", want: "Oregon"},
+		{name: "enter without matches is ignored", keys: "xyz\r\x7f\x7f\x7f\r", want: "Oregon"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			term := newFakeTerminal(tt.keys)
+
+			loc, err := PickLocation(term, pickerPortlands, Locale{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, loc.Region)
+			assert.True(t, term.raw)
+			assert.True(t, term.restored)
+		})
+	}
+}
+
+func TestPickLocation_Cancel(t *testing.T) {
+	for name, keys := range map[string]string{"esc": "\x1b", "ctrl-c": "mai\x03", "alt key": "\x1bx"} {
+		t.Run(name, func(t *testing.T) {
+			term := newFakeTerminal(keys)
+
+			_, err := PickLocation(term, pickerPortlands, Locale{})
+			assert.ErrorIs(t, err, ErrSelectionCanceled)
+			assert.True(t, term.restored)
+			assert.True(t, strings.HasSuffix(term.out.String(), "\r\x1b[J"), "picker is erased")
+		})
+	}
+}
+
+func TestPickLocation_Errors(t *testing.T) {
+	_, err := PickLocation(newFakeTerminal(""), nil, Locale{})
+	assert.EqualError(t, err, "no locations to select from")
+
+	term := newFakeTerminal("ma")
+	_, err = PickLocation(term, pickerPortlands, Locale{})
+	assert.EqualError(t, err, "failed to read input: EOF")
+	assert.True(t, term.restored)
+
+	term = newFakeTerminal("\r")
+	term.rawErr = errors.New("not a terminal")
+	_, err = PickLocation(term, pickerPortlands, Locale{})
+	assert.EqualError(t, err, "failed to switch terminal to raw mode: not a terminal")
+}
+
+func TestPickLocation_Draw(t *testing.T) {
+	term := newFakeTerminal("ne" + down + "\r")
+
+	_, err := PickLocation(term, pickerPortlands, Locale{})
+	require.NoError(t, err)
+
+	frames := strings.Split(term.out.String(), "\x1b[J")
+	require.Len(t, frames, 6, "one frame per key, then the erase")
+	assert.Equal(t, ""+
+		"Filter: \r\n"+
+		"> Portland, United States (Oregon) - pop. 652,503\r\n"+
+		"  Portland, United States (Maine)\r\n"+
+		"  Portland, Australia (Victoria)\r\n"+
+		"↑/↓ to move, Enter to select, Esc to cancel (3/3)\x1b[4A\r", frames[1])
+	assert.Equal(t, ""+
+		"Filter: ne\r\n"+
+		"  Portland, United States (Maine)\r\n"+
+		"> Portland, United States (Oregon) - pop. 652,503\r\n"+
+		"↑/↓ to move, Enter to select, Esc to cancel (2/3)\x1b[3A\r", frames[4])
+}
+
+func TestPickLocation_Scrolls(t *testing.T) {
+	var many []models.Location
+	for _, name := range strings.Split("ABCDEFGHIJKL", "") {
+		many = append(many, models.Location{Name: name})
+	}
+	term := newFakeTerminal(strings.Repeat(down, 11) + "\r")
+
+	loc, err := PickLocation(term, many, Locale{})
+	require.NoError(t, err)
+	assert.Equal(t, "L", loc.Name)
+
+	frames := strings.Split(term.out.String(), "\x1b[J")
+	last := frames[len(frames)-2]
+	assert.NotContains(t, last, "  A\r\n")
+	assert.Contains(t, last, "  C\r\n")
+	assert.Contains(t, last, "> L\r\n")
+}
+
+func TestPickLocation_Localized(t *testing.T) {
+	term := newFakeTerminal("\x1b")
+
+	_, err := PickLocation(term, pickerPortlands, locales["de"])
+	assert.ErrorIs(t, err, ErrSelectionCanceled)
+	assert.Contains(t, term.out.String(), "Portland, United States (Oregon) - 652.503 Einw.")
+	assert.Contains(t, term.out.String(), "Esc abbrechen (3/3)")
+}
+
+func TestPickLocation_TruncatesToWidth(t *testing.T) {
+	term := newFakeTerminal("\r")
+	term.width = 20
+
+	_, err := PickLocation(term, pickerPortlands, Locale{})
+	require.NoError(t, err)
+	assert.Contains(t, term.out.String(), "> Portland, United…\r\n")
+}
+
+func TestPickLocation_NarrowTerminal(t *testing.T) {
+	term := newFakeTerminal("\r")
+	term.width = 1
+
+	loc, err := PickLocation(term, pickerPortlands, Locale{})
+	require.NoError(t, err)
+	assert.Equal(t, pickerPortlands[0], loc)
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		cols int
+		want string
+	}{
+		{"Portland, Oregon", 0, "Portland, Oregon"},
+		{"Portland, Oregon", 17, "Portland, Oregon"},
+		{"Portland, Oregon", 16, "Portland, Oreg…"},
+		{"Portland", 2, "…"},
+		{"Portland", 1, ""},
+		{"", 1, ""},
+		{"> 東京, 日本 (東京都)", 30, "> 東京, 日本 (東京都)"},
+		{"> 東京, 日本 (東京都)", 12, "> 東京, 日…"},
+		{"> 東京, 日本 (東京都)", 11, "> 東京, …"},
+		{"Zu\u0308rich", 7, "Zu\u0308rich"},
+		{"Zu\u0308rich", 4, "Zu\u0308…"},
+	}
+
+	for _, tt := range tests {
+		got := truncate(tt.s, tt.cols)
+		assert.Equal(t, tt.want, got, "truncate(%q, %d)", tt.s, tt.cols)
+		if tt.cols > 0 && got != tt.s {
+			assert.Less(t, displayWidth(got), tt.cols, "truncate(%q, %d) leaves the last column free", tt.s, tt.cols)
+		}
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	assert.Equal(t, 8, displayWidth("Portland"))
+	assert.Equal(t, 4, displayWidth("東京"))
+	assert.Equal(t, 6, displayWidth("Zu\u0308rich"), "combining marks take no column")
+	assert.Equal(t, 4, displayWidth("ＡＢ"), "fullwidth forms take two columns")
+}
+
+func TestReadKey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want key
+	}{
+		{in: "\r", want: keyEnter},
+		{in: "\x7f", want: keyBackspace},
+		{in: "\b", want: keyBackspace},
+		{in: "\x1b[A", want: keyUp},
+		{in: "\x1b[1;5B", want: keyDown},
+		{in: "\x1b[C", want: keyOther},
+		{in: "\x1b", want: keyCancel},
+		{in: "ü", want: keyRune},
+		{in: "\t", want: keyOther},
+	}
+	for _, tt := range tests {
+		got, _, err := readKey(bufio.NewReader(strings.NewReader(tt.in)))
+		require.NoError(t, err, "%q", tt.in)
+		assert.Equal(t, tt.want, got, "%q", tt.in)
+	}
+}
+
+func TestFilterLocations(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 2}, filterLocations(pickerPortlands, ""))
+	assert.Equal(t, []int{1, 0}, filterLocations(pickerPortlands, "ne"), "substring matches come first")
+	assert.Empty(t, filterLocations(pickerPortlands, "berlin"))
+}
+
+func TestOpenTerminal_NotATerminal(t *testing.T) {
+	_, ok := OpenTerminal(strings.NewReader(""), &bytes.Buffer{}, "xterm-256color")
+	assert.False(t, ok)
+}
@@ -2,11 +2,13 @@ package ui
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 
 	"weather-reporter/src/internal/models"
 )
@@ -20,8 +22,9 @@ func IsTerminal(f *os.File) bool {
 	return (stat.Mode() & os.ModeCharDevice) != 0
 }
 
-// SelectLocation prompts the user to select a location from a list.
-func SelectLocation(locations []models.Location, in io.Reader, out io.Writer, interactive bool) (models.Location, error) {
+// SelectLocation prompts the user to select a location from a list,
+// using the language of l.
+func SelectLocation(locations []models.Location, in io.Reader, out io.Writer, interactive bool, l Locale) (models.Location, error) {
 	if len(locations) == 0 {
 		return models.Location{}, fmt.Errorf("no locations to select from")
 	}
@@ -32,19 +35,20 @@ func SelectLocation(locations []models.Location, in io.Reader, out io.Writer, in
 		displayCount = 10
 	}
 
+	msg := l.msg()
 	if !interactive {
-		_, _ = fmt.Fprintln(out, "Multiple locations found:")
-		printLocations(out, locations[:displayCount])
-		return models.Location{}, fmt.Errorf("multiple locations found, please be more specific")
+		_, _ = fmt.Fprintln(out, msg.MultipleLocations)
+		printLocations(out, l, locations[:displayCount])
+		return models.Location{}, errors.New(msg.BeMoreSpecific)
 	}
 
-	_, _ = fmt.Fprintln(out, "Multiple locations found:")
-	printLocations(out, locations[:displayCount])
+	_, _ = fmt.Fprintln(out, msg.MultipleLocations)
+	printLocations(out, l, locations[:displayCount])
 
 	reader := bufio.NewReader(in)
 
 	for {
-		_, _ = fmt.Fprintf(out, "Select location [1-%d]: ", displayCount)
+		_, _ = fmt.Fprintf(out, msg.SelectPrompt, displayCount)
 		input, err := reader.ReadString('\n')
 		if err != nil {
 			return models.Location{}, fmt.Errorf("failed to read input: %w", err)
@@ -53,7 +57,7 @@ func SelectLocation(locations []models.Location, in io.Reader, out io.Writer, in
 		input = strings.TrimSpace(input)
 		index, err := strconv.Atoi(input)
 		if err != nil || index < 1 || index > displayCount {
-			_, _ = fmt.Fprintf(out, "Invalid selection. Please enter a number between 1 and %d.\n", displayCount)
+			_, _ = fmt.Fprintf(out, msg.InvalidSelection+"\n", displayCount)
 			continue
 		}
 
@@ -61,46 +65,93 @@ func SelectLocation(locations []models.Location, in io.Reader, out io.Writer, in
 	}
 }
 
-func printLocations(out io.Writer, locations []models.Location) {
+func printLocations(out io.Writer, l Locale, locations []models.Location) {
 	for i, loc := range locations {
-		_, _ = fmt.Fprintf(out, "%d. %s, %s (%s)\n", i+1, loc.Name, loc.Country, loc.Region)
+		line := fmt.Sprintf("%d. %s", i+1, FormatLocation(loc))
+		if details := locationDetails(l, loc); details != "" {
+			line += " - " + details
+		}
+		_, _ = fmt.Fprintln(out, line)
 	}
 }
 
-// PrintWeather prints the weather information to the output writer.
-func PrintWeather(out io.Writer, loc models.Location, w models.WeatherResponse) error {
-	if _, err := fmt.Fprintf(out, "Weather for %s, %s (%s)\n", loc.Name, loc.Country, loc.Region); err != nil {
-		return err
+// locationDetails lists what tells places of the same name apart: the
+// lower administrative areas, a postcode, the population and the
+// elevation. Unknown details are left out.
+func locationDetails(l Locale, loc models.Location) string {
+	var details []string
+	for _, area := range []string{loc.Admin2, loc.Admin3} {
+		if area != "" && area != loc.Name {
+			details = append(details, area)
+		}
 	}
-	if _, err := fmt.Fprintln(out, "------------------------------------------------"); err != nil {
-		return err
+	if len(loc.Postcodes) > 0 {
+		details = append(details, loc.Postcodes[0])
 	}
-	if _, err := fmt.Fprintf(out, "Temperature:          %s\n", w.QuantityOfTemperature()); err != nil {
-		return err
+	if loc.Population > 0 {
+		details = append(details, fmt.Sprintf(l.msg().Population, l.formatCount(loc.Population)))
 	}
-	if _, err := fmt.Fprintf(out, "Apparent Temperature: %s\n", w.QuantityOfApparentTemperature()); err != nil {
-		return err
+	if loc.Elevation != 0 {
+		details = append(details, fmt.Sprintf("%.0f m", loc.Elevation))
 	}
-	if _, err := fmt.Fprintf(out, "Humidity:             %s\n", w.QuantityOfHumidity()); err != nil {
-		return err
+	return strings.Join(details, ", ")
+}
+
+// FormatLocation formats a location as "Name, Country (Region)", leaving
+// out the country and region when they are unknown.
+func FormatLocation(loc models.Location) string {
+	label := loc.Name
+	if loc.Country != "" {
+		label += ", " + loc.Country
 	}
-	if _, err := fmt.Fprintf(out, "Precipitation:        %s\n", w.QuantityOfPrecipitation()); err != nil {
-		return err
+	if loc.Region != "" {
+		label += " (" + loc.Region + ")"
 	}
-	if _, err := fmt.Fprintf(out, "Cloud Cover:          %s\n", w.QuantityOfCloudCover()); err != nil {
-		return err
-	}
-	if _, err := fmt.Fprintf(out, "Pressure:             %s\n", w.QuantityOfPressure()); err != nil {
+	return label
+}
+
+// printHeader prints the report title for loc, followed by the nearest
+// place when loc was given by its coordinates, and a separator line.
+func printHeader(out io.Writer, l Locale, title string, loc models.Location) error {
+	if _, err := fmt.Fprintf(out, "%s %s\n", title, FormatLocation(loc)); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintf(out, "Wind Speed:           %s\n", w.QuantityOfWindSpeed()); err != nil {
-		return err
+	if loc.Near != nil {
+		if _, err := fmt.Fprintf(out, "%s %s\n", l.msg().Near, FormatLocation(*loc.Near)); err != nil {
+			return err
+		}
 	}
-	if _, err := fmt.Fprintf(out, "Wind Direction:       %s\n", w.QuantityOfWindDirection()); err != nil {
+	_, err := fmt.Fprintln(out, "------------------------------------------------")
+	return err
+}
+
+// PrintWeather prints the weather information to the output writer, with
+// labels and numbers written for l.
+func PrintWeather(out io.Writer, l Locale, loc models.Location, w models.WeatherResponse) error {
+	msg := l.msg()
+	if err := printHeader(out, l, msg.WeatherFor, loc); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintf(out, "Wind Gusts:           %s\n", w.QuantityOfWindGusts()); err != nil {
-		return err
+
+	rows := []struct {
+		label    string
+		quantity string
+	}{
+		{msg.Temperature, w.QuantityOfTemperature()},
+		{msg.ApparentTemperature, w.QuantityOfApparentTemperature()},
+		{msg.Humidity, w.QuantityOfHumidity()},
+		{msg.Precipitation, w.QuantityOfPrecipitation()},
+		{msg.CloudCover, w.QuantityOfCloudCover()},
+		{msg.Pressure, w.QuantityOfPressure()},
+		{msg.WindSpeed, w.QuantityOfWindSpeed()},
+		{msg.WindDirection, w.QuantityOfWindDirection()},
+		{msg.WindGusts, w.QuantityOfWindGusts()},
+	}
+	tw := tabwriter.NewWriter(out, 0, 0, 1, ' ', 0)
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(tw, "%s:\t%s\n", row.label, l.localizeNumbers(row.quantity)); err != nil {
+			return err
+		}
 	}
-	return nil
+	return tw.Flush()
 }
@@ -23,7 +23,7 @@ func TestSelectLocation(t *testing.T) {
 		in := strings.NewReader(input)
 		var out bytes.Buffer
 
-		loc, err := SelectLocation(locations, in, &out, true)
+		loc, err := SelectLocation(locations, in, &out, true, Locale{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, locations[0], loc)
@@ -31,12 +31,23 @@ func TestSelectLocation(t *testing.T) {
 		assert.Contains(t, out.String(), "1. London, UK (Greater London)")
 	})
 
+	t.Run("Non-Interactive Localized", func(t *testing.T) {
+		de, err := ParseLocale("de")
+		assert.NoError(t, err)
+		var out bytes.Buffer
+
+		_, err = SelectLocation(locations, strings.NewReader(""), &out, false, de)
+
+		assert.EqualError(t, err, "mehrere Orte gefunden, bitte genauer angeben")
+		assert.Contains(t, out.String(), "Mehrere Orte gefunden:")
+	})
+
 	t.Run("Interactive Invalid Input Then Success", func(t *testing.T) {
 		input := "invalid\n3\n2\n" // 3 is out of range
 		in := strings.NewReader(input)
 		var out bytes.Buffer
 
-		loc, err := SelectLocation(locations, in, &out, true)
+		loc, err := SelectLocation(locations, in, &out, true, Locale{})
 
 		assert.NoError(t, err)
 		assert.Equal(t, locations[1], loc)
@@ -47,7 +58,7 @@ func TestSelectLocation(t *testing.T) {
 		in := strings.NewReader("")
 		var out bytes.Buffer
 
-		loc, err := SelectLocation(locations, in, &out, false)
+		loc, err := SelectLocation(locations, in, &out, false, Locale{})
 
 		assert.Error(t, err)
 		assert.Equal(t, "multiple locations found, please be more specific", err.Error())
@@ -65,7 +76,7 @@ func TestSelectLocation(t *testing.T) {
 		in := strings.NewReader("")
 		var out bytes.Buffer
 
-		_, err := SelectLocation(manyLocations, in, &out, false)
+		_, err := SelectLocation(manyLocations, in, &out, false, Locale{})
 		assert.Error(t, err)
 
 		output := out.String()
@@ -99,7 +110,7 @@ func TestSelectLocation_ReadError(t *testing.T) {
 	}
 	var out bytes.Buffer
 
-	_, err := SelectLocation(locations, &errorReader{}, &out, true)
+	_, err := SelectLocation(locations, &errorReader{}, &out, true, Locale{})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to read input")
 }
@@ -115,6 +126,7 @@ func (m mockWeatherResponse) QuantityOfPressure() string            { return "10
 func (m mockWeatherResponse) QuantityOfWindSpeed() string           { return "10km/h" }
 func (m mockWeatherResponse) QuantityOfWindDirection() string       { return "N" }
 func (m mockWeatherResponse) QuantityOfWindGusts() string           { return "15km/h" }
+func (m mockWeatherResponse) Observation() models.Observation       { return models.Observation{} }
 
 func TestPrintWeather(t *testing.T) {
 loc := models.Location{
@@ -125,7 +137,7 @@ Region:  "Test Region",
 w := mockWeatherResponse{}
 var out bytes.Buffer
 
-err := PrintWeather(&out, loc, w)
+err := PrintWeather(&out, Locale{}, loc, w)
 assert.NoError(t, err)
 
 output := out.String()
@@ -147,12 +159,57 @@ func (e errorWriter) Write(p []byte) (n int, err error) {
 return 0, fmt.Errorf("write error")
 }
 
+func TestFormatLocation(t *testing.T) {
+	assert.Equal(t, "London, UK (Greater London)", FormatLocation(models.Location{Name: "London", Country: "UK", Region: "Greater London"}))
+	assert.Equal(t, "Monaco, Monaco", FormatLocation(models.Location{Name: "Monaco", Country: "Monaco"}))
+	assert.Equal(t, "52.5200°N, 13.4100°E", FormatLocation(models.Location{Name: "52.5200°N, 13.4100°E"}))
+}
+
 func TestPrintWeather_Error(t *testing.T) {
 loc := models.Location{Name: "Test"}
 w := mockWeatherResponse{}
 out := errorWriter{}
 
-err := PrintWeather(out, loc, w)
+err := PrintWeather(out, Locale{}, loc, w)
 assert.Error(t, err)
 assert.Equal(t, "write error", err.Error())
 }
+
+func TestPrintWeather_Near(t *testing.T) {
+	loc := models.Location{
+		Name:      "52.5200°N, 13.4100°E",
+		Latitude:  52.52,
+		Longitude: 13.41,
+		Near:      &models.Location{Name: "Berlin", Country: "Germany", Region: "Berlin"},
+	}
+	var out bytes.Buffer
+
+	err := PrintWeather(&out, Locale{}, loc, mockWeatherResponse{})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "Weather for 52.5200°N, 13.4100°E\nNear Berlin, Germany (Berlin)\n---")
+}
+
+func TestSelectLocation_Details(t *testing.T) {
+	locations := []models.Location{
+		{ID: 4409896, Name: "Springfield", Country: "United States", Region: "Missouri", Admin2: "Greene", Population: 166810, Elevation: 397, Postcodes: []string{"65801", "65802"}},
+		{ID: 4250542, Name: "Springfield", Country: "United States", Region: "Illinois", Admin2: "Sangamon", Admin3: "Capital Township"},
+		{ID: 2950159, Name: "Berlin", Country: "Germany", Region: "Land Berlin", Admin2: "Berlin"},
+	}
+	var out bytes.Buffer
+
+	_, err := SelectLocation(locations, strings.NewReader(""), &out, false, Locale{})
+	assert.Error(t, err)
+	assert.Contains(t, out.String(), ""+
+		"1. Springfield, United States (Missouri) - Greene, 65801, pop. 166,810, 397 m\n"+
+		"2. Springfield, United States (Illinois) - Sangamon, Capital Township\n"+
+		"3. Berlin, Germany (Land Berlin)\n")
+}
+
+func TestFormatCount(t *testing.T) {
+	assert.Equal(t, "0", Locale{}.formatCount(0))
+	assert.Equal(t, "999", Locale{}.formatCount(999))
+	assert.Equal(t, "1,000", Locale{}.formatCount(1000))
+	assert.Equal(t, "3,426,354", Locale{}.formatCount(3426354))
+	assert.Equal(t, "3.426.354", locales["de"].formatCount(3426354))
+	assert.Equal(t, "3\u202f426\u202f354", locales["fr"].formatCount(3426354))
+}
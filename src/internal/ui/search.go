@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"weather-reporter/src/internal/models"
+)
+
+// SearchSchemaVersion is the version of the JSON document written by
+// PrintLocationsJSON. It follows the same rules as WeatherSchemaVersion.
+const SearchSchemaVersion = 1
+
+// searchDocument is the JSON representation of search results.
+type searchDocument struct {
+	SchemaVersion int                `json:"schema_version"`
+	Query         string             `json:"query"`
+	Results       []locationDocument `json:"results"`
+}
+
+// PrintLocationTable prints search results as a table with one location
+// per row, including the geocoding ID and coordinates.
+func PrintLocationTable(out io.Writer, locations []models.Location) error {
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "ID\tName\tCountry\tCode\tAdmin1\tLatitude\tLongitude"); err != nil {
+		return err
+	}
+	for _, loc := range locations {
+		if _, err := fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%.4f\t%.4f\n",
+			loc.ID, loc.Name, loc.Country, loc.CountryCode, loc.Region, loc.Latitude, loc.Longitude); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+// PrintLocationsJSON writes the search results for query as a versioned
+// JSON document. The schema is documented in README.md.
+func PrintLocationsJSON(out io.Writer, query string, locations []models.Location) error {
+	doc := searchDocument{
+		SchemaVersion: SearchSchemaVersion,
+		Query:         query,
+		Results:       make([]locationDocument, 0, len(locations)),
+	}
+	for _, loc := range locations {
+		doc.Results = append(doc.Results, newLocationDocument(loc))
+	}
+	return writeJSON(out, doc)
+}
+
+// PrintLocationsCSV writes the search results as CSV with a header row.
+// Coordinates are written with full precision.
+func PrintLocationsCSV(out io.Writer, locations []models.Location) error {
+	w := csv.NewWriter(out)
+	if err := w.Write([]string{"id", "name", "country", "country_code", "admin1", "latitude", "longitude"}); err != nil {
+		return err
+	}
+	for _, loc := range locations {
+		record := []string{
+			strconv.Itoa(loc.ID),
+			loc.Name,
+			loc.Country,
+			loc.CountryCode,
+			loc.Region,
+			strconv.FormatFloat(loc.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(loc.Longitude, 'f', -1, 64),
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var portlands = []models.Location{
+	{ID: 5746545, Name: "Portland", Latitude: 45.52345, Longitude: -122.67621, Country: "United States", CountryCode: "US", Region: "Oregon", Admin2: "Multnomah", Timezone: "America/Los_Angeles", Population: 652503, Postcodes: []string{"97201"}, FeatureCode: "PPLA2"},
+	{ID: 4975802, Name: "Portland", Latitude: 43.66147, Longitude: -70.25533, Country: "United States", CountryCode: "US", Region: "Maine"},
+}
+
+func TestPrintLocationTable(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, PrintLocationTable(&out, portlands))
+
+	assert.Equal(t, ""+
+		"ID       Name      Country        Code  Admin1  Latitude  Longitude\n"+
+		"5746545  Portland  United States  US    Oregon  45.5234   -122.6762\n"+
+		"4975802  Portland  United States  US    Maine   43.6615   -70.2553\n", out.String())
+}
+
+func TestPrintLocationTable_Error(t *testing.T) {
+	err := PrintLocationTable(errorWriter{}, []models.Location{{Name: "Test"}})
+	assert.EqualError(t, err, "write error")
+}
+
+func TestPrintLocationsJSON(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, PrintLocationsJSON(&out, "Portland", portlands))
+
+	var doc struct {
+		SchemaVersion int    `json:"schema_version"`
+		Query         string `json:"query"`
+		Results       []struct {
+			ID          int     `json:"id"`
+			CountryCode string  `json:"country_code"`
+			Admin1      string  `json:"admin1"`
+			Latitude    float64 `json:"latitude"`
+			Population  int     `json:"population"`
+			Timezone    string  `json:"timezone"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.Equal(t, SearchSchemaVersion, doc.SchemaVersion)
+	assert.Equal(t, "Portland", doc.Query)
+	require.Len(t, doc.Results, 2)
+	assert.Equal(t, 5746545, doc.Results[0].ID)
+	assert.Equal(t, "US", doc.Results[0].CountryCode)
+	assert.Equal(t, "Maine", doc.Results[1].Admin1)
+	assert.Equal(t, 43.66147, doc.Results[1].Latitude)
+	assert.Equal(t, 652503, doc.Results[0].Population)
+	assert.Equal(t, "America/Los_Angeles", doc.Results[0].Timezone)
+	assert.NotContains(t, out.String(), `"admin3"`, "unknown details are left out")
+}
+
+func TestPrintLocationsJSON_Empty(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, PrintLocationsJSON(&out, "Nowhere", nil))
+	assert.Contains(t, out.String(), `"results": []`)
+}
+
+func TestPrintLocationsCSV(t *testing.T) {
+	locations := append([]models.Location{}, portlands...)
+	locations = append(locations, models.Location{ID: 1, Name: "Washington, D.C.", Country: "United States", CountryCode: "US"})
+	var out bytes.Buffer
+
+	require.NoError(t, PrintLocationsCSV(&out, locations))
+
+	assert.Equal(t, ""+
+		"id,name,country,country_code,admin1,latitude,longitude\n"+
+		"5746545,Portland,United States,US,Oregon,45.52345,-122.67621\n"+
+		"4975802,Portland,United States,US,Maine,43.66147,-70.25533\n"+
+		"1,\"Washington, D.C.\",United States,US,,0,0\n", out.String())
+}
+
+func TestPrintLocationsCSV_Error(t *testing.T) {
+	err := PrintLocationsCSV(errorWriter{}, portlands)
+	assert.EqualError(t, err, "write error")
+}
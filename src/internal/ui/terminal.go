@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Terminal is a terminal that PickLocation reads keys from and draws on.
+type Terminal interface {
+	io.ReadWriter
+
+	// MakeRaw switches the terminal to raw mode, in which keys are read
+	// one at a time without echo, and returns a function that restores
+	// the previous mode.
+	MakeRaw() (restore func() error, err error)
+
+	// Width returns the number of columns, or 0 if it is unknown.
+	Width() int
+}
+
+// OpenTerminal returns the terminal that in and out are connected to. It
+// returns false when either is not a terminal or termType, the value of
+// TERM, names a terminal that cannot move the cursor, such as "dumb"; the
+// numbered prompt of SelectLocation works there instead.
+func OpenTerminal(in io.Reader, out io.Writer, termType string) (Terminal, bool) {
+	inFile, ok := in.(*os.File)
+	if !ok || !term.IsTerminal(int(inFile.Fd())) {
+		return nil, false
+	}
+	outFile, ok := out.(*os.File)
+	if !ok || !term.IsTerminal(int(outFile.Fd())) {
+		return nil, false
+	}
+	if termType == "" || termType == "dumb" {
+		return nil, false
+	}
+	return &fileTerminal{in: inFile, out: outFile}, true
+}
+
+// fileTerminal is a Terminal reading from a terminal device.
+type fileTerminal struct {
+	in  *os.File
+	out io.Writer
+}
+
+// Read reads from the terminal device.
+func (t *fileTerminal) Read(p []byte) (int, error) {
+	return t.in.Read(p)
+}
+
+// Write writes to the terminal output.
+func (t *fileTerminal) Write(p []byte) (int, error) {
+	return t.out.Write(p)
+}
+
+// MakeRaw implements Terminal.
+func (t *fileTerminal) MakeRaw() (func() error, error) {
+	fd := int(t.in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	return func() error { return term.Restore(fd, state) }, nil
+}
+
+// Width implements Terminal.
+func (t *fileTerminal) Width() int {
+	width, _, err := term.GetSize(int(t.in.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,222 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/cache"
+	"weather-reporter/src/internal/models"
+)
+
+const (
+	// CacheTTL is how long a weather response is reused before the weather
+	// service is asked again. Open-Meteo updates current conditions every
+	// 15 minutes, so a short TTL loses little accuracy.
+	CacheTTL = 10 * time.Minute
+
+	// CacheMaxBytes limits the size of the on-disk weather cache.
+	CacheMaxBytes = 4 << 20
+)
+
+// StaleFunc is called when a cached response is served because the weather
+// service failed. age is how old the cached data is and err is the error
+// returned by the service.
+type StaleFunc func(age time.Duration, err error)
+
+// CachedClient implements models.WeatherService by serving repeated
+// requests for nearby coordinates from a cache.Store. When the wrapped
+// service is unreachable or failing, the last cached response is returned
+// regardless of its age and onStale is notified so the caller can label
+// it. Without onStale, failures are returned as they are, so that stale
+// data is never passed off as current.
+type CachedClient struct {
+	next    models.WeatherService
+	store   *cache.Store
+	onStale StaleFunc
+}
+
+// NewCachedClient wraps next with a cache backed by store. onStale may be
+// nil to disable the stale fallback.
+func NewCachedClient(next models.WeatherService, store *cache.Store, onStale StaleFunc) *CachedClient {
+	return &CachedClient{next: next, store: store, onStale: onStale}
+}
+
+// forecastEntry is the cached form of a forecast. The timezone name is kept
+// because JSON only preserves the UTC offset of each time.
+type forecastEntry[T any] struct {
+	Timezone string `json:"timezone"`
+	Items    []T    `json:"items"`
+}
+
+// GetCurrentWeather returns the current weather, from the cache if a
+// response for nearby coordinates is fresh enough.
+func (c *CachedClient) GetCurrentWeather(ctx context.Context, lat, lon float64, units models.Units) (models.WeatherResponse, error) {
+	key := cacheKey("current", 0, lat, lon, units)
+	obs, err := fetchCached(c, key, func() (models.Observation, error) {
+		w, err := c.next.GetCurrentWeather(ctx, lat, lon, units)
+		if err != nil {
+			return models.Observation{}, err
+		}
+		return w.Observation(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return observationResponse{obs}, nil
+}
+
+// GetDailyForecast returns the daily forecast, from the cache if a response
+// for nearby coordinates is fresh enough.
+func (c *CachedClient) GetDailyForecast(ctx context.Context, lat, lon float64, days int, units models.Units) ([]models.DailyForecast, error) {
+	key := cacheKey("daily", days, lat, lon, units)
+	entry, err := fetchCached(c, key, func() (forecastEntry[models.DailyForecast], error) {
+		forecast, err := c.next.GetDailyForecast(ctx, lat, lon, days, units)
+		if err != nil || len(forecast) == 0 {
+			return forecastEntry[models.DailyForecast]{Items: forecast}, err
+		}
+		return forecastEntry[models.DailyForecast]{Timezone: forecast[0].Date.Location().String(), Items: forecast}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range entry.Items {
+		entry.Items[i].Date = inZone(entry.Items[i].Date, entry.Timezone)
+	}
+	return entry.Items, nil
+}
+
+// GetHourlyForecast returns the hourly forecast, from the cache if a
+// response for nearby coordinates is fresh enough.
+func (c *CachedClient) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int, units models.Units) ([]models.HourlyForecast, error) {
+	key := cacheKey("hourly", hours, lat, lon, units)
+	entry, err := fetchCached(c, key, func() (forecastEntry[models.HourlyForecast], error) {
+		forecast, err := c.next.GetHourlyForecast(ctx, lat, lon, hours, units)
+		if err != nil || len(forecast) == 0 {
+			return forecastEntry[models.HourlyForecast]{Items: forecast}, err
+		}
+		return forecastEntry[models.HourlyForecast]{Timezone: forecast[0].Time.Location().String(), Items: forecast}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range entry.Items {
+		entry.Items[i].Time = inZone(entry.Items[i].Time, entry.Timezone)
+	}
+	return entry.Items, nil
+}
+
+// fetchCached returns the fresh cached value for key, or calls get and
+// caches its result. If get fails in a way that stale data can stand in
+// for, the client has an onStale hook and a stale value exists, the stale
+// value is returned and the hook is called.
+func fetchCached[T any](c *CachedClient, key string, get func() (T, error)) (T, error) {
+	var cached T
+	if c.store.Get(key, &cached) {
+		return cached, nil
+	}
+
+	v, err := get()
+	if err == nil {
+		_ = c.store.Put(key, v)
+		return v, nil
+	}
+
+	if c.onStale == nil || !servesStale(err) {
+		return v, err
+	}
+	if age, ok := c.store.GetStale(key, &cached); ok {
+		c.onStale(age, err)
+		return cached, nil
+	}
+	return v, err
+}
+
+// servesStale reports whether stale data may be returned for a request
+// that failed with err: the weather service could not be reached, did not
+// answer in time, failed or throttled us, or its breaker is open. Rejected
+// requests are the caller's mistake and fail, as do canceled ones.
+func servesStale(err error) bool {
+	return errors.Is(err, ErrNetwork) || errors.Is(err, ErrTimeout) ||
+		errors.Is(err, ErrUpstream) || errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, breaker.ErrOpen)
+}
+
+// cacheKey identifies a request. Coordinates are rounded to two decimals
+// (about 1 km) so that nearby lookups share an entry, and units are
+// normalized so the zero value and explicit metric units match.
+func cacheKey(kind string, n int, lat, lon float64, units models.Units) string {
+	return fmt.Sprintf("%s:%d:%.2f,%.2f:%s,%s,%s", kind, n, lat, lon,
+		units.TemperatureSymbol(), units.WindSpeedSymbol(), units.PrecipitationSymbol())
+}
+
+// inZone converts t to the named timezone, falling back to a fixed zone
+// with t's offset if the name is not a known IANA timezone.
+func inZone(t time.Time, name string) time.Time {
+	if name == "" {
+		return t
+	}
+	if loc, err := time.LoadLocation(name); err == nil {
+		return t.In(loc)
+	}
+	_, offset := t.Zone()
+	return t.In(time.FixedZone(name, offset))
+}
+
+// observationResponse implements models.WeatherResponse for a cached
+// observation.
+type observationResponse struct {
+	obs models.Observation
+}
+
+// QuantityOfTemperature returns the temperature.
+func (r observationResponse) QuantityOfTemperature() string {
+	return r.obs.Temperature.String()
+}
+
+// QuantityOfApparentTemperature returns the apparent temperature.
+func (r observationResponse) QuantityOfApparentTemperature() string {
+	return r.obs.ApparentTemperature.String()
+}
+
+// QuantityOfHumidity returns the humidity.
+func (r observationResponse) QuantityOfHumidity() string {
+	return r.obs.Humidity.String()
+}
+
+// QuantityOfPrecipitation returns the precipitation.
+func (r observationResponse) QuantityOfPrecipitation() string {
+	return r.obs.Precipitation.String()
+}
+
+// QuantityOfCloudCover returns the cloud cover.
+func (r observationResponse) QuantityOfCloudCover() string {
+	return r.obs.CloudCover.String()
+}
+
+// QuantityOfPressure returns the pressure.
+func (r observationResponse) QuantityOfPressure() string {
+	return r.obs.Pressure.String()
+}
+
+// QuantityOfWindSpeed returns the wind speed.
+func (r observationResponse) QuantityOfWindSpeed() string {
+	return r.obs.WindSpeed.String()
+}
+
+// QuantityOfWindDirection returns the wind direction.
+func (r observationResponse) QuantityOfWindDirection() string {
+	return r.obs.WindDirection.String()
+}
+
+// QuantityOfWindGusts returns the wind gusts.
+func (r observationResponse) QuantityOfWindGusts() string {
+	return r.obs.WindGusts.String()
+}
+
+// Observation returns the cached observation.
+func (r observationResponse) Observation() models.Observation {
+	return r.obs
+}
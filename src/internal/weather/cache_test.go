@@ -0,0 +1,203 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/breaker"
+	"weather-reporter/src/internal/cache"
+	"weather-reporter/src/internal/models"
+)
+
+// fakeService is a models.WeatherService that counts calls and can be
+// switched to fail.
+type fakeService struct {
+	calls int
+	err   error
+	obs   models.Observation
+	daily []models.DailyForecast
+	hours []models.HourlyForecast
+}
+
+func (f *fakeService) GetCurrentWeather(ctx context.Context, lat, lon float64, units models.Units) (models.WeatherResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return observationResponse{f.obs}, nil
+}
+
+func (f *fakeService) GetDailyForecast(ctx context.Context, lat, lon float64, days int, units models.Units) ([]models.DailyForecast, error) {
+	f.calls++
+	return f.daily, f.err
+}
+
+func (f *fakeService) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int, units models.Units) ([]models.HourlyForecast, error) {
+	f.calls++
+	return f.hours, f.err
+}
+
+func newTestCachedClient(t *testing.T, next models.WeatherService, ttl time.Duration, onStale StaleFunc) *CachedClient {
+	t.Helper()
+	return NewCachedClient(next, cache.NewStore(t.TempDir(), cache.Options{TTL: ttl}), onStale)
+}
+
+func TestCachedClient_GetCurrentWeather(t *testing.T) {
+	next := &fakeService{obs: models.Observation{Temperature: models.Measurement{Value: 12.3, Unit: "°C"}}}
+	c := newTestCachedClient(t, next, CacheTTL, nil)
+
+	for _, coords := range [][2]float64{{52.52, 13.41}, {52.5211, 13.4139}} {
+		w, err := c.GetCurrentWeather(context.Background(), coords[0], coords[1], models.Units{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := w.QuantityOfTemperature(); got != "12.3°C" {
+			t.Errorf("QuantityOfTemperature() = %q, want %q", got, "12.3°C")
+		}
+	}
+	if next.calls != 1 {
+		t.Errorf("service called %d times, want 1", next.calls)
+	}
+
+	// Different units are a different entry.
+	if _, err := c.GetCurrentWeather(context.Background(), 52.52, 13.41, models.ImperialUnits()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if next.calls != 2 {
+		t.Errorf("service called %d times, want 2", next.calls)
+	}
+}
+
+func TestCachedClient_StaleFallback(t *testing.T) {
+	next := &fakeService{obs: models.Observation{Temperature: models.Measurement{Value: 12.3, Unit: "°C"}}}
+	var staleAge time.Duration
+	var staleErr error
+	// A tiny TTL makes every entry expire right after it is written.
+	c := newTestCachedClient(t, next, time.Nanosecond, func(age time.Duration, err error) {
+		staleAge, staleErr = age, err
+	})
+
+	if _, err := c.GetCurrentWeather(context.Background(), 52.52, 13.41, models.Units{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	next.err = &RequestError{Kind: ErrNetwork, Err: errors.New("request failed: network is unreachable")}
+	w, err := c.GetCurrentWeather(context.Background(), 52.52, 13.41, models.Units{})
+	if err != nil {
+		t.Fatalf("expected stale data, got error: %v", err)
+	}
+	if got := w.QuantityOfTemperature(); got != "12.3°C" {
+		t.Errorf("QuantityOfTemperature() = %q, want %q", got, "12.3°C")
+	}
+	if staleErr != next.err {
+		t.Errorf("onStale error = %v, want %v", staleErr, next.err)
+	}
+	if staleAge < 0 {
+		t.Errorf("onStale age = %v, want >= 0", staleAge)
+	}
+}
+
+func TestCachedClient_StaleFallbackCauses(t *testing.T) {
+	tests := []struct {
+		err   error
+		stale bool
+	}{
+		{&RequestError{Kind: ErrNetwork, Err: errors.New("connection refused")}, true},
+		{&RequestError{Kind: ErrTimeout, Err: context.DeadlineExceeded}, true},
+		{&RequestError{Kind: ErrUpstream, StatusCode: 503, Err: errors.New("API returned status 503")}, true},
+		{&RequestError{Kind: ErrRateLimited, StatusCode: 429, Err: errors.New("API returned status 429")}, true},
+		{breaker.ErrOpen, true},
+		{&RequestError{Kind: ErrBadRequest, StatusCode: 400, Err: errors.New("API returned status 400: Invalid unit")}, false},
+		{&RequestError{Kind: ErrBadRequest, Err: errors.New("invalid number of days: 17")}, false},
+		{context.Canceled, false},
+		{errors.New("unknown"), false},
+	}
+
+	for _, tt := range tests {
+		next := &fakeService{daily: []models.DailyForecast{{TemperatureMax: models.Measurement{Value: 3.4, Unit: "°C"}}}}
+		stale := false
+		c := newTestCachedClient(t, next, time.Nanosecond, func(time.Duration, error) { stale = true })
+		if _, err := c.GetDailyForecast(context.Background(), 52.52, 13.41, 1, models.Units{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		next.err = tt.err
+		_, err := c.GetDailyForecast(context.Background(), 52.52, 13.41, 1, models.Units{})
+		if stale != tt.stale {
+			t.Errorf("%v: stale data served = %v, want %v", tt.err, stale, tt.stale)
+		}
+		if tt.stale == (err != nil) {
+			t.Errorf("%v: error = %v", tt.err, err)
+		}
+		if !tt.stale && !errors.Is(err, tt.err) {
+			t.Errorf("error = %v, want %v", err, tt.err)
+		}
+	}
+}
+
+func TestCachedClient_NoStaleFallbackWithoutHook(t *testing.T) {
+	next := &fakeService{daily: []models.DailyForecast{{TemperatureMax: models.Measurement{Value: 3.4, Unit: "°C"}}}}
+	c := newTestCachedClient(t, next, time.Nanosecond, nil)
+	if _, err := c.GetDailyForecast(context.Background(), 52.52, 13.41, 1, models.Units{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	next.err = &RequestError{Kind: ErrNetwork, Err: errors.New("connection refused")}
+	if _, err := c.GetDailyForecast(context.Background(), 52.52, 13.41, 1, models.Units{}); !errors.Is(err, ErrNetwork) {
+		t.Errorf("error = %v, want %v", err, ErrNetwork)
+	}
+}
+
+func TestCachedClient_ErrorWithoutCachedData(t *testing.T) {
+	next := &fakeService{err: errors.New("request failed")}
+	c := newTestCachedClient(t, next, CacheTTL, func(time.Duration, error) {
+		t.Error("onStale must not be called without cached data")
+	})
+
+	if _, err := c.GetDailyForecast(context.Background(), 52.52, 13.41, 3, models.Units{}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestCachedClient_ForecastKeepsTimezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+	next := &fakeService{
+		daily: []models.DailyForecast{{Date: time.Date(2026, 3, 1, 0, 0, 0, 0, berlin)}},
+		hours: []models.HourlyForecast{{Time: time.Date(2026, 3, 1, 14, 0, 0, 0, berlin)}},
+	}
+	c := newTestCachedClient(t, next, CacheTTL, nil)
+
+	for i := 0; i < 2; i++ {
+		hours, err := c.GetHourlyForecast(context.Background(), 52.52, 13.41, 1, models.Units{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := hours[0].Time.Format("15:04 MST"); got != "14:00 CET" {
+			t.Errorf("run %d: hour = %q, want %q", i, got, "14:00 CET")
+		}
+		days, err := c.GetDailyForecast(context.Background(), 52.52, 13.41, 1, models.Units{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := days[0].Date.Location().String(); got != "Europe/Berlin" {
+			t.Errorf("run %d: date location = %q, want %q", i, got, "Europe/Berlin")
+		}
+	}
+	if next.calls != 2 {
+		t.Errorf("service called %d times, want 2", next.calls)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	if a, b := cacheKey("current", 0, 52.52, 13.41, models.Units{}), cacheKey("current", 0, 52.5249, 13.4051, models.MetricUnits()); a != b {
+		t.Errorf("cacheKey mismatch: %q != %q", a, b)
+	}
+	if a, b := cacheKey("daily", 3, 52.52, 13.41, models.Units{}), cacheKey("daily", 7, 52.52, 13.41, models.Units{}); a == b {
+		t.Errorf("cacheKey should differ by day count: %q", a)
+	}
+}
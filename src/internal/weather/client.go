@@ -12,9 +12,16 @@ import (
 	meteosdk "github.com/gregbalnis/open-meteo-weather-sdk"
 )
 
+const defaultBaseURL = "https://api.open-meteo.com/v1"
+
 // Client is a client for the weather API.
+// Current conditions are fetched through the Open-Meteo SDK; forecasts,
+// which the SDK does not cover, are requested from the same API using
+// the client's HTTP client.
 type Client struct {
-	sdkClient *meteosdk.Client
+	httpClient *http.Client
+	baseURL    string
+	sdkClient  *meteosdk.Client
 }
 
 // NewClient creates a new weather client.
@@ -25,68 +32,102 @@ func NewClient(httpClient *http.Client) *Client {
 			Timeout: 10 * time.Second,
 		}
 	}
+
+	// The SDK always requests metric units, so its requests go through a
+	// transport that adds the units selected for each call.
+	sdkHTTPClient := *httpClient
+	sdkHTTPClient.Transport = &unitsTransport{base: httpClient.Transport}
+
 	return &Client{
-		sdkClient: meteosdk.NewClient(meteosdk.WithHTTPClient(httpClient)),
+		httpClient: httpClient,
+		baseURL:    defaultBaseURL,
+		sdkClient:  meteosdk.NewClient(meteosdk.WithHTTPClient(&sdkHTTPClient)),
 	}
 }
 
-// GetCurrentWeather fetches the current weather for the given coordinates.
-func (c *Client) GetCurrentWeather(ctx context.Context, lat, lon float64) (models.WeatherResponse, error) {
-	resp, err := c.sdkClient.GetCurrentWeather(ctx, lat, lon)
+// GetCurrentWeather fetches the current weather for the given coordinates
+// in the requested units. Failed requests return a *RequestError.
+func (c *Client) GetCurrentWeather(ctx context.Context, lat, lon float64, units models.Units) (models.WeatherResponse, error) {
+	resp, err := c.sdkClient.GetCurrentWeather(withUnits(ctx, units), lat, lon)
 	if err != nil {
-		return nil, err
+		return nil, newSDKError(err)
 	}
 	if resp == nil {
 		return nil, fmt.Errorf("received nil response from SDK")
 	}
-	return &weatherResponseAdapter{resp}, nil
+	return &weatherResponseAdapter{CurrentWeather: resp, units: units}, nil
 }
 
+// weatherResponseAdapter adapts the SDK's CurrentWeather to
+// models.WeatherResponse. The SDK's own formatted accessors assume metric
+// units, so all values are formatted from the typed observation instead.
 type weatherResponseAdapter struct {
 	*meteosdk.CurrentWeather
+	units models.Units
 }
 
 // QuantityOfTemperature returns the temperature.
 func (w *weatherResponseAdapter) QuantityOfTemperature() string {
-	return w.CurrentWeather.QuantityOfTemperature()
+	return w.Observation().Temperature.String()
 }
 
 // QuantityOfHumidity returns the humidity.
 func (w *weatherResponseAdapter) QuantityOfHumidity() string {
-	return w.QuantityOfRelativeHumidity()
+	return w.Observation().Humidity.String()
 }
 
 // QuantityOfApparentTemperature returns the apparent temperature.
 func (w *weatherResponseAdapter) QuantityOfApparentTemperature() string {
-	return w.CurrentWeather.QuantityOfApparentTemperature()
+	return w.Observation().ApparentTemperature.String()
 }
 
 // QuantityOfPrecipitation returns the precipitation.
 func (w *weatherResponseAdapter) QuantityOfPrecipitation() string {
-	return w.CurrentWeather.QuantityOfPrecipitation()
+	return w.Observation().Precipitation.String()
 }
 
 // QuantityOfCloudCover returns the cloud cover.
 func (w *weatherResponseAdapter) QuantityOfCloudCover() string {
-	return w.CurrentWeather.QuantityOfCloudCover()
+	return w.Observation().CloudCover.String()
 }
 
 // QuantityOfPressure returns the pressure.
 func (w *weatherResponseAdapter) QuantityOfPressure() string {
-	return w.QuantityOfSurfacePressure()
+	return w.Observation().Pressure.String()
 }
 
 // QuantityOfWindSpeed returns the wind speed.
 func (w *weatherResponseAdapter) QuantityOfWindSpeed() string {
-	return w.CurrentWeather.QuantityOfWindSpeed()
+	return w.Observation().WindSpeed.String()
 }
 
 // QuantityOfWindDirection returns the wind direction.
 func (w *weatherResponseAdapter) QuantityOfWindDirection() string {
-	return w.CurrentWeather.QuantityOfWindDirection()
+	return w.Observation().WindDirection.String()
 }
 
 // QuantityOfWindGusts returns the wind gusts.
 func (w *weatherResponseAdapter) QuantityOfWindGusts() string {
-	return w.CurrentWeather.QuantityOfWindGusts()
+	return w.Observation().WindGusts.String()
+}
+
+// Observation returns the current conditions as typed measurements in the
+// units they were requested in.
+func (w *weatherResponseAdapter) Observation() models.Observation {
+	cw := w.CurrentWeather
+	measure := func(value float64, unit string) models.Measurement {
+		return models.Measurement{Value: value, Unit: unit, Time: cw.Time}
+	}
+	return models.Observation{
+		Time:                cw.Time,
+		Temperature:         measure(cw.Temperature, w.units.TemperatureSymbol()),
+		ApparentTemperature: measure(cw.ApparentTemperature, w.units.TemperatureSymbol()),
+		Humidity:            measure(cw.RelativeHumidity, "%"),
+		Precipitation:       measure(cw.Precipitation, w.units.PrecipitationSymbol()),
+		CloudCover:          measure(cw.CloudCover, "%"),
+		Pressure:            measure(cw.SurfacePressure, "hPa"),
+		WindSpeed:           measure(cw.WindSpeed, w.units.WindSpeedSymbol()),
+		WindDirection:       measure(cw.WindDirection, "°"),
+		WindGusts:           measure(cw.WindGusts, w.units.WindSpeedSymbol()),
+	}
 }
@@ -5,7 +5,12 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/url"
 	"testing"
+	"time"
+
+	"weather-reporter/src/internal/models"
+	"weather-reporter/src/internal/retry"
 )
 
 // roundTripFunc .
@@ -75,7 +80,7 @@ func TestGetCurrentWeather(t *testing.T) {
 	client := NewClient(httpClient)
 
 	ctx := context.Background()
-	resp, err := client.GetCurrentWeather(ctx, 52.52, 13.41)
+	resp, err := client.GetCurrentWeather(ctx, 52.52, 13.41, models.Units{})
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -114,6 +119,153 @@ func TestGetCurrentWeather(t *testing.T) {
 	}
 }
 
+func TestGetCurrentWeather_Observation(t *testing.T) {
+	jsonResponse := `{
+		"latitude": 52.52,
+		"longitude": 13.419998,
+		"current": {
+			"time": "2026-01-01T06:30",
+			"temperature_2m": 2.5,
+			"relative_humidity_2m": 76,
+			"apparent_temperature": -2.8,
+			"precipitation": 0.1,
+			"cloud_cover": 99,
+			"surface_pressure": 997.4,
+			"wind_speed_10m": 20.2,
+			"wind_direction_10m": 239,
+			"wind_gusts_10m": 46.1
+		}
+	}`
+	httpClient := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	})
+
+	resp, err := NewClient(httpClient).GetCurrentWeather(context.Background(), 52.52, 13.41, models.Units{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	obs := resp.Observation()
+	wantTime := time.Date(2026, 1, 1, 6, 30, 0, 0, time.UTC)
+	if !obs.Time.Equal(wantTime) {
+		t.Errorf("Time = %v, want %v", obs.Time, wantTime)
+	}
+
+	tests := []struct {
+		name  string
+		got   models.Measurement
+		value float64
+		unit  string
+	}{
+		{"Temperature", obs.Temperature, 2.5, "°C"},
+		{"ApparentTemperature", obs.ApparentTemperature, -2.8, "°C"},
+		{"Humidity", obs.Humidity, 76, "%"},
+		{"Precipitation", obs.Precipitation, 0.1, "mm"},
+		{"CloudCover", obs.CloudCover, 99, "%"},
+		{"Pressure", obs.Pressure, 997.4, "hPa"},
+		{"WindSpeed", obs.WindSpeed, 20.2, "km/h"},
+		{"WindDirection", obs.WindDirection, 239, "°"},
+		{"WindGusts", obs.WindGusts, 46.1, "km/h"},
+	}
+	for _, tt := range tests {
+		if tt.got.Value != tt.value || tt.got.Unit != tt.unit {
+			t.Errorf("%s = %v %q, want %v %q", tt.name, tt.got.Value, tt.got.Unit, tt.value, tt.unit)
+		}
+		if !tt.got.Time.Equal(wantTime) {
+			t.Errorf("%s.Time = %v, want %v", tt.name, tt.got.Time, wantTime)
+		}
+	}
+
+	// The formatted accessors and the typed measurements must agree.
+	if got, want := obs.Temperature.String(), resp.QuantityOfTemperature(); got != want {
+		t.Errorf("Temperature.String() = %q, want %q", got, want)
+	}
+	if got, want := obs.Pressure.String(), resp.QuantityOfPressure(); got != want {
+		t.Errorf("Pressure.String() = %q, want %q", got, want)
+	}
+}
+
+func TestGetCurrentWeather_ImperialUnits(t *testing.T) {
+	jsonResponse := `{
+		"current": {
+			"time": "2026-01-01T06:30",
+			"temperature_2m": 36.5,
+			"apparent_temperature": 27,
+			"precipitation": 0.02,
+			"wind_speed_10m": 12.6,
+			"wind_gusts_10m": 28.6
+		}
+	}`
+	var gotQuery url.Values
+	httpClient := NewTestClient(func(req *http.Request) *http.Response {
+		gotQuery = req.URL.Query()
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	})
+
+	resp, err := NewClient(httpClient).GetCurrentWeather(context.Background(), 40.71, -74.01, models.ImperialUnits())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for param, want := range map[string]string{
+		"temperature_unit":   "fahrenheit",
+		"wind_speed_unit":    "mph",
+		"precipitation_unit": "inch",
+	} {
+		if got := gotQuery.Get(param); got != want {
+			t.Errorf("%s = %q, want %q", param, got, want)
+		}
+	}
+	if got := gotQuery.Get("current"); got == "" {
+		t.Error("SDK query parameters were dropped")
+	}
+
+	if got := resp.QuantityOfTemperature(); got != "36.5°F" {
+		t.Errorf("QuantityOfTemperature() = %v, want %v", got, "36.5°F")
+	}
+	if got := resp.QuantityOfApparentTemperature(); got != "27.0°F" {
+		t.Errorf("QuantityOfApparentTemperature() = %v, want %v", got, "27.0°F")
+	}
+	if got := resp.QuantityOfPrecipitation(); got != "0.02 inch" {
+		t.Errorf("QuantityOfPrecipitation() = %v, want %v", got, "0.02 inch")
+	}
+	if got := resp.QuantityOfWindSpeed(); got != "12.6 mph" {
+		t.Errorf("QuantityOfWindSpeed() = %v, want %v", got, "12.6 mph")
+	}
+	if got := resp.QuantityOfWindGusts(); got != "28.6 mph" {
+		t.Errorf("QuantityOfWindGusts() = %v, want %v", got, "28.6 mph")
+	}
+}
+
+func TestGetCurrentWeather_DefaultUnits(t *testing.T) {
+	var gotQuery url.Values
+	httpClient := NewTestClient(func(req *http.Request) *http.Response {
+		gotQuery = req.URL.Query()
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"current": {}}`)),
+			Header:     make(http.Header),
+		}
+	})
+
+	if _, err := NewClient(httpClient).GetCurrentWeather(context.Background(), 0, 0, models.Units{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, param := range []string{"temperature_unit", "wind_speed_unit", "precipitation_unit"} {
+		if gotQuery.Has(param) {
+			t.Errorf("unexpected %s parameter for default units", param)
+		}
+	}
+}
+
 func TestGetCurrentWeather_Error(t *testing.T) {
 	httpClient := NewTestClient(func(req *http.Request) *http.Response {
 		return &http.Response{
@@ -124,12 +276,42 @@ func TestGetCurrentWeather_Error(t *testing.T) {
 	})
 
 	client := NewClient(httpClient)
-	_, err := client.GetCurrentWeather(context.Background(), 0, 0)
+	_, err := client.GetCurrentWeather(context.Background(), 0, 0, models.Units{})
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
 }
 
+func TestGetCurrentWeather_RetriesTransientFailure(t *testing.T) {
+	var attempts int
+	transport := retry.NewTransport(roundTripFunc(func(req *http.Request) *http.Response {
+		attempts++
+		if attempts == 1 {
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(bytes.NewBufferString("Bad Gateway")),
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"current": {"temperature_2m": 2.5}}`)),
+			Header:     make(http.Header),
+		}
+	}), retry.Policy{Attempts: 3, Backoff: time.Millisecond})
+
+	w, err := NewClient(&http.Client{Transport: transport}).GetCurrentWeather(context.Background(), 0, 0, models.MetricUnits())
+	if err != nil {
+		t.Fatalf("Expected no error after a retry, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+	if got := w.Observation().Temperature.Value; got != 2.5 {
+		t.Errorf("Expected temperature 2.5, got %v", got)
+	}
+}
+
 func TestNewClient_Default(t *testing.T) {
 	client := NewClient(nil)
 	if client == nil {
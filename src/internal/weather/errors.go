@@ -0,0 +1,114 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	meteosdk "github.com/gregbalnis/open-meteo-weather-sdk"
+)
+
+// Causes of a failed request. The errors returned by Client are
+// *RequestError values that match one of them with errors.Is, unless the
+// cause is not known.
+var (
+	// ErrNetwork means the weather service could not be reached.
+	ErrNetwork = errors.New("network unreachable")
+	// ErrTimeout means the request did not finish in time.
+	ErrTimeout = errors.New("timeout")
+	// ErrRateLimited means the service turned the request away for making
+	// too many requests.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrBadRequest means the request parameters are invalid, or the
+	// service rejected them with a 4xx status.
+	ErrBadRequest = errors.New("bad request")
+	// ErrUpstream means the service failed with a 5xx status.
+	ErrUpstream = errors.New("weather service error")
+	// ErrConcurrencyLimit means the request was not sent because the client
+	// already had as many requests in flight as the SDK allows. Unlike the
+	// other kinds, it says nothing about the service.
+	ErrConcurrencyLimit = errors.New("concurrency limit exceeded")
+)
+
+// sdkConcurrencyLimit starts the message of the SDK's validation error for
+// requests beyond its concurrency limit, for which it has no sentinel.
+const sdkConcurrencyLimit = "concurrent request limit exceeded"
+
+// RequestError is a failed weather request.
+type RequestError struct {
+	// Kind is the cause of the failure, one of the Err variables above, or
+	// nil if it is not known.
+	Kind error
+	// StatusCode is the HTTP status of the response, or 0 if there was
+	// none.
+	StatusCode int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the message of the underlying error.
+func (e *RequestError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the kind and the underlying error, so that errors.Is
+// matches either.
+func (e *RequestError) Unwrap() []error {
+	if e.Kind == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// newRequestError classifies err, given the HTTP status of the response if
+// there was one.
+func newRequestError(err error, statusCode int) *RequestError {
+	return &RequestError{Kind: classify(err, statusCode), StatusCode: statusCode, Err: err}
+}
+
+// newSDKError classifies an error of the SDK, which only reports the HTTP
+// status in the message of its API errors.
+func newSDKError(err error) *RequestError {
+	var sdkErr *meteosdk.Error
+	statusCode := 0
+	if errors.As(err, &sdkErr) && sdkErr.Type == meteosdk.ErrorTypeAPI {
+		_, _ = fmt.Sscanf(sdkErr.Message, "API returned status %d", &statusCode)
+	}
+	e := newRequestError(err, statusCode)
+	if e.Kind == nil && sdkErr != nil && !errors.Is(err, context.Canceled) {
+		switch {
+		case sdkErr.Type == meteosdk.ErrorTypeValidation && strings.HasPrefix(sdkErr.Message, sdkConcurrencyLimit):
+			e.Kind = ErrConcurrencyLimit
+		case sdkErr.Type == meteosdk.ErrorTypeValidation:
+			e.Kind = ErrBadRequest
+		case sdkErr.Type == meteosdk.ErrorTypeNetwork:
+			e.Kind = ErrNetwork
+		}
+	}
+	return e
+}
+
+func classify(err error, statusCode int) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return ErrTimeout
+	case errors.Is(err, context.Canceled):
+		return nil
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= 500:
+		return ErrUpstream
+	case statusCode >= 400:
+		return ErrBadRequest
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return ErrNetwork
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package weather
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"weather-reporter/src/internal/models"
+)
+
+func TestClient_ErrorKinds(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{status: http.StatusBadRequest, want: ErrBadRequest},
+		{status: http.StatusTooManyRequests, want: ErrRateLimited},
+		{status: http.StatusInternalServerError, want: ErrUpstream},
+		{status: http.StatusBadGateway, want: ErrUpstream},
+	}
+
+	for _, tt := range tests {
+		client := NewClient(NewTestClient(func(req *http.Request) *http.Response {
+			return &http.Response{
+				StatusCode: tt.status,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"error": true, "reason": "Invalid value"}`)),
+				Header:     make(http.Header),
+			}
+		}))
+
+		_, err := client.GetCurrentWeather(context.Background(), 0, 0, models.Units{})
+		var reqErr *RequestError
+		if !errors.As(err, &reqErr) || !errors.Is(err, tt.want) || reqErr.StatusCode != tt.status {
+			t.Errorf("GetCurrentWeather with HTTP %d: error = %#v, want %v", tt.status, err, tt.want)
+		}
+		_, err = client.GetDailyForecast(context.Background(), 0, 0, 1, models.Units{})
+		if !errors.As(err, &reqErr) || !errors.Is(err, tt.want) || reqErr.StatusCode != tt.status {
+			t.Errorf("GetDailyForecast with HTTP %d: error = %#v, want %v", tt.status, err, tt.want)
+		}
+	}
+}
+
+func TestClient_ErrorKindsWithoutResponse(t *testing.T) {
+	client := NewClient(&http.Client{Transport: failingTransport{errors.New("connection refused")}})
+
+	_, err := client.GetCurrentWeather(context.Background(), 0, 0, models.Units{})
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("GetCurrentWeather: error = %v, want ErrNetwork", err)
+	}
+	_, err = client.GetHourlyForecast(context.Background(), 0, 0, 1, models.Units{})
+	if !errors.Is(err, ErrNetwork) {
+		t.Errorf("GetHourlyForecast: error = %v, want ErrNetwork", err)
+	}
+
+	client = NewClient(&http.Client{Transport: failingTransport{context.DeadlineExceeded}})
+	_, err = client.GetCurrentWeather(context.Background(), 0, 0, models.Units{})
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("GetCurrentWeather: error = %v, want ErrTimeout", err)
+	}
+
+	_, err = client.GetCurrentWeather(context.Background(), 91, 0, models.Units{})
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("GetCurrentWeather with invalid latitude: error = %v, want ErrBadRequest", err)
+	}
+	_, err = client.GetDailyForecast(context.Background(), 0, 0, 0, models.Units{})
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("GetDailyForecast with 0 days: error = %v, want ErrBadRequest", err)
+	}
+}
+
+// failingTransport fails every request with err.
+type failingTransport struct {
+	err error
+}
+
+func (t failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, t.err
+}
+
+func TestRequestError(t *testing.T) {
+	cause := errors.New("API returned status 400: bad")
+	err := &RequestError{Kind: ErrBadRequest, StatusCode: 400, Err: cause}
+
+	if err.Error() != cause.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), cause.Error())
+	}
+	if !errors.Is(err, ErrBadRequest) || !errors.Is(err, cause) {
+		t.Error("errors.Is must match the kind and the cause")
+	}
+	if errors.Is(&RequestError{Err: cause}, ErrBadRequest) {
+		t.Error("an error without a kind must not match any kind")
+	}
+}
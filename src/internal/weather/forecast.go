@@ -0,0 +1,188 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"weather-reporter/src/internal/models"
+)
+
+const (
+	// MaxForecastDays is the longest daily forecast horizon offered by the API.
+	MaxForecastDays = 16
+
+	// MaxForecastHours is the longest hourly forecast horizon supported.
+	MaxForecastHours = 168
+)
+
+const (
+	dailyVariables  = "temperature_2m_min,temperature_2m_max,precipitation_sum,wind_speed_10m_max"
+	hourlyVariables = "temperature_2m,precipitation_probability,wind_speed_10m,wind_direction_10m"
+)
+
+// dailyResponse mirrors the subset of the forecast endpoint response used
+// for daily forecasts.
+type dailyResponse struct {
+	Timezone         string `json:"timezone"`
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+	Daily            struct {
+		Time             []string   `json:"time"`
+		TemperatureMin   []*float64 `json:"temperature_2m_min"`
+		TemperatureMax   []*float64 `json:"temperature_2m_max"`
+		PrecipitationSum []*float64 `json:"precipitation_sum"`
+		WindSpeedMax     []*float64 `json:"wind_speed_10m_max"`
+	} `json:"daily"`
+}
+
+// hourlyResponse mirrors the subset of the forecast endpoint response used
+// for hourly forecasts.
+type hourlyResponse struct {
+	Timezone         string `json:"timezone"`
+	UTCOffsetSeconds int    `json:"utc_offset_seconds"`
+	Hourly           struct {
+		Time                     []string   `json:"time"`
+		Temperature              []*float64 `json:"temperature_2m"`
+		PrecipitationProbability []*float64 `json:"precipitation_probability"`
+		WindSpeed                []*float64 `json:"wind_speed_10m"`
+		WindDirection            []*float64 `json:"wind_direction_10m"`
+	} `json:"hourly"`
+}
+
+// GetDailyForecast fetches a daily forecast for the given coordinates in
+// the requested units. days must be between 1 and MaxForecastDays. Dates
+// are expressed in the location's local timezone.
+func (c *Client) GetDailyForecast(ctx context.Context, lat, lon float64, days int, units models.Units) ([]models.DailyForecast, error) {
+	if days < 1 || days > MaxForecastDays {
+		return nil, &RequestError{Kind: ErrBadRequest, Err: fmt.Errorf("invalid number of days: %d (must be between 1 and %d)", days, MaxForecastDays)}
+	}
+
+	params := url.Values{}
+	params.Set("daily", dailyVariables)
+	params.Set("forecast_days", strconv.Itoa(days))
+	setUnitParams(params, units)
+
+	var resp dailyResponse
+	if err := c.getForecast(ctx, lat, lon, params, &resp); err != nil {
+		return nil, err
+	}
+
+	loc := responseLocation(resp.Timezone, resp.UTCOffsetSeconds)
+	d := resp.Daily
+	forecast := make([]models.DailyForecast, 0, len(d.Time))
+	for i, day := range d.Time {
+		date, err := time.ParseInLocation(time.DateOnly, day, loc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse forecast date %q: %w", day, err)
+		}
+		forecast = append(forecast, models.DailyForecast{
+			Date:             date,
+			TemperatureMin:   measurementAt(d.TemperatureMin, i, units.TemperatureSymbol(), date),
+			TemperatureMax:   measurementAt(d.TemperatureMax, i, units.TemperatureSymbol(), date),
+			PrecipitationSum: measurementAt(d.PrecipitationSum, i, units.PrecipitationSymbol(), date),
+			WindSpeedMax:     measurementAt(d.WindSpeedMax, i, units.WindSpeedSymbol(), date),
+		})
+	}
+	return forecast, nil
+}
+
+// GetHourlyForecast fetches an hourly forecast for the given coordinates in
+// the requested units, starting at the current hour. hours must be between
+// 1 and MaxForecastHours.
+func (c *Client) GetHourlyForecast(ctx context.Context, lat, lon float64, hours int, units models.Units) ([]models.HourlyForecast, error) {
+	if hours < 1 || hours > MaxForecastHours {
+		return nil, &RequestError{Kind: ErrBadRequest, Err: fmt.Errorf("invalid number of hours: %d (must be between 1 and %d)", hours, MaxForecastHours)}
+	}
+
+	params := url.Values{}
+	params.Set("hourly", hourlyVariables)
+	params.Set("forecast_hours", strconv.Itoa(hours))
+	setUnitParams(params, units)
+
+	var resp hourlyResponse
+	if err := c.getForecast(ctx, lat, lon, params, &resp); err != nil {
+		return nil, err
+	}
+
+	loc := responseLocation(resp.Timezone, resp.UTCOffsetSeconds)
+	h := resp.Hourly
+	forecast := make([]models.HourlyForecast, 0, len(h.Time))
+	for i, hour := range h.Time {
+		t, err := time.ParseInLocation("2006-01-02T15:04", hour, loc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse forecast time %q: %w", hour, err)
+		}
+		forecast = append(forecast, models.HourlyForecast{
+			Time:                     t,
+			Temperature:              measurementAt(h.Temperature, i, units.TemperatureSymbol(), t),
+			PrecipitationProbability: measurementAt(h.PrecipitationProbability, i, "%", t),
+			WindSpeed:                measurementAt(h.WindSpeed, i, units.WindSpeedSymbol(), t),
+			WindDirection:            measurementAt(h.WindDirection, i, "°", t),
+		})
+	}
+	return forecast, nil
+}
+
+// responseLocation resolves the timezone reported by the API. If the IANA
+// name is unknown to the local tz database, a fixed offset zone is used.
+func responseLocation(name string, offsetSeconds int) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.FixedZone(name, offsetSeconds)
+}
+
+// getForecast issues a request to the forecast endpoint for the given
+// coordinates and decodes the JSON response into out. Failed requests
+// return a *RequestError.
+func (c *Client) getForecast(ctx context.Context, lat, lon float64, params url.Values, out any) error {
+	u, err := url.Parse(c.baseURL + "/forecast")
+	if err != nil {
+		return fmt.Errorf("failed to build request URL: %w", err)
+	}
+	params.Set("latitude", strconv.FormatFloat(lat, 'f', -1, 64))
+	params.Set("longitude", strconv.FormatFloat(lon, 'f', -1, 64))
+	params.Set("timezone", "auto")
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return newRequestError(fmt.Errorf("failed to execute HTTP request: %w", err), 0)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return newRequestError(fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body)), resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return newRequestError(fmt.Errorf("failed to parse JSON response: %w", err), resp.StatusCode)
+	}
+	return nil
+}
+
+// measurementAt returns the i-th value of a forecast series as a
+// measurement. Entries that are null or beyond the end of the series are
+// marked as missing.
+func measurementAt(series []*float64, i int, unit string, t time.Time) models.Measurement {
+	m := models.Measurement{Unit: unit, Time: t}
+	if i < len(series) && series[i] != nil {
+		m.Value = *series[i]
+	} else {
+		m.Missing = true
+	}
+	return m
+}
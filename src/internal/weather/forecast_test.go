@@ -0,0 +1,264 @@
+package weather
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"weather-reporter/src/internal/models"
+)
+
+func TestGetDailyForecast(t *testing.T) {
+	jsonResponse := `{
+		"latitude": 52.52,
+		"longitude": 13.419998,
+		"timezone": "Europe/Berlin",
+		"utc_offset_seconds": 3600,
+		"daily_units": {
+			"time": "iso8601",
+			"temperature_2m_min": "°C",
+			"temperature_2m_max": "°C",
+			"precipitation_sum": "mm",
+			"wind_speed_10m_max": "km/h"
+		},
+		"daily": {
+			"time": ["2026-01-01", "2026-01-02"],
+			"temperature_2m_min": [-1.5, 0.2],
+			"temperature_2m_max": [3.4, 5.1],
+			"precipitation_sum": [0.0, 2.7],
+			"wind_speed_10m_max": [18.3, null]
+		}
+	}`
+
+	var gotQuery map[string]string
+	httpClient := NewTestClient(func(req *http.Request) *http.Response {
+		gotQuery = map[string]string{}
+		for k := range req.URL.Query() {
+			gotQuery[k] = req.URL.Query().Get(k)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	})
+
+	client := NewClient(httpClient)
+	days, err := client.GetDailyForecast(context.Background(), 52.52, 13.41, 2, models.Units{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if gotQuery["forecast_days"] != "2" {
+		t.Errorf("forecast_days = %q, want %q", gotQuery["forecast_days"], "2")
+	}
+	if gotQuery["daily"] != dailyVariables {
+		t.Errorf("daily = %q, want %q", gotQuery["daily"], dailyVariables)
+	}
+	if gotQuery["latitude"] != "52.52" || gotQuery["longitude"] != "13.41" {
+		t.Errorf("coordinates = %q,%q, want 52.52,13.41", gotQuery["latitude"], gotQuery["longitude"])
+	}
+
+	if len(days) != 2 {
+		t.Fatalf("len(days) = %d, want 2", len(days))
+	}
+	if want := time.Date(2026, 1, 1, 23, 0, 0, 0, time.UTC); !days[1].Date.Equal(want) {
+		t.Errorf("Date = %v, want local midnight %v", days[1].Date, want)
+	}
+	if got := days[1].Date.Format("2006-01-02 15:04 -0700"); got != "2026-01-02 00:00 +0100" {
+		t.Errorf("Date = %q, want %q", got, "2026-01-02 00:00 +0100")
+	}
+	if got := days[0].TemperatureMin.String() + "/" + days[0].TemperatureMax.String(); got != "-1.5°C/3.4°C" {
+		t.Errorf("temperatures = %v, want -1.5°C/3.4°C", got)
+	}
+	if got := days[1].PrecipitationSum.String(); got != "2.7 mm" {
+		t.Errorf("PrecipitationSum = %v, want 2.7 mm", got)
+	}
+	if !days[1].WindSpeedMax.Missing || days[1].WindSpeedMax.String() != "n/a" {
+		t.Errorf("WindSpeedMax = %+v, want missing for null value", days[1].WindSpeedMax)
+	}
+	if days[0].WindSpeedMax.Missing {
+		t.Errorf("WindSpeedMax of day 1 is missing, want 18.3")
+	}
+	if !days[1].WindSpeedMax.Time.Equal(days[1].Date) {
+		t.Errorf("WindSpeedMax.Time = %v, want %v", days[1].WindSpeedMax.Time, days[1].Date)
+	}
+}
+
+func TestGetForecast_Units(t *testing.T) {
+	var gotQuery url.Values
+	client := NewClient(NewTestClient(func(req *http.Request) *http.Response {
+		gotQuery = req.URL.Query()
+		return &http.Response{
+			StatusCode: 200,
+			Body: io.NopCloser(bytes.NewBufferString(`{
+				"daily": {"time": ["2026-01-01"], "temperature_2m_max": [41.2], "precipitation_sum": [0.12], "wind_speed_10m_max": [15.5]},
+				"hourly": {"time": ["2026-01-01T09:00"], "temperature_2m": [35.6], "wind_speed_10m": [9.7]}
+			}`)),
+			Header: make(http.Header),
+		}
+	}))
+	units := models.Units{Temperature: models.Fahrenheit, WindSpeed: models.Knots, Precipitation: models.Inches}
+
+	days, err := client.GetDailyForecast(context.Background(), 0, 0, 1, units)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotQuery.Get("temperature_unit") != "fahrenheit" || gotQuery.Get("wind_speed_unit") != "kn" || gotQuery.Get("precipitation_unit") != "inch" {
+		t.Errorf("unit parameters = %v", gotQuery)
+	}
+	if got := days[0].TemperatureMax.String(); got != "41.2°F" {
+		t.Errorf("TemperatureMax = %v, want 41.2°F", got)
+	}
+	if got := days[0].PrecipitationSum.String(); got != "0.12 inch" {
+		t.Errorf("PrecipitationSum = %v, want 0.12 inch", got)
+	}
+	if got := days[0].WindSpeedMax.String(); got != "15.5 kn" {
+		t.Errorf("WindSpeedMax = %v, want 15.5 kn", got)
+	}
+
+	hours, err := client.GetHourlyForecast(context.Background(), 0, 0, 1, units)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotQuery.Get("temperature_unit") != "fahrenheit" {
+		t.Errorf("temperature_unit = %q, want fahrenheit", gotQuery.Get("temperature_unit"))
+	}
+	if got := hours[0].Temperature.String() + " " + hours[0].WindSpeed.String(); got != "35.6°F 9.7 kn" {
+		t.Errorf("hour = %v, want 35.6°F 9.7 kn", got)
+	}
+}
+
+func TestGetDailyForecast_InvalidDays(t *testing.T) {
+	client := NewClient(NewTestClient(func(req *http.Request) *http.Response {
+		t.Fatal("unexpected HTTP request")
+		return nil
+	}))
+
+	for _, days := range []int{0, -1, MaxForecastDays + 1} {
+		if _, err := client.GetDailyForecast(context.Background(), 0, 0, days, models.Units{}); err == nil {
+			t.Errorf("GetDailyForecast(days=%d): expected error, got nil", days)
+		}
+	}
+}
+
+func TestGetDailyForecast_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "API Error", status: 500, body: "Internal Server Error"},
+		{name: "Malformed JSON", status: 200, body: `{"daily": {"time": "not-a-list"}}`},
+		{name: "Bad Date", status: 200, body: `{"daily": {"time": ["01/01/2026"]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(NewTestClient(func(req *http.Request) *http.Response {
+				return &http.Response{
+					StatusCode: tt.status,
+					Body:       io.NopCloser(bytes.NewBufferString(tt.body)),
+					Header:     make(http.Header),
+				}
+			}))
+			if _, err := client.GetDailyForecast(context.Background(), 0, 0, 1, models.Units{}); err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestGetHourlyForecast(t *testing.T) {
+	jsonResponse := `{
+		"latitude": 52.52,
+		"longitude": 13.419998,
+		"timezone": "Europe/Berlin",
+		"utc_offset_seconds": 3600,
+		"hourly": {
+			"time": ["2026-01-01T14:00", "2026-01-01T15:00"],
+			"temperature_2m": [3.1, 2.8],
+			"precipitation_probability": [10, 45],
+			"wind_speed_10m": [12.4, null],
+			"wind_direction_10m": [230, 250]
+		}
+	}`
+
+	var gotHours string
+	client := NewClient(NewTestClient(func(req *http.Request) *http.Response {
+		gotHours = req.URL.Query().Get("forecast_hours")
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	}))
+
+	hours, err := client.GetHourlyForecast(context.Background(), 52.52, 13.41, 2, models.Units{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if gotHours != "2" {
+		t.Errorf("forecast_hours = %q, want %q", gotHours, "2")
+	}
+	if len(hours) != 2 {
+		t.Fatalf("len(hours) = %d, want 2", len(hours))
+	}
+
+	// 14:00 in Berlin during winter is 13:00 UTC.
+	if want := time.Date(2026, 1, 1, 13, 0, 0, 0, time.UTC); !hours[0].Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", hours[0].Time, want)
+	}
+	if got := hours[0].Time.Format("15:04"); got != "14:00" {
+		t.Errorf("local time = %q, want %q", got, "14:00")
+	}
+	if got := hours[1].PrecipitationProbability.String(); got != "45%" {
+		t.Errorf("PrecipitationProbability = %v, want 45%%", got)
+	}
+	if got := hours[1].Temperature.String(); got != "2.8°C" {
+		t.Errorf("Temperature = %v, want 2.8°C", got)
+	}
+	if !hours[1].WindSpeed.Missing || hours[1].WindDirection.Value != 250 {
+		t.Errorf("hour 2 wind = %+v/%v, want missing/250", hours[1].WindSpeed, hours[1].WindDirection.Value)
+	}
+}
+
+func TestGetHourlyForecast_UnknownTimezone(t *testing.T) {
+	jsonResponse := `{
+		"timezone": "Not/AZone",
+		"utc_offset_seconds": -18000,
+		"hourly": {"time": ["2026-01-01T09:00"]}
+	}`
+	client := NewClient(NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(jsonResponse)),
+			Header:     make(http.Header),
+		}
+	}))
+
+	hours, err := client.GetHourlyForecast(context.Background(), 0, 0, 1, models.Units{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if want := time.Date(2026, 1, 1, 14, 0, 0, 0, time.UTC); !hours[0].Time.Equal(want) {
+		t.Errorf("Time = %v, want %v", hours[0].Time, want)
+	}
+}
+
+func TestGetHourlyForecast_InvalidHours(t *testing.T) {
+	client := NewClient(NewTestClient(func(req *http.Request) *http.Response {
+		t.Fatal("unexpected HTTP request")
+		return nil
+	}))
+
+	for _, hours := range []int{0, MaxForecastHours + 1} {
+		if _, err := client.GetHourlyForecast(context.Background(), 0, 0, hours, models.Units{}); err == nil {
+			t.Errorf("GetHourlyForecast(hours=%d): expected error, got nil", hours)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"weather-reporter/src/internal/models"
 	"weather-reporter/src/internal/weather"
 )
 
@@ -24,7 +25,7 @@ func TestClient_GetCurrentWeather_Integration(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	resp, err := client.GetCurrentWeather(ctx, lat, lon)
+	resp, err := client.GetCurrentWeather(ctx, lat, lon, models.Units{})
 	if err != nil {
 		t.Fatalf("Failed to get weather: %v", err)
 	}
@@ -59,7 +60,7 @@ func TestClient_GetCurrentWeather_Timeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
 	defer cancel()
 
-	_, err := client.GetCurrentWeather(ctx, lat, lon)
+	_, err := client.GetCurrentWeather(ctx, lat, lon, models.Units{})
 	if err == nil {
 		t.Error("Expected timeout error, got nil")
 	}
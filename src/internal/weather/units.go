@@ -0,0 +1,53 @@
+package weather
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"weather-reporter/src/internal/models"
+)
+
+type unitsKey struct{}
+
+// withUnits returns a context carrying the units to request.
+func withUnits(ctx context.Context, units models.Units) context.Context {
+	return context.WithValue(ctx, unitsKey{}, units)
+}
+
+// setUnitParams adds the upstream query parameters selecting units.
+// Empty units are left out so the API falls back to its metric defaults.
+func setUnitParams(params url.Values, units models.Units) {
+	if units.Temperature != "" {
+		params.Set("temperature_unit", units.Temperature)
+	}
+	if units.WindSpeed != "" {
+		params.Set("wind_speed_unit", units.WindSpeed)
+	}
+	if units.Precipitation != "" {
+		params.Set("precipitation_unit", units.Precipitation)
+	}
+}
+
+// unitsTransport adds the units carried by the request context to the
+// query of outgoing requests. It lets the units flow into requests built
+// by the SDK, which has no option for them.
+type unitsTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *unitsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if units, ok := req.Context().Value(unitsKey{}).(models.Units); ok {
+		req = req.Clone(req.Context())
+		q := req.URL.Query()
+		setUnitParams(q, units)
+		req.URL.RawQuery = q.Encode()
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}